@@ -0,0 +1,40 @@
+// Command clienttoken prints a token for a client which is not a user, e.g.
+//
+//	go run ./cmd/clienttoken -type service -client order-service -expires 720h
+//	go run ./cmd/clienttoken -type admin -client support-tool
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"userservice/config"
+	"userservice/infrastructure"
+	"userservice/internal/user/entity"
+)
+
+func main() {
+	clientType := flag.String("type", entity.ServiceClientType, "client type, service for the internal API, admin for the admin API or monitoring for the metrics")
+	clientName := flag.String("client", "", "name of the client, used as the token subject")
+	expires := flag.Duration("expires", 24*time.Hour, "how long the token is valid")
+	flag.Parse()
+
+	if *clientName == "" {
+		log.Fatal("-client is required")
+	}
+	switch *clientType {
+	case entity.ServiceClientType, entity.AdminClientType, entity.MonitoringClientType:
+	default:
+		log.Fatalf("-type must be %s, %s or %s", entity.ServiceClientType, entity.AdminClientType, entity.MonitoringClientType)
+	}
+
+	conf := config.NewConfig()
+	tokenProvider := infrastructure.NewClientTokenProvider(&conf.JWT, *clientType, int(expires.Seconds()))
+	token, err := tokenProvider.Generate(*clientName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(token.Token)
+}
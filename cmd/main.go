@@ -1,32 +1,270 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	// the runtime image has no zoneinfo, it is needed to validate user timezone
+	_ "time/tzdata"
+
 	"userservice/config"
 	"userservice/generated"
+	"userservice/generated/userpb"
+	"userservice/grpchandler"
 	"userservice/handler"
+	"userservice/infrastructure"
+	"userservice/internal/health"
+	"userservice/internal/logging"
+	"userservice/internal/tlsconfig"
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
 	custommiddleware "userservice/middleware"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	e := echo.New()
+	// the first SIGINT or SIGTERM starts the graceful shutdown, the second one stops the service at once
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	conf := config.NewConfig()
+	// the log package writes to the same logger, e.g. the errors of net/http
+	slog.SetDefault(logging.New(os.Stdout, &logging.Options{
+		Level:  conf.Log.Level,
+		Format: conf.Log.Format,
+	}))
 
-	server := newServer()
+	tracerProvider, err := tracing.Setup(context.Background(), &tracing.Options{
+		ServiceName: conf.Tracing.ServiceName,
+		Endpoint:    conf.Tracing.Endpoint,
+		Insecure:    conf.Tracing.Insecure,
+	})
+	if err != nil {
+		fatal("failed to set up tracing", err)
+	}
+
+	e := echo.New()
+	db := infrastructure.NewPostgreConnection(&conf.Postgres)
+	metrics := infrastructure.NewPrometheusMetrics(db)
+	server := handler.NewServer(&handler.ServerOptions{
+		Conf:    conf,
+		DB:      db,
+		Metrics: metrics,
+	})
 
+	swagger, err := generated.GetSwagger()
+	if err != nil {
+		fatal("failed to load api spec", err)
+	}
+	security, err := custommiddleware.NewSecurity(swagger, &custommiddleware.SecurityOptions{
+		Schemes: map[string]custommiddleware.SecurityScheme{
+			"bearerAuth":         custommiddleware.UserJWT(server.TokenProvider),
+			"serviceAuth":        custommiddleware.ClientJWT(server.TokenProvider, entity.ServiceClientType),
+			"serviceCertificate": custommiddleware.ClientCertificate(entity.ServiceClientType),
+			"adminAuth":          custommiddleware.ClientJWT(server.TokenProvider, entity.AdminClientType),
+			"monitoringAuth":     custommiddleware.ClientJWT(server.TokenProvider, entity.MonitoringClientType),
+			"apiKeyAuth":         custommiddleware.UserAPIKey(server.APIKeyUsecase),
+		},
+		PublicPaths: map[string][]string{
+			http.MethodGet: {"/blobs*"},
+		},
+		Metrics: metrics,
+	})
+	if err != nil {
+		fatal("failed to load api security", err)
+	}
 	e.Use(
+		// the request ID is returned with the errors to find their logs
+		custommiddleware.WithRequestID(),
+		custommiddleware.WithTracing(),
+		custommiddleware.WithAccessLog(swagger),
+		custommiddleware.WithMetrics(swagger, metrics),
 		middleware.Recover(),
-		middleware.Logger(),
-		custommiddleware.WithJwtAuth(server.TokenProvider),
+		middleware.BodyLimit(conf.Server.BodyLimit),
+		security.Middleware(),
+		custommiddleware.WithOpenAPIValidation(swagger, nil),
 	)
 
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	workers.Add(2)
+	go func() {
+		defer workers.Done()
+		server.OutboxRelay.Run(workerCtx)
+	}()
+	go func() {
+		defer workers.Done()
+		server.WebhookDispatcher.Run(workerCtx)
+	}()
+
 	generated.RegisterHandlers(e, server)
-	e.Logger.Fatal(e.Start(":8080"))
+	// the blobs written by infrastructure.FilesystemBlobStore
+	e.Static("/blobs", conf.Blob.Path)
+	if err := security.CheckRoutes(e.Routes()); err != nil {
+		fatal("failed to secure the routes", err)
+	}
+	httpServer := &http.Server{
+		Handler:           e,
+		ReadTimeout:       time.Duration(conf.Server.ReadTimeoutSecond) * time.Second,
+		ReadHeaderTimeout: time.Duration(conf.Server.ReadHeaderTimeoutSecond) * time.Second,
+		WriteTimeout:      time.Duration(conf.Server.WriteTimeoutSecond) * time.Second,
+		IdleTimeout:       time.Duration(conf.Server.IdleTimeoutSecond) * time.Second,
+		ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+	}
+	// a missing certificate or key fails instead of serving without TLS
+	if conf.Server.TLSCertPath != "" || conf.Server.TLSKeyPath != "" || conf.Server.TLSClientCAPath != "" {
+		reloader, err := tlsconfig.NewReloader(&tlsconfig.Options{
+			CertPath:          conf.Server.TLSCertPath,
+			KeyPath:           conf.Server.TLSKeyPath,
+			ClientCAPath:      conf.Server.TLSClientCAPath,
+			RequireClientCert: conf.Server.TLSRequireClientCert,
+		})
+		if err != nil {
+			fatal("failed to load tls certificate", err)
+		}
+		httpServer.TLSConfig = reloader.TLSConfig()
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			reloader.Run(workerCtx, time.Duration(conf.Server.TLSReloadIntervalSecond)*time.Second)
+		}()
+	}
+	go serveHTTP(httpServer, conf.Server.Address)
+
+	grpcServer, grpcHealth := newGRPCServer(conf, db, metrics)
+	go serveGRPC(grpcServer, conf.Server.GRPCAddress)
+
+	// the readiness fails until the startup is done
+	server.Health.SetPhase(health.PhaseServing)
+	<-ctx.Done()
+	stop()
+
+	slog.Info("shutting down", "delay_second", conf.Server.ShutdownDelaySecond, "timeout_second", conf.Server.ShutdownTimeoutSecond)
+	server.Health.SetPhase(health.PhaseStopping)
+	grpcHealth.Shutdown()
+	// the load balancers stop sending requests once they see the readiness failing
+	time.Sleep(time.Duration(conf.Server.ShutdownDelaySecond) * time.Second)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(conf.Server.ShutdownTimeoutSecond)*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("failed to drain http connections", "error", err)
+	}
+	stopGRPC(shutdownCtx, grpcServer)
+	stopWorkers()
+	if err := waitGroup(shutdownCtx, &workers); err != nil {
+		slog.Warn("failed to stop background workers", "error", err)
+	}
+	if err := db.Db.Close(); err != nil {
+		slog.Warn("failed to close database", "error", err)
+	}
+	if err := tracerProvider.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("failed to export spans", "error", err)
+	}
+	slog.Info("service stopped")
 }
 
-func newServer() *handler.Server {
-	return handler.NewServer(&handler.ServerOptions{
-		Conf: config.NewConfig(),
+// serveHTTP serves with TLS when the server has a TLS config, its certificate is reloaded by tlsconfig.Reloader.
+func serveHTTP(httpServer *http.Server, address string) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		fatal("failed to listen http", err)
+	}
+
+	slog.Info("http server started", "address", address, "tls", httpServer.TLSConfig != nil)
+	if httpServer.TLSConfig != nil {
+		err = httpServer.ServeTLS(listener, "", "")
+	} else {
+		err = httpServer.Serve(listener)
+	}
+	if !errors.Is(err, http.ErrServerClosed) {
+		fatal("failed to serve http", err)
+	}
+}
+
+// newGRPCServer serves the same user operations for the internal Go services,
+// the health service is set to NOT_SERVING at the shutdown.
+func newGRPCServer(conf *config.ApplicationConfig, db *infrastructure.PostgreConnection, metrics *infrastructure.PrometheusMetrics) (*grpc.Server, *grpchealth.Server) {
+	server := grpchandler.NewServer(&grpchandler.ServerOptions{
+		Conf:    conf,
+		DB:      db,
+		Metrics: metrics,
 	})
+	grpcServer := grpc.NewServer(
+		// the spans of the requests from their W3C trace context
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			custommiddleware.WithGRPCAccessLog(),
+			custommiddleware.WithGRPCJwtAuth(server.TokenProvider),
+		),
+	)
+	healthServer := grpchealth.NewServer()
+	userpb.RegisterUserServiceServer(grpcServer, server)
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+	return grpcServer, healthServer
+}
+
+func serveGRPC(grpcServer *grpc.Server, address string) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		fatal("failed to listen gRPC", err)
+	}
+	slog.Info("grpc server started", "address", address)
+	if err := grpcServer.Serve(listener); err != nil {
+		fatal("failed to serve gRPC", err)
+	}
+}
+
+// stopGRPC waits for the pending RPCs until the context is done, then closes their connections.
+func stopGRPC(ctx context.Context, grpcServer *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		slog.Warn("failed to drain grpc connections", "error", ctx.Err())
+		grpcServer.Stop()
+	}
+}
+
+// waitGroup waits for the group until the context is done.
+func waitGroup(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fatal logs the error which stops the service.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
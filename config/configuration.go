@@ -11,8 +11,42 @@ import (
 )
 
 type ApplicationConfig struct {
+	Server   Server   `mapstructure:"server"`
 	Postgres DBConfig `mapstructure:"postgres"`
 	JWT      JWT      `mapstructure:"jwt"`
+	Password Password `mapstructure:"password"`
+	Email    Email    `mapstructure:"email"`
+	Avatar   Avatar   `mapstructure:"avatar"`
+	Blob     Blob     `mapstructure:"blob"`
+	Username Username `mapstructure:"username"`
+	Internal Internal `mapstructure:"internal"`
+	Outbox   Outbox   `mapstructure:"outbox"`
+	Webhook  Webhook  `mapstructure:"webhook"`
+	Log      Log      `mapstructure:"log"`
+	Tracing  Tracing  `mapstructure:"tracing"`
+	Health   Health   `mapstructure:"health"`
+}
+
+type Server struct {
+	Address     string `mapstructure:"address"`
+	GRPCAddress string `mapstructure:"grpc_address"`
+	// the timeouts of net/http, ReadTimeout includes the body of the uploads
+	ReadTimeoutSecond       int `mapstructure:"read_timeout_second"`
+	ReadHeaderTimeoutSecond int `mapstructure:"read_header_timeout_second"`
+	WriteTimeoutSecond      int `mapstructure:"write_timeout_second"`
+	IdleTimeoutSecond       int `mapstructure:"idle_timeout_second"`
+	// BodyLimit is the max size of a request body, e.g. 8M, it must be above Avatar.MaxSizeByte
+	BodyLimit string `mapstructure:"body_limit"`
+	// ShutdownDelaySecond is how long the readiness fails before the listeners are closed
+	ShutdownDelaySecond   int `mapstructure:"shutdown_delay_second"`
+	ShutdownTimeoutSecond int `mapstructure:"shutdown_timeout_second"`
+	// the HTTP server is served with TLS when they are set, the files are reloaded on every interval
+	TLSCertPath             string `mapstructure:"tls_cert_path"`
+	TLSKeyPath              string `mapstructure:"tls_key_path"`
+	TLSReloadIntervalSecond int    `mapstructure:"tls_reload_interval_second"`
+	// TLSClientCAPath verifies the client certificates of the services, they are required only with TLSRequireClientCert
+	TLSClientCAPath      string `mapstructure:"tls_client_ca_path"`
+	TLSRequireClientCert bool   `mapstructure:"tls_require_client_cert"`
 }
 
 type DBConfig struct {
@@ -29,6 +63,70 @@ type JWT struct {
 	ExpiresSecond int    `mapstructure:"expires_second"`
 }
 
+type Password struct {
+	HistoryLimit int `mapstructure:"history_limit"`
+}
+
+type Email struct {
+	From                      string `mapstructure:"from"`
+	MaildirPath               string `mapstructure:"maildir_path"`
+	VerificationURL           string `mapstructure:"verification_url"`
+	VerificationSecret        string `mapstructure:"verification_secret"`
+	VerificationExpiresSecond int    `mapstructure:"verification_expires_second"`
+}
+
+type Avatar struct {
+	MaxSizeByte int `mapstructure:"max_size_byte"`
+}
+
+type Blob struct {
+	Path    string `mapstructure:"path"`
+	BaseURL string `mapstructure:"base_url"`
+}
+
+type Username struct {
+	ChangeIntervalDay int `mapstructure:"change_interval_day"`
+	RedirectGraceDay  int `mapstructure:"redirect_grace_day"`
+}
+
+type Internal struct {
+	BatchGetMaxSize int `mapstructure:"batch_get_max_size"`
+}
+
+type Outbox struct {
+	PublisherPath           string `mapstructure:"publisher_path"`
+	PollIntervalMillisecond int    `mapstructure:"poll_interval_millisecond"`
+	BatchSize               int    `mapstructure:"batch_size"`
+	LockSecond              int    `mapstructure:"lock_second"`
+}
+
+type Webhook struct {
+	TimeoutSecond           int `mapstructure:"timeout_second"`
+	PollIntervalMillisecond int `mapstructure:"poll_interval_millisecond"`
+	BatchSize               int `mapstructure:"batch_size"`
+	LockSecond              int `mapstructure:"lock_second"`
+	MaxAttempts             int `mapstructure:"max_attempts"`
+	BackoffBaseSecond       int `mapstructure:"backoff_base_second"`
+	DisableAfterFailures    int `mapstructure:"disable_after_failures"`
+	// AllowPrivateNetworks lets the webhooks reach the loopback and private addresses, e.g. a local receiver in development
+	AllowPrivateNetworks bool `mapstructure:"allow_private_networks"`
+}
+
+type Log struct {
+	Level  string `mapstructure:"level"`
+	Format string `mapstructure:"format"`
+}
+
+type Tracing struct {
+	ServiceName string `mapstructure:"service_name"`
+	Endpoint    string `mapstructure:"endpoint"`
+	Insecure    bool   `mapstructure:"insecure"`
+}
+
+type Health struct {
+	CheckTimeoutMillisecond int `mapstructure:"check_timeout_millisecond"`
+}
+
 var (
 	basepath string
 	conf     *ApplicationConfig
@@ -47,6 +145,7 @@ func NewConfig() *ApplicationConfig {
 		viper.AddConfigPath(basepath)
 		viper.AutomaticEnv()
 		viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+		setServerDefaults()
 		if err := viper.ReadInConfig(); err != nil {
 			panic(fmt.Errorf("failed to read config file: %s", err))
 		}
@@ -57,3 +156,17 @@ func NewConfig() *ApplicationConfig {
 	})
 	return conf
 }
+
+// setServerDefaults keeps the service listening on the usual ports with the timeouts set
+// when the server values are empty in config.yaml and in the environment.
+func setServerDefaults() {
+	viper.SetDefault("server.address", ":8080")
+	viper.SetDefault("server.grpc_address", ":9090")
+	viper.SetDefault("server.read_timeout_second", 30)
+	viper.SetDefault("server.read_header_timeout_second", 5)
+	viper.SetDefault("server.write_timeout_second", 30)
+	viper.SetDefault("server.idle_timeout_second", 120)
+	viper.SetDefault("server.body_limit", "8M")
+	viper.SetDefault("server.shutdown_timeout_second", 8)
+	viper.SetDefault("server.tls_reload_interval_second", 60)
+}
@@ -1,16 +1,27 @@
 BEGIN;
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
 
 CREATE TABLE users
 (
     id              UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
-    phone_number    VARCHAR(13)  UNIQUE NOT NULL,
+    phone_number    VARCHAR(16)  UNIQUE NOT NULL,
     full_name       VARCHAR(64)  NOT NULL,
     password        VARCHAR(255) NOT NULL,
+    email           VARCHAR(254),
+    email_verified_at TIMESTAMPTZ,
+    username        VARCHAR(30),
+    username_changed_at TIMESTAMPTZ,
     created_at      TIMESTAMPTZ  DEFAULT NOW(),
     updated_at      TIMESTAMPTZ  DEFAULT NOW()
 );
 
+CREATE UNIQUE INDEX users_lower_email_key ON users (LOWER(email)) WHERE email_verified_at IS NOT NULL;
+CREATE INDEX users_lower_email_idx ON users (LOWER(email));
+CREATE UNIQUE INDEX users_lower_username_key ON users (LOWER(username));
+CREATE INDEX users_full_name_trgm_idx ON users USING GIN (full_name gin_trgm_ops);
+CREATE INDEX users_phone_number_pattern_idx ON users (phone_number text_pattern_ops);
+
 COMMIT;
 
 CREATE TABLE user_tokens (
@@ -19,3 +30,126 @@ CREATE TABLE user_tokens (
     success_login_count INT         DEFAULT 0,
     last_login_at       TIMESTAMPTZ DEFAULT NOW()
 );
+
+CREATE TABLE password_history (
+    id          SERIAL       PRIMARY KEY,
+    user_id     UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password    VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMPTZ  DEFAULT NOW()
+);
+
+CREATE INDEX password_history_user_id_created_at_idx ON password_history (user_id, created_at DESC);
+
+CREATE TABLE user_profiles (
+    user_id             UUID          PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    avatar_url          VARCHAR(2048),
+    date_of_birth       DATE,
+    locale              VARCHAR(16),
+    timezone            VARCHAR(64),
+    address_street      VARCHAR(255),
+    address_city        VARCHAR(100),
+    address_province    VARCHAR(100),
+    address_postal_code VARCHAR(10),
+    address_country     CHAR(2),
+    created_at          TIMESTAMPTZ   DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ   DEFAULT NOW()
+);
+
+CREATE TABLE username_redirects (
+    username    VARCHAR(30)  PRIMARY KEY,
+    user_id     UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at  TIMESTAMPTZ  NOT NULL,
+    created_at  TIMESTAMPTZ  DEFAULT NOW()
+);
+
+CREATE TABLE user_privacy_settings (
+    user_id           UUID         PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    show_full_name    BOOLEAN      NOT NULL DEFAULT TRUE,
+    show_avatar       BOOLEAN      NOT NULL DEFAULT TRUE,
+    show_phone_number BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at        TIMESTAMPTZ  DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ  DEFAULT NOW()
+);
+
+CREATE TABLE outbox (
+    id            UUID         PRIMARY KEY,
+    event_type    VARCHAR(64)  NOT NULL,
+    aggregate_id  UUID         NOT NULL,
+    payload       JSONB        NOT NULL,
+    occurred_at   TIMESTAMPTZ  NOT NULL,
+    published_at  TIMESTAMPTZ,
+    locked_until  TIMESTAMPTZ,
+    attempts      INT          NOT NULL DEFAULT 0,
+    last_error    TEXT
+);
+
+CREATE INDEX outbox_unpublished_idx ON outbox (occurred_at) WHERE published_at IS NULL;
+
+CREATE TABLE webhook_subscriptions (
+    id                   UUID           PRIMARY KEY DEFAULT gen_random_uuid(),
+    partner_id           VARCHAR(64)    NOT NULL,
+    url                  VARCHAR(2048)  NOT NULL,
+    event_types          TEXT[]         NOT NULL,
+    secret               VARCHAR(255)   NOT NULL,
+    enabled              BOOLEAN        NOT NULL DEFAULT TRUE,
+    consecutive_failures INT            NOT NULL DEFAULT 0,
+    disabled_at          TIMESTAMPTZ,
+    created_at           TIMESTAMPTZ    DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ    DEFAULT NOW()
+);
+
+CREATE INDEX webhook_subscriptions_partner_id_idx ON webhook_subscriptions (partner_id);
+
+CREATE TABLE partner_users (
+    partner_id VARCHAR(64)  NOT NULL,
+    user_id    UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ  DEFAULT NOW(),
+    PRIMARY KEY (partner_id, user_id)
+);
+
+CREATE INDEX partner_users_user_id_idx ON partner_users (user_id);
+
+CREATE TABLE webhook_deliveries (
+    id              UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID         NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id        UUID         NOT NULL,
+    event_type      VARCHAR(64)  NOT NULL,
+    payload         JSONB        NOT NULL,
+    status          VARCHAR(16)  NOT NULL DEFAULT 'pending',
+    attempts        INT          NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    locked_until    TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ  DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ  DEFAULT NOW(),
+    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE webhook_delivery_attempts (
+    id              UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    delivery_id     UUID         NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    subscription_id UUID         NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    attempt         INT          NOT NULL,
+    status_code     INT          NOT NULL,
+    error           TEXT,
+    duration_ms     INT          NOT NULL,
+    created_at      TIMESTAMPTZ  DEFAULT NOW()
+);
+
+CREATE INDEX webhook_delivery_attempts_subscription_idx ON webhook_delivery_attempts (subscription_id, created_at DESC);
+
+CREATE TABLE api_keys (
+    id           UUID          PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID          NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         VARCHAR(100)  NOT NULL,
+    prefix       VARCHAR(32)   NOT NULL UNIQUE,
+    hash         VARCHAR(64)   NOT NULL,
+    scopes       TEXT[]        NOT NULL,
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ   DEFAULT NOW()
+);
+
+CREATE INDEX api_keys_user_id_idx ON api_keys (user_id, created_at DESC) WHERE revoked_at IS NULL;
@@ -0,0 +1,8 @@
+CREATE TABLE password_history (
+    id          SERIAL       PRIMARY KEY,
+    user_id     UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password    VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMPTZ  DEFAULT NOW()
+);
+
+CREATE INDEX password_history_user_id_created_at_idx ON password_history (user_id, created_at DESC);
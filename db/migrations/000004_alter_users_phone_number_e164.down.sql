@@ -0,0 +1 @@
+ALTER TABLE users ALTER COLUMN phone_number TYPE VARCHAR(13);
@@ -0,0 +1,2 @@
+-- E.164 allows up to 15 digits after the leading '+'
+ALTER TABLE users ALTER COLUMN phone_number TYPE VARCHAR(16);
@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN email             VARCHAR(254),
+    ADD COLUMN email_verified_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX users_lower_email_key ON users (LOWER(email));
@@ -0,0 +1,14 @@
+CREATE TABLE user_profiles (
+    user_id             UUID          PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    avatar_url          VARCHAR(2048),
+    date_of_birth       DATE,
+    locale              VARCHAR(16),
+    timezone            VARCHAR(64),
+    address_street      VARCHAR(255),
+    address_city        VARCHAR(100),
+    address_province    VARCHAR(100),
+    address_postal_code VARCHAR(10),
+    address_country     CHAR(2),
+    created_at          TIMESTAMPTZ   DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ   DEFAULT NOW()
+);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS username_redirects;
+
+DROP INDEX IF EXISTS users_lower_username_key;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS username,
+    DROP COLUMN IF EXISTS username_changed_at;
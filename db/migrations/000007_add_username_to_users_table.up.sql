@@ -0,0 +1,12 @@
+ALTER TABLE users
+    ADD COLUMN username            VARCHAR(30),
+    ADD COLUMN username_changed_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX users_lower_username_key ON users (LOWER(username));
+
+CREATE TABLE username_redirects (
+    username    VARCHAR(30)  PRIMARY KEY,
+    user_id     UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at  TIMESTAMPTZ  NOT NULL,
+    created_at  TIMESTAMPTZ  DEFAULT NOW()
+);
@@ -0,0 +1,8 @@
+CREATE TABLE user_privacy_settings (
+    user_id           UUID         PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    show_full_name    BOOLEAN      NOT NULL DEFAULT TRUE,
+    show_avatar       BOOLEAN      NOT NULL DEFAULT TRUE,
+    show_phone_number BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at        TIMESTAMPTZ  DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ  DEFAULT NOW()
+);
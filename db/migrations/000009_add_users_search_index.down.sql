@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS users_phone_number_pattern_idx;
+DROP INDEX IF EXISTS users_full_name_trgm_idx;
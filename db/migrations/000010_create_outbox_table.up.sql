@@ -0,0 +1,13 @@
+CREATE TABLE outbox (
+    id            UUID         PRIMARY KEY,
+    event_type    VARCHAR(64)  NOT NULL,
+    aggregate_id  UUID         NOT NULL,
+    payload       JSONB        NOT NULL,
+    occurred_at   TIMESTAMPTZ  NOT NULL,
+    published_at  TIMESTAMPTZ,
+    locked_until  TIMESTAMPTZ,
+    attempts      INT          NOT NULL DEFAULT 0,
+    last_error    TEXT
+);
+
+CREATE INDEX outbox_unpublished_idx ON outbox (occurred_at) WHERE published_at IS NULL;
@@ -0,0 +1,41 @@
+CREATE TABLE webhook_subscriptions (
+    id                   UUID           PRIMARY KEY DEFAULT gen_random_uuid(),
+    url                  VARCHAR(2048)  NOT NULL,
+    event_types          TEXT[]         NOT NULL,
+    secret               VARCHAR(255)   NOT NULL,
+    enabled              BOOLEAN        NOT NULL DEFAULT TRUE,
+    consecutive_failures INT            NOT NULL DEFAULT 0,
+    disabled_at          TIMESTAMPTZ,
+    created_at           TIMESTAMPTZ    DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ    DEFAULT NOW()
+);
+
+CREATE TABLE webhook_deliveries (
+    id              UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID         NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id        UUID         NOT NULL,
+    event_type      VARCHAR(64)  NOT NULL,
+    payload         JSONB        NOT NULL,
+    status          VARCHAR(16)  NOT NULL DEFAULT 'pending',
+    attempts        INT          NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    locked_until    TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ  DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ  DEFAULT NOW(),
+    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE webhook_delivery_attempts (
+    id              UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    delivery_id     UUID         NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    subscription_id UUID         NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    attempt         INT          NOT NULL,
+    status_code     INT          NOT NULL,
+    error           TEXT,
+    duration_ms     INT          NOT NULL,
+    created_at      TIMESTAMPTZ  DEFAULT NOW()
+);
+
+CREATE INDEX webhook_delivery_attempts_subscription_idx ON webhook_delivery_attempts (subscription_id, created_at DESC);
@@ -0,0 +1,14 @@
+CREATE TABLE api_keys (
+    id           UUID          PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID          NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         VARCHAR(100)  NOT NULL,
+    prefix       VARCHAR(32)   NOT NULL UNIQUE,
+    hash         VARCHAR(64)   NOT NULL,
+    scopes       TEXT[]        NOT NULL,
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ   DEFAULT NOW()
+);
+
+CREATE INDEX api_keys_user_id_idx ON api_keys (user_id, created_at DESC) WHERE revoked_at IS NULL;
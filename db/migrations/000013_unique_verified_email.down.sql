@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS users_lower_email_idx;
+DROP INDEX IF EXISTS users_lower_email_key;
+
+CREATE UNIQUE INDEX users_lower_email_key ON users (LOWER(email));
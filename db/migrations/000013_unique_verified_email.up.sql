@@ -0,0 +1,5 @@
+-- an unverified email could be claimed by anyone, only the verified one is unique
+DROP INDEX IF EXISTS users_lower_email_key;
+
+CREATE UNIQUE INDEX users_lower_email_key ON users (LOWER(email)) WHERE email_verified_at IS NOT NULL;
+CREATE INDEX users_lower_email_idx ON users (LOWER(email));
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS partner_users;
+
+DROP INDEX IF EXISTS webhook_subscriptions_partner_id_idx;
+
+ALTER TABLE webhook_subscriptions
+    DROP COLUMN IF EXISTS partner_id;
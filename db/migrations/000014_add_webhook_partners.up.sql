@@ -0,0 +1,16 @@
+-- the existing subscriptions have no partner, so they receive no event until they are created again for a partner
+ALTER TABLE webhook_subscriptions
+    ADD COLUMN partner_id VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE webhook_subscriptions
+    ALTER COLUMN partner_id DROP DEFAULT;
+
+CREATE INDEX webhook_subscriptions_partner_id_idx ON webhook_subscriptions (partner_id);
+
+CREATE TABLE partner_users (
+    partner_id VARCHAR(64)  NOT NULL,
+    user_id    UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ  DEFAULT NOW(),
+    PRIMARY KEY (partner_id, user_id)
+);
+
+CREATE INDEX partner_users_user_id_idx ON partner_users (user_id);
@@ -0,0 +1,24 @@
+package grpchandler
+
+import (
+	"context"
+
+	"userservice/generated/userpb"
+	"userservice/internal/user/param/request"
+)
+
+// CreateUser implements userpb.UserServiceServer.
+func (s *Server) CreateUser(ctx context.Context, req *userpb.CreateUserRequest) (*userpb.CreateUserResponse, error) {
+	id, err := s.userUsecase.CreateUser(ctx, &request.CreateUser{
+		PhoneNumber: req.GetPhoneNumber(),
+		FullName:    req.GetFullName(),
+		Password:    req.GetPassword(),
+	})
+	if err != nil {
+		return nil, parseError(ctx, err)
+	}
+
+	return &userpb.CreateUserResponse{
+		Id: id,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package grpchandler
+
+import (
+	"context"
+	"testing"
+
+	"userservice/generated/userpb"
+	"userservice/test/fake"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_CreateUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *userpb.CreateUserRequest
+		wantCode codes.Code
+	}{
+		{
+			name:     "when input is invalid, it should return invalid argument",
+			req:      &userpb.CreateUserRequest{FullName: "John Doe", Password: "Secret123!"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "when usecase failed, it should return internal",
+			req:      &userpb.CreateUserRequest{PhoneNumber: "11111111", FullName: "John Doe", Password: "Secret123!"},
+			wantCode: codes.Internal,
+		},
+		{
+			name:     "when user created, it should not need token",
+			req:      &userpb.CreateUserRequest{PhoneNumber: "+628123456789", FullName: "John Doe", Password: "Secret123!"},
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, &Server{
+				userUsecase: fake.NewFakeUserUsecase(),
+			})
+
+			got, err := client.CreateUser(context.Background(), tt.req)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.NotEmpty(t, got.Id)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/logging"
+	"userservice/internal/user/port/driven"
+
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// parseError maps the errors like handler.parseError does for the HTTP API,
+// the validation errors are listed as field violations of errdetails.BadRequest.
+func parseError(ctx context.Context, err error) error {
+	switch parsedError := err.(type) {
+	case *customerror.ValidationError:
+		return parseValidationError(parsedError)
+	case *pq.Error:
+		if parsedError.Code == "23505" {
+			return status.Error(codes.AlreadyExists, "duplicate violation on unique constraint")
+		}
+		return parseDefaultError(ctx, parsedError)
+	default:
+		if errors.Is(parsedError, driven.ErrNotFound) {
+			return status.Error(codes.NotFound, driven.ErrNotFound.Error())
+		}
+		return parseDefaultError(ctx, parsedError)
+	}
+}
+
+// parseDefaultError logs the error like handler.parseDefaultError does, the clients never see its details.
+func parseDefaultError(ctx context.Context, err error) error {
+	logging.FromContext(ctx).Error("request failed", "error", err)
+	return status.Error(codes.Internal, "An unexpected error occurred.")
+}
+
+func parseValidationError(err *customerror.ValidationError) error {
+	badRequest := &errdetails.BadRequest{}
+	for _, fieldError := range err.Errors() {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldError.Field,
+			Description: fieldError.Message(customerror.LanguageEnglish),
+			Reason:      fieldError.Code,
+		})
+	}
+
+	st, detailErr := status.New(codes.InvalidArgument, "ValidationError").WithDetails(badRequest)
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
@@ -0,0 +1,89 @@
+package grpchandler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/logging"
+	"userservice/internal/user/port/driven"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{
+			name:     "when validation error, it should return invalid argument",
+			err:      customerror.NewValidationErrorWithCode("phoneNumber", customerror.CodeInvalidPhoneNumber, nil),
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "when unique violation, it should return already exists",
+			err:      &pq.Error{Code: "23505"},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name:        "when other database error, it should return internal",
+			err:         &pq.Error{Code: "57014", Message: "canceling statement due to statement timeout"},
+			wantCode:    codes.Internal,
+			wantMessage: "An unexpected error occurred.",
+		},
+		{
+			name:     "when not found, it should return not found",
+			err:      fmt.Errorf("get user: %w", driven.ErrNotFound),
+			wantCode: codes.NotFound,
+		},
+		{
+			name:        "when unexpected error, it should return internal",
+			err:         errors.New("dial tcp 10.0.0.5:5432: connection refused"),
+			wantCode:    codes.Internal,
+			wantMessage: "An unexpected error occurred.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			ctx := logging.WithLogger(context.Background(), logging.New(&buf, &logging.Options{}))
+
+			st := status.Convert(parseError(ctx, tt.err))
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantCode, st.Code())
+			if tt.wantMessage != "" {
+				// the internal errors are logged instead of returned to the client
+				assert.Equal(tt.wantMessage, st.Message())
+				assert.Contains(buf.String(), tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestParseError_ValidationDetails(t *testing.T) {
+	validationError := customerror.NewValidationError()
+	validationError.AddError("password", customerror.CodeMinLength, customerror.Params{"min": 6})
+	validationError.AddError("password", customerror.CodePasswordComplexity, nil)
+
+	st := status.Convert(parseError(context.Background(), validationError))
+
+	assert := assert.New(t)
+	assert.Len(st.Details(), 1)
+	badRequest := st.Details()[0].(*errdetails.BadRequest)
+	assert.Len(badRequest.FieldViolations, 2)
+	assert.Equal("password", badRequest.FieldViolations[0].Field)
+	assert.Equal("must be at least 6 characters in length", badRequest.FieldViolations[0].Description)
+	assert.Equal(customerror.CodeMinLength, badRequest.FieldViolations[0].Reason)
+	assert.Equal(customerror.CodePasswordComplexity, badRequest.FieldViolations[1].Reason)
+}
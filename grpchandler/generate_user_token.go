@@ -0,0 +1,26 @@
+package grpchandler
+
+import (
+	"context"
+
+	"userservice/generated/userpb"
+	"userservice/internal/user/param/request"
+)
+
+// GenerateUserToken implements userpb.UserServiceServer.
+func (s *Server) GenerateUserToken(ctx context.Context, req *userpb.GenerateUserTokenRequest) (*userpb.GenerateUserTokenResponse, error) {
+	token, err := s.userUsecase.GenerateUserToken(ctx, &request.GenerateUserTokenRequest{
+		PhoneNumber: req.GetPhoneNumber(),
+		Email:       req.GetEmail(),
+		Password:    req.GetPassword(),
+	})
+	if err != nil {
+		return nil, parseError(ctx, err)
+	}
+
+	return &userpb.GenerateUserTokenResponse{
+		AccessToken: token.Token,
+		ExpiresIn:   int32(token.ExpiresIn),
+		Type:        token.Type,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package grpchandler
+
+import (
+	"context"
+	"testing"
+
+	"userservice/generated/userpb"
+	"userservice/test/fake"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestServer_GenerateUserToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *userpb.GenerateUserTokenRequest
+		wantCode codes.Code
+	}{
+		{
+			name:     "when authentication failed, it should return invalid argument",
+			req:      &userpb.GenerateUserTokenRequest{PhoneNumber: proto.String("0000000"), Password: "Secret123!"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "when token generated, it should return the token",
+			req:      &userpb.GenerateUserTokenRequest{PhoneNumber: proto.String("+628123456789"), Password: "Secret123!"},
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, &Server{
+				userUsecase: fake.NewFakeUserUsecase(),
+			})
+
+			got, err := client.GenerateUserToken(context.Background(), tt.req)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.NotEmpty(t, got.AccessToken)
+				assert.Equal(t, int32(3600), got.ExpiresIn)
+				assert.Equal(t, "Bearer", got.Type)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package grpchandler
+
+import (
+	"context"
+
+	"userservice/generated/userpb"
+	"userservice/internal/auth"
+)
+
+// GetCurrentUser implements userpb.UserServiceServer.
+func (s *Server) GetCurrentUser(ctx context.Context, req *userpb.GetCurrentUserRequest) (*userpb.GetCurrentUserResponse, error) {
+	userID := auth.UserID(ctx)
+	user, err := s.userGetter.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, parseError(ctx, err)
+	}
+
+	return &userpb.GetCurrentUserResponse{
+		FullName:      user.FullName,
+		PhoneNumber:   user.PhoneNumber,
+		Email:         optionalString(user.Email),
+		EmailVerified: user.EmailVerified,
+		AvatarUrl:     optionalString(user.AvatarURL),
+		Username:      optionalString(user.Username),
+	}, nil
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,61 @@
+package grpchandler
+
+import (
+	"context"
+	"testing"
+
+	"userservice/generated/userpb"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_GetCurrentUser(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	id, _ := fu.CreateUser(context.Background(), &request.CreateUser{
+		PhoneNumber: "+628123456789",
+		FullName:    "John Doe",
+		Password:    "Secret123!",
+	})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{
+			name:     "when token is missing, it should return unauthenticated",
+			ctx:      context.Background(),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "when user is not found, it should return not found",
+			ctx:      withToken("1232131"),
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "when user is found, it should return the current user",
+			ctx:      withToken(id),
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, &Server{
+				userGetter: fu,
+			})
+
+			got, err := client.GetCurrentUser(tt.ctx, &userpb.GetCurrentUserRequest{})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, "John Doe", got.FullName)
+				assert.Equal(t, "+628123456789", got.PhoneNumber)
+				assert.Nil(t, got.Email)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package grpchandler
+
+import (
+	"context"
+
+	"userservice/generated/userpb"
+	customerror "userservice/internal/custom_error"
+
+	"github.com/google/uuid"
+)
+
+// GetUserByID implements userpb.UserServiceServer.
+func (s *Server) GetUserByID(ctx context.Context, req *userpb.GetUserByIDRequest) (*userpb.GetUserByIDResponse, error) {
+	// the HTTP API validates the id with the openapi format, it is done here instead
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, parseError(ctx, customerror.NewValidationErrorWithCode("id", customerror.CodeInvalidUUID, nil))
+	}
+
+	publicProfile, err := s.userGetter.GetPublicProfileByID(ctx, req.GetId())
+	if err != nil {
+		return nil, parseError(ctx, err)
+	}
+
+	return &userpb.GetUserByIDResponse{
+		Id:          publicProfile.ID,
+		Username:    optionalString(publicProfile.Username),
+		FullName:    optionalString(publicProfile.FullName),
+		AvatarUrl:   optionalString(publicProfile.AvatarURL),
+		PhoneNumber: optionalString(publicProfile.PhoneNumber),
+	}, nil
+}
@@ -0,0 +1,55 @@
+package grpchandler
+
+import (
+	"context"
+	"testing"
+
+	"userservice/generated/userpb"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_GetUserByID(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	id, _ := fu.CreateUser(context.Background(), &request.CreateUser{
+		PhoneNumber: "+628123456789",
+		FullName:    "John Doe",
+		Password:    "Secret123!",
+	})
+
+	tests := []struct {
+		name     string
+		id       string
+		wantCode codes.Code
+	}{
+		{
+			name:     "when id is not a UUID, it should return invalid argument",
+			id:       "not-a-uuid",
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "when user is found, it should return the public profile without token",
+			id:       id,
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, &Server{
+				userGetter: fu,
+			})
+
+			got, err := client.GetUserByID(context.Background(), &userpb.GetUserByIDRequest{Id: tt.id})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, id, got.Id)
+				assert.Equal(t, "John Doe", got.GetFullName())
+			}
+		})
+	}
+}
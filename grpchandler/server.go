@@ -0,0 +1,44 @@
+package grpchandler
+
+import (
+	"userservice/config"
+	"userservice/generated/userpb"
+	"userservice/infrastructure"
+	"userservice/internal/user/port/driver"
+	"userservice/internal/user/usecase"
+)
+
+var _ userpb.UserServiceServer = new(Server)
+
+// Server implements the gRPC user service with the same usecases as handler.Server.
+type Server struct {
+	userpb.UnimplementedUserServiceServer
+
+	TokenProvider *infrastructure.UserTokenProvider
+
+	userUsecase driver.UserUsecase
+	userGetter  driver.UserGetterUsecase
+}
+
+type ServerOptions struct {
+	Conf    *config.ApplicationConfig
+	DB      *infrastructure.PostgreConnection
+	Metrics *infrastructure.PrometheusMetrics
+}
+
+func NewServer(opt *ServerOptions) *Server {
+	userDB := infrastructure.NewUserDB(opt.DB)
+	tokenProvider := infrastructure.NewUserTokenProvider(&opt.Conf.JWT)
+
+	return &Server{
+		userUsecase: usecase.NewUserUsecase(
+			userDB,
+			infrastructure.NewBcryptEncryption(opt.Metrics),
+			userDB,
+			tokenProvider,
+			opt.Metrics,
+		),
+		userGetter:    usecase.NewUserGetterUsecase(userDB),
+		TokenProvider: tokenProvider,
+	}
+}
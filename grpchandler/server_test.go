@@ -0,0 +1,44 @@
+package grpchandler
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"userservice/generated/userpb"
+	"userservice/middleware"
+	"userservice/test/fake"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves the server with the auth interceptor in memory.
+func newTestClient(t *testing.T, server *Server) userpb.UserServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(middleware.WithGRPCJwtAuth(new(fake.FakeTokenProvider))),
+	)
+	userpb.RegisterUserServiceServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return userpb.NewUserServiceClient(conn)
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
@@ -0,0 +1,26 @@
+package grpchandler
+
+import (
+	"context"
+
+	"userservice/generated/userpb"
+	"userservice/internal/auth"
+	"userservice/internal/user/param/request"
+)
+
+// UpdateCurrentUser implements userpb.UserServiceServer.
+func (s *Server) UpdateCurrentUser(ctx context.Context, req *userpb.UpdateCurrentUserRequest) (*userpb.UpdateCurrentUserResponse, error) {
+	userID := auth.UserID(ctx)
+	user, err := s.userUsecase.UpdateProfileByID(ctx, userID, &request.UpdateProfile{
+		PhoneNumber: req.PhoneNumber,
+		FullName:    req.FullName,
+	})
+	if err != nil {
+		return nil, parseError(ctx, err)
+	}
+
+	return &userpb.UpdateCurrentUserResponse{
+		FullName:    user.FullName,
+		PhoneNumber: user.PhoneNumber,
+	}, nil
+}
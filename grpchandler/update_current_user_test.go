@@ -0,0 +1,67 @@
+package grpchandler
+
+import (
+	"context"
+	"testing"
+
+	"userservice/generated/userpb"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestServer_UpdateCurrentUser(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	id, _ := fu.CreateUser(context.Background(), &request.CreateUser{
+		PhoneNumber: "+628123456789",
+		FullName:    "John Doe",
+		Password:    "Secret123!",
+	})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{
+			name:     "when phone number is used by another user, it should return already exists",
+			ctx:      withToken("3333"),
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name:     "when usecase failed, it should return internal",
+			ctx:      withToken("1232131"),
+			wantCode: codes.Internal,
+		},
+		{
+			name:     "when service token used, it should return permission denied",
+			ctx:      withToken("service-token"),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "when profile updated, it should return the profile",
+			ctx:      withToken(id),
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, &Server{
+				userUsecase: fu,
+			})
+
+			got, err := client.UpdateCurrentUser(tt.ctx, &userpb.UpdateCurrentUserRequest{
+				FullName: proto.String("John Doe"),
+			})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, "John Doe", got.FullName)
+			}
+		})
+	}
+}
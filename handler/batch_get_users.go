@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/user/param/request"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// BatchGetUsers implements generated.ServerInterface.
+func (s *Server) BatchGetUsers(ctx echo.Context) error {
+	var params generated.BatchGetUsersRequest
+	if err := ctx.Bind(&params); err != nil {
+		return parseError(ctx, err)
+	}
+
+	batchParams := &request.BatchGetUsers{}
+	if params.Ids != nil {
+		for _, id := range *params.Ids {
+			batchParams.IDs = append(batchParams.IDs, id.String())
+		}
+	}
+	if params.PhoneNumbers != nil {
+		batchParams.PhoneNumbers = *params.PhoneNumbers
+	}
+
+	result, err := s.internalUserUsecase.BatchGetUsers(ctx.Request().Context(), batchParams)
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	response := generated.BatchGetUsersResponse{
+		Users:               make([]generated.InternalUser, 0, len(result.Users)),
+		MissingIds:          make([]openapi_types.UUID, 0, len(result.MissingIDs)),
+		MissingPhoneNumbers: result.MissingPhoneNumbers,
+	}
+	for _, user := range result.Users {
+		response.Users = append(response.Users, generated.InternalUser{
+			Id:            uuid.MustParse(user.ID),
+			FullName:      user.FullName,
+			PhoneNumber:   user.PhoneNumber,
+			Email:         optionalString(user.Email),
+			EmailVerified: user.EmailVerified,
+			AvatarUrl:     optionalString(user.AvatarURL),
+			Username:      optionalString(user.Username),
+		})
+	}
+	for _, id := range result.MissingIDs {
+		response.MissingIds = append(response.MissingIds, uuid.MustParse(id))
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
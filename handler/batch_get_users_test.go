@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_BatchGetUsers_CannotBindBody(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/internal/users:batchGet",
+		strings.NewReader(`{"ids": "not an array"}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		internalUserUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.BatchGetUsers(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("RequestBodyError", response.Type)
+}
+
+func TestServer_BatchGetUsers_InvalidInput(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/internal/users:batchGet",
+		strings.NewReader(`{}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		internalUserUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.BatchGetUsers(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("ids", response.Messages[0].Name)
+}
+
+func TestServer_BatchGetUsers_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	params.PhoneNumber = "+628123456789"
+	id, _ := fu.CreateUser(context.Background(), params)
+	missingID := uuid.NewString()
+
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/internal/users:batchGet",
+		strings.NewReader(`{"ids": ["`+id+`", "`+missingID+`"], "phoneNumbers": ["+628123456789", "+628111111111"]}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		internalUserUsecase: fu,
+	}
+
+	err := server.BatchGetUsers(ctx)
+	assert.NoError(err)
+
+	var response generated.BatchGetUsersResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Len(response.Users, 2)
+	assert.Equal(id, response.Users[0].Id.String())
+	assert.Equal(params.FullName, response.Users[0].FullName)
+	assert.Len(response.MissingIds, 1)
+	assert.Equal(missingID, response.MissingIds[0].String())
+	assert.Equal([]string{"+628111111111"}, response.MissingPhoneNumbers)
+}
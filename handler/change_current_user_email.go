@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/param/request"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ChangeCurrentUserEmail implements generated.ServerInterface.
+func (s *Server) ChangeCurrentUserEmail(ctx echo.Context) error {
+	var params generated.ChangeEmailRequest
+	if err := ctx.Bind(&params); err != nil {
+		return parseError(ctx, err)
+	}
+	userID := auth.UserID(ctx.Request().Context())
+	err := s.emailUsecase.ChangeEmailByID(ctx.Request().Context(), userID, &request.ChangeEmail{
+		Email: params.Email,
+	})
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusAccepted)
+}
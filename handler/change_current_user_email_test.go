@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_ChangeCurrentUserEmail_CannotBindBody(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/email",
+		strings.NewReader(`{"Email": "john@example.com",}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	fu := fake.NewFakeUserUsecase()
+	server := &Server{
+		emailUsecase: fu,
+	}
+
+	err := server.ChangeCurrentUserEmail(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("RequestBodyError", response.Type)
+}
+
+func TestServer_ChangeCurrentUserEmail_ErrValidation(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	id, _ := fu.CreateUser(context.Background(), params)
+
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/email",
+		strings.NewReader(`{"Email": ""}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		emailUsecase: fu,
+	}
+
+	err := server.ChangeCurrentUserEmail(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("ValidationError", response.Type)
+}
+
+func TestServer_ChangeCurrentUserEmail_DuplicateError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/email",
+		strings.NewReader(`{"Email": "john@example.com"}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "3333"}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	fu := fake.NewFakeUserUsecase()
+	server := &Server{
+		emailUsecase: fu,
+	}
+
+	err := server.ChangeCurrentUserEmail(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusConflict, rec.Code)
+	assert.Equal("DuplicateResource", response.Type)
+}
+
+func TestServer_ChangeCurrentUserEmail_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	id, _ := fu.CreateUser(context.Background(), params)
+
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/email",
+		strings.NewReader(`{"Email": "john@example.com"}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		emailUsecase: fu,
+	}
+
+	err := server.ChangeCurrentUserEmail(ctx)
+	assert.NoError(err)
+	assert.Equal(http.StatusAccepted, rec.Code)
+
+	user, _ := fu.GetUserByID(context.Background(), id)
+	assert.Equal("john@example.com", user.Email)
+	assert.False(user.EmailVerified)
+}
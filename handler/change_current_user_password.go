@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/param/request"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ChangeCurrentUserPassword implements generated.ServerInterface.
+func (s *Server) ChangeCurrentUserPassword(ctx echo.Context) error {
+	var params generated.ChangePasswordRequest
+	if err := ctx.Bind(&params); err != nil {
+		return parseError(ctx, err)
+	}
+	userID := auth.UserID(ctx.Request().Context())
+	err := s.passwordUsecase.ChangePasswordByID(ctx.Request().Context(), userID, &request.ChangePassword{
+		CurrentPassword: params.CurrentPassword,
+		NewPassword:     params.NewPassword,
+	})
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_ChangeCurrentUserPassword_CannotBindBody(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/password",
+		strings.NewReader(`{"CurrentPassword": "password123", "NewPassword": "Password123!",}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	fu := fake.NewFakeUserUsecase()
+	server := &Server{
+		passwordUsecase: fu,
+	}
+
+	err := server.ChangeCurrentUserPassword(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("RequestBodyError", response.Type)
+}
+
+func TestServer_ChangeCurrentUserPassword_WrongCurrentPassword(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	id, _ := fu.CreateUser(context.Background(), params)
+
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/password",
+		strings.NewReader(`{"CurrentPassword": "0000000", "NewPassword": "Password123!"}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		passwordUsecase: fu,
+	}
+
+	err := server.ChangeCurrentUserPassword(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("ValidationError", response.Type)
+}
+
+func TestServer_ChangeCurrentUserPassword_UnexpectedError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/password",
+		strings.NewReader(`{"CurrentPassword": "password123", "NewPassword": "Password123!"}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "1232131"}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	fu := fake.NewFakeUserUsecase()
+	server := &Server{
+		passwordUsecase: fu,
+	}
+
+	err := server.ChangeCurrentUserPassword(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusInternalServerError, rec.Code)
+	assert.Equal("InternalServerError", response.Type)
+}
+
+func TestServer_ChangeCurrentUserPassword_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	id, _ := fu.CreateUser(context.Background(), params)
+
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/password",
+		strings.NewReader(`{"CurrentPassword": "password123", "NewPassword": "Password123!"}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		passwordUsecase: fu,
+	}
+
+	err := server.ChangeCurrentUserPassword(ctx)
+	assert.NoError(err)
+	assert.Equal(http.StatusNoContent, rec.Code)
+	assert.Empty(rec.Body.Bytes())
+}
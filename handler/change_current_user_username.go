@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// ChangeCurrentUserUsername implements generated.ServerInterface.
+func (s *Server) ChangeCurrentUserUsername(ctx echo.Context) error {
+	var params generated.ChangeUsernameRequest
+	if err := ctx.Bind(&params); err != nil {
+		return parseError(ctx, err)
+	}
+	userID := auth.UserID(ctx.Request().Context())
+	user, err := s.usernameUsecase.ChangeUsernameByID(ctx.Request().Context(), userID, &request.ChangeUsername{
+		Username: params.Username,
+	})
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, parseUsernameResponse(user))
+}
+
+func parseUsernameResponse(user *entity.User) generated.UsernameResponse {
+	uuid, _ := uuid.Parse(user.ID)
+	return generated.UsernameResponse{
+		Id:       uuid,
+		Username: user.Username,
+	}
+}
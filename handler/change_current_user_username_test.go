@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_ChangeCurrentUserUsername_CannotBindBody(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/username",
+		strings.NewReader(`{"username": "john_doe",}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		usernameUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.ChangeCurrentUserUsername(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("RequestBodyError", response.Type)
+}
+
+func TestServer_ChangeCurrentUserUsername_ErrValidation(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/username",
+		strings.NewReader(`{"username": "admin"}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: faker.UUIDHyphenated()}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		usernameUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.ChangeCurrentUserUsername(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("ValidationError", response.Type)
+	assert.Equal("username", response.Messages[0].Name)
+}
+
+func TestServer_ChangeCurrentUserUsername_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	id, _ := fu.CreateUser(context.Background(), params)
+
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/username",
+		strings.NewReader(`{"username": "john_doe"}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		usernameUsecase: fu,
+	}
+
+	err := server.ChangeCurrentUserUsername(ctx)
+	assert.NoError(err)
+
+	var response generated.UsernameResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal(id, response.Id.String())
+	assert.Equal("john_doe", response.Username)
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"userservice/generated"
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/problem"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CheckUsernameAvailability implements generated.ServerInterface.
+// The unavailable username is not an error of the request, so the reason is returned with 200.
+func (s *Server) CheckUsernameAvailability(ctx echo.Context, username string) error {
+	err := s.usernameUsecase.CheckUsernameAvailability(ctx.Request().Context(), username)
+	if validationError, ok := err.(*customerror.ValidationError); ok {
+		var reasons []string
+		for _, item := range problem.ValidationItems(ctx, validationError) {
+			reasons = append(reasons, item.Reason)
+		}
+		reason := strings.Join(reasons, ", ")
+		return ctx.JSON(http.StatusOK, generated.UsernameAvailabilityResponse{
+			Username:  username,
+			Available: false,
+			Reason:    &reason,
+		})
+	} else if err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.UsernameAvailabilityResponse{
+		Username:  username,
+		Available: true,
+	})
+}
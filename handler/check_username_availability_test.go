@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_CheckUsernameAvailability_Available(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/usernames/john_doe/availability", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		usernameUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.CheckUsernameAvailability(ctx, "john_doe")
+	assert.NoError(err)
+
+	var response generated.UsernameAvailabilityResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal("john_doe", response.Username)
+	assert.True(response.Available)
+	assert.Nil(response.Reason)
+}
+
+func TestServer_CheckUsernameAvailability_Taken(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	id, _ := fu.CreateUser(context.Background(), params)
+	fu.ChangeUsernameByID(context.Background(), id, &request.ChangeUsername{Username: "john_doe"})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/usernames/John_Doe/availability", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		usernameUsecase: fu,
+	}
+
+	err := server.CheckUsernameAvailability(ctx, "John_Doe")
+	assert.NoError(err)
+
+	var response generated.UsernameAvailabilityResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.False(response.Available)
+	assert.Equal("is already taken", *response.Reason)
+}
+
+func TestServer_CheckUsernameAvailability_Invalid(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/usernames/me/availability", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		usernameUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.CheckUsernameAvailability(ctx, "me")
+	assert.NoError(err)
+
+	var response generated.UsernameAvailabilityResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.False(response.Available)
+	assert.Contains(*response.Reason, "is reserved")
+}
+
+func TestServer_CheckUsernameAvailability_ErrOnUsecase(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/usernames/error/availability", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		usernameUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.CheckUsernameAvailability(ctx, "error")
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusInternalServerError, rec.Code)
+	assert.Equal("InternalServerError", response.Type)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/param/request"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CreateCurrentUserApiKey implements generated.ServerInterface.
+func (s *Server) CreateCurrentUserApiKey(ctx echo.Context) error {
+	var params generated.CreateApiKeyRequest
+	if err := ctx.Bind(&params); err != nil {
+		return parseError(ctx, err)
+	}
+
+	userID := auth.UserID(ctx.Request().Context())
+	apiKey, err := s.APIKeyUsecase.CreateAPIKey(ctx.Request().Context(), userID, &request.CreateAPIKey{
+		Name:      params.Name,
+		Scopes:    parseAPIKeyScopes(params.Scopes),
+		ExpiresAt: params.ExpiresAt,
+	})
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	response := parseAPIKeyResponse(apiKey)
+	return ctx.JSON(http.StatusCreated, generated.CreateApiKeyResponse{
+		Id:         response.Id,
+		Name:       response.Name,
+		Prefix:     response.Prefix,
+		Scopes:     response.Scopes,
+		ExpiresAt:  response.ExpiresAt,
+		LastUsedAt: response.LastUsedAt,
+		CreatedAt:  response.CreatedAt,
+		Key:        apiKey.Key,
+	})
+}
+
+func parseAPIKeyScopes(scopes []generated.ApiKeyScope) []string {
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		result = append(result, string(scope))
+	}
+	return result
+}
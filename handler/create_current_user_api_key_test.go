@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/test/fake"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_CreateCurrentUserApiKey_InvalidInput(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/users/me/api-keys",
+		strings.NewReader(`{"name": "backup script", "scopes": ["users:delete"]}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "user-id"}))
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		APIKeyUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.CreateCurrentUserApiKey(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("scopes", response.Messages[0].Name)
+}
+
+func TestServer_CreateCurrentUserApiKey_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/users/me/api-keys",
+		strings.NewReader(`{"name": "backup script", "scopes": ["users:read", "users:write"]}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "user-id"}))
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	fu := fake.NewFakeUserUsecase()
+	server := &Server{
+		APIKeyUsecase: fu,
+	}
+
+	err := server.CreateCurrentUserApiKey(ctx)
+	assert.NoError(err)
+
+	var response generated.CreateApiKeyResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusCreated, rec.Code)
+	assert.Equal("backup script", response.Name)
+	assert.Equal([]generated.ApiKeyScope{generated.UsersRead, generated.UsersWrite}, response.Scopes)
+	assert.Nil(response.ExpiresAt)
+	apiKey := fu.APIKeys.APIKeys[response.Id.String()]
+	assert.Equal("user-id", apiKey.UserID)
+	assert.Equal(apiKey.Prefix, response.Prefix)
+	assert.Equal(apiKey.Key, response.Key)
+}
@@ -73,6 +73,62 @@ func TestServer_CreateUser_ErrValidation(t *testing.T) {
 	assert.Equal("ValidationError", response.Type)
 }
 
+func TestServer_CreateUser_ValidationErrorLocalized(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		wantLanguage   string
+		wantReason     string
+	}{
+		{
+			name:           "when indonesian is accepted, it should return indonesian reason",
+			acceptLanguage: "id-ID,id;q=0.9,en;q=0.8",
+			wantLanguage:   "id",
+			wantReason:     "tidak boleh kosong",
+		},
+		{
+			name:           "when language is not supported, it should return english reason",
+			acceptLanguage: "fr-FR",
+			wantLanguage:   "en",
+			wantReason:     "must not be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/api/users",
+				strings.NewReader(`{"FullName": "John Doe", "Password": "password123"}`),
+			)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			assert := assert.New(t)
+
+			server := &Server{
+				userUsecase: fake.NewFakeUserUsecase(),
+			}
+
+			err := server.CreateUser(ctx)
+			assert.NoError(err)
+
+			var response generated.ErrorResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(err)
+
+			assert.Equal(http.StatusBadRequest, rec.Code)
+			assert.Equal(tt.wantLanguage, rec.Header().Get("Content-Language"))
+			assert.Len(response.Messages, 1)
+			assert.Equal("phone number", response.Messages[0].Name)
+			assert.Equal(tt.wantReason, response.Messages[0].Reason)
+			assert.Equal("empty", *response.Messages[0].Code)
+		})
+	}
+}
+
 func TestServer_CreateUser_UnexpectedError(t *testing.T) {
 	e := echo.New()
 	req := httptest.NewRequest(
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/user/param/request"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CreateWebhookSubscription implements generated.ServerInterface.
+func (s *Server) CreateWebhookSubscription(ctx echo.Context) error {
+	var params generated.CreateWebhookSubscriptionRequest
+	if err := ctx.Bind(&params); err != nil {
+		return parseError(ctx, err)
+	}
+
+	subscription, err := s.webhookUsecase.CreateWebhookSubscription(ctx.Request().Context(), &request.CreateWebhookSubscription{
+		PartnerID:  params.PartnerId,
+		URL:        params.Url,
+		EventTypes: parseWebhookEventTypes(params.EventTypes),
+		Secret:     valueOrEmpty(params.Secret),
+	})
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	response := parseWebhookSubscriptionResponse(subscription)
+	return ctx.JSON(http.StatusCreated, generated.CreateWebhookSubscriptionResponse{
+		Id:                  response.Id,
+		PartnerId:           response.PartnerId,
+		Url:                 response.Url,
+		EventTypes:          response.EventTypes,
+		Enabled:             response.Enabled,
+		ConsecutiveFailures: response.ConsecutiveFailures,
+		DisabledAt:          response.DisabledAt,
+		CreatedAt:           response.CreatedAt,
+		UpdatedAt:           response.UpdatedAt,
+		Secret:              subscription.Secret,
+	})
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	"userservice/test/fake"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_CreateWebhookSubscription_InvalidInput(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/admin/webhooks",
+		strings.NewReader(`{"partnerId": "acme", "url": "not a url", "eventTypes": ["UserCreated"]}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		webhookUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.CreateWebhookSubscription(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("url", response.Messages[0].Name)
+}
+
+func TestServer_CreateWebhookSubscription_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/admin/webhooks",
+		strings.NewReader(`{"partnerId": "acme", "url": "https://partner.example.com/webhooks", "eventTypes": ["UserCreated", "UserProfileUpdated"]}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	fu := fake.NewFakeUserUsecase()
+	server := &Server{
+		webhookUsecase: fu,
+	}
+
+	err := server.CreateWebhookSubscription(ctx)
+	assert.NoError(err)
+
+	var response generated.CreateWebhookSubscriptionResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusCreated, rec.Code)
+	assert.Equal("acme", response.PartnerId)
+	assert.Equal("https://partner.example.com/webhooks", response.Url)
+	assert.Equal([]generated.WebhookEventType{generated.UserCreated, generated.UserProfileUpdated}, response.EventTypes)
+	assert.True(response.Enabled)
+	assert.Equal(fu.Webhooks.Subscriptions[response.Id.String()].Secret, response.Secret)
+	assert.NotEmpty(response.Secret)
+}
@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// DeleteWebhookSubscription implements generated.ServerInterface.
+func (s *Server) DeleteWebhookSubscription(ctx echo.Context, id openapi_types.UUID) error {
+	if err := s.webhookUsecase.DeleteWebhookSubscriptionByID(ctx.Request().Context(), id.String()); err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
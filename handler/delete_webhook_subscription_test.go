@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_DeleteWebhookSubscription(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	subscription, _ := fu.CreateWebhookSubscription(context.Background(), &request.CreateWebhookSubscription{
+		PartnerID:  "acme",
+		URL:        "https://partner.example.com/webhooks",
+		EventTypes: []string{entity.UserCreatedEventType},
+	})
+
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		wantCode int
+	}{
+		{
+			name:     "when subscription is found, it should return no content",
+			id:       uuid.MustParse(subscription.ID),
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "when subscription is already deleted, it should return not found",
+			id:       uuid.MustParse(subscription.ID),
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/webhooks/"+tt.id.String(), nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			server := &Server{
+				webhookUsecase: fu,
+			}
+
+			err := server.DeleteWebhookSubscription(ctx, tt.id)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
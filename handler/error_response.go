@@ -1,75 +1,50 @@
 package handler
 
 import (
-	"database/sql"
 	"errors"
-	"net/http"
-	"strings"
+	"fmt"
 
-	"userservice/generated"
 	customerror "userservice/internal/custom_error"
+	"userservice/internal/logging"
+	"userservice/internal/problem"
+	"userservice/internal/user/port/driven"
 
 	"github.com/labstack/echo/v4"
 	"github.com/lib/pq"
 )
 
 func parseValidationError(ctx echo.Context, err *customerror.ValidationError) error {
-	return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
-		Type:     "ValidationError",
-		Messages: parseItemValidationError(err),
-	})
+	return problem.Write(ctx, problem.Validation(problem.ValidationItems(ctx, err)))
 }
 
 func parseHTTPError(ctx echo.Context, err *echo.HTTPError) error {
-	return ctx.JSON(err.Code, generated.ErrorResponse{
-		Type: "RequestBodyError",
-		Messages: []generated.ErrorResponseItem{
-			{
-				Name:   "request body",
-				Reason: err.Internal.Error(),
-			},
-		},
-	})
+	// the binding errors tell which field has a wrong type, they do not contain internal details
+	detail := fmt.Sprint(err.Message)
+	if err.Internal != nil {
+		detail = err.Internal.Error()
+	}
+	invalidRequestBody := problem.New(problem.CodeInvalidRequestBody, detail)
+	// e.g. 415 when the content type cannot be bound
+	invalidRequestBody.Status = err.Code
+	return problem.Write(ctx, invalidRequestBody)
 }
 
 func parsePQError(ctx echo.Context, err *pq.Error) error {
 	if err.Code == "23505" {
-		return ctx.JSON(http.StatusConflict, generated.ErrorResponse{
-			Type: "DuplicateResource",
-			Messages: []generated.ErrorResponseItem{
-				{
-					Name:   "resource",
-					Reason: "duplicate violation on unique constraint",
-				},
-			},
-		})
+		return problem.Write(ctx, problem.New(problem.CodeDuplicateResource, "duplicate violation on unique constraint"))
 	}
 
 	return parseDefaultError(ctx, err)
 }
 
-func parseNoRowsError(ctx echo.Context, err error) error {
-	return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
-		Type: "RecordNotFound",
-		Messages: []generated.ErrorResponseItem{
-			{
-				Name:   "data",
-				Reason: err.Error(),
-			},
-		},
-	})
+func parseNotFoundError(ctx echo.Context, err error) error {
+	return problem.Write(ctx, problem.New(problem.CodeNotFound, "The resource is not found."))
 }
 
+// parseDefaultError logs the error, the client only gets the request ID to report it.
 func parseDefaultError(ctx echo.Context, err error) error {
-	return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
-		Type: "InternalServerError",
-		Messages: []generated.ErrorResponseItem{
-			{
-				Name:   "UnexpectedError",
-				Reason: err.Error(),
-			},
-		},
-	})
+	logging.FromContext(ctx.Request().Context()).Error("request failed", "error", err)
+	return problem.Write(ctx, problem.New(problem.CodeInternalError, "An unexpected error occurred."))
 }
 
 func parseError(ctx echo.Context, err error) error {
@@ -81,26 +56,9 @@ func parseError(ctx echo.Context, err error) error {
 	case *pq.Error:
 		return parsePQError(ctx, parsedError)
 	default:
-		if errors.Is(parsedError, sql.ErrNoRows) {
-			return parseNoRowsError(ctx, parsedError)
+		if errors.Is(parsedError, driven.ErrNotFound) {
+			return parseNotFoundError(ctx, parsedError)
 		}
 		return parseDefaultError(ctx, parsedError)
 	}
 }
-
-func parseItemValidationError(err *customerror.ValidationError) []generated.ErrorResponseItem {
-	var errorItems []generated.ErrorResponseItem
-
-	for _, message := range strings.Split(err.Error(), ";") {
-		messages := strings.Split(message, ": ")
-
-		key := messages[0]
-		for _, detailError := range strings.Split(messages[1], ",") {
-			errorItems = append(errorItems, generated.ErrorResponseItem{
-				Name:   key,
-				Reason: detailError,
-			})
-		}
-	}
-	return errorItems
-}
@@ -17,10 +17,17 @@ func (s *Server) GenerateUserToken(ctx echo.Context) error {
 	if err := ctx.Bind(&params); err != nil {
 		return parseError(ctx, err)
 	}
-	token, err := s.userUsecase.GenerateUserToken(ctx.Request().Context(), &request.GenerateUserTokenRequest{
-		PhoneNumber: params.PhoneNumber,
-		Password:    params.Password,
-	})
+	tokenRequest := &request.GenerateUserTokenRequest{
+		Password: params.Password,
+	}
+	if params.PhoneNumber != nil {
+		tokenRequest.PhoneNumber = *params.PhoneNumber
+	}
+	if params.Email != nil {
+		tokenRequest.Email = *params.Email
+	}
+
+	token, err := s.userUsecase.GenerateUserToken(ctx.Request().Context(), tokenRequest)
 	if err != nil {
 		return parseError(ctx, err)
 	}
@@ -125,3 +125,28 @@ func TestServer_GenerateUserToken_Success(t *testing.T) {
 	assert.NotEmpty(response.AccessToken)
 	assert.Equal("Bearer", response.Type)
 }
+
+func TestServer_GenerateUserToken_WithEmailSuccess(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/users/token", strings.NewReader(`{"Email": "john@example.com", "Password": "password123"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	fu := fake.NewFakeUserUsecase()
+	server := &Server{
+		userUsecase: fu,
+	}
+
+	err := server.GenerateUserToken(ctx)
+	assert.NoError(err)
+
+	var response generated.CreateTokenResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.NotEmpty(response.AccessToken)
+	assert.Equal("Bearer", response.Type)
+}
@@ -4,19 +4,31 @@ import (
 	"net/http"
 
 	"userservice/generated"
+	"userservice/internal/auth"
 
 	"github.com/labstack/echo/v4"
 )
 
 // GetCurrentUser implements generated.ServerInterface.
 func (s *Server) GetCurrentUser(ctx echo.Context) error {
-	userID := ctx.Request().Context().Value("userID").(string)
+	userID := auth.UserID(ctx.Request().Context())
 	user, err := s.userGetter.GetUserByID(ctx.Request().Context(), userID)
 	if err != nil {
 		return parseError(ctx, err)
 	}
-	return ctx.JSON(http.StatusOK, generated.GetUserResponse{
-		FullName:    user.FullName,
-		PhoneNumber: user.PhoneNumber,
-	})
+	response := generated.GetUserResponse{
+		FullName:      user.FullName,
+		PhoneNumber:   user.PhoneNumber,
+		EmailVerified: user.EmailVerified,
+	}
+	if user.Email != "" {
+		response.Email = &user.Email
+	}
+	if user.AvatarURL != "" {
+		response.AvatarUrl = &user.AvatarURL
+	}
+	if user.Username != "" {
+		response.Username = &user.Username
+	}
+	return ctx.JSON(http.StatusOK, response)
 }
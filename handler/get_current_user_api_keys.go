@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/entity"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// GetCurrentUserApiKeys implements generated.ServerInterface.
+func (s *Server) GetCurrentUserApiKeys(ctx echo.Context) error {
+	userID := auth.UserID(ctx.Request().Context())
+	apiKeys, err := s.APIKeyUsecase.GetAPIKeysByUserID(ctx.Request().Context(), userID)
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	response := generated.ApiKeysResponse{
+		ApiKeys: make([]generated.ApiKey, 0, len(apiKeys)),
+	}
+	for _, apiKey := range apiKeys {
+		response.ApiKeys = append(response.ApiKeys, parseAPIKeyResponse(apiKey))
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// parseAPIKeyResponse never contains the key.
+func parseAPIKeyResponse(apiKey *entity.APIKey) generated.ApiKey {
+	scopes := make([]generated.ApiKeyScope, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		scopes = append(scopes, generated.ApiKeyScope(scope))
+	}
+
+	return generated.ApiKey{
+		Id:         uuid.MustParse(apiKey.ID),
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  apiKey.ExpiresAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_GetCurrentUserApiKeys(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	apiKey, _ := fu.CreateAPIKey(context.Background(), "user-id", &request.CreateAPIKey{
+		Name:   "backup script",
+		Scopes: []string{entity.APIKeyScopeUsersRead},
+	})
+	_, _ = fu.CreateAPIKey(context.Background(), "other-user-id", &request.CreateAPIKey{
+		Name:   "other script",
+		Scopes: []string{entity.APIKeyScopeUsersRead},
+	})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/api-keys", nil)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "user-id"}))
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		APIKeyUsecase: fu,
+	}
+
+	err := server.GetCurrentUserApiKeys(ctx)
+	assert.NoError(err)
+
+	var response generated.ApiKeysResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Len(response.ApiKeys, 1)
+	assert.Equal(apiKey.ID, response.ApiKeys[0].Id.String())
+	assert.Equal(apiKey.Prefix, response.ApiKeys[0].Prefix)
+	// the key is never listed
+	assert.NotContains(rec.Body.String(), apiKey.Key)
+}
+
+func TestServer_GetCurrentUserApiKeys_Error(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/api-keys", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "api_key_error", true))
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	server := &Server{
+		APIKeyUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.GetCurrentUserApiKeys(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/entity"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetCurrentUserExtendedProfile implements generated.ServerInterface.
+func (s *Server) GetCurrentUserExtendedProfile(ctx echo.Context) error {
+	userID := auth.UserID(ctx.Request().Context())
+	profile, err := s.userGetter.GetProfileByUserID(ctx.Request().Context(), userID)
+	if err != nil {
+		return parseError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, parseExtendedProfileResponse(profile))
+}
+
+func parseExtendedProfileResponse(profile *entity.Profile) generated.ExtendedProfileResponse {
+	response := generated.ExtendedProfileResponse{
+		AvatarUrl: optionalString(profile.AvatarURL),
+		Locale:    optionalString(profile.Locale),
+		Timezone:  optionalString(profile.Timezone),
+	}
+	if profile.DateOfBirth != nil {
+		response.DateOfBirth = optionalString(profile.DateOfBirth.Format("2006-01-02"))
+	}
+	if profile.Address != nil {
+		response.Address = &generated.Address{
+			Street:     optionalString(profile.Address.Street),
+			City:       optionalString(profile.Address.City),
+			Province:   optionalString(profile.Address.Province),
+			PostalCode: optionalString(profile.Address.PostalCode),
+			Country:    profile.Address.Country,
+		}
+	}
+	return response
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
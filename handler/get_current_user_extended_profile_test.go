@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_GetCurrentUserExtendedProfile_Empty(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/users/me/profile", nil)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: faker.UUIDHyphenated()}))
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userGetter: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.GetCurrentUserExtendedProfile(ctx)
+	assert.NoError(err)
+
+	var response generated.ExtendedProfileResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal(generated.ExtendedProfileResponse{}, response)
+}
+
+func TestServer_GetCurrentUserExtendedProfile_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	id := faker.UUIDHyphenated()
+	locale := "id-ID"
+	dateOfBirth := "1990-12-31"
+	_, err := fu.UpdateExtendedProfileByID(context.Background(), id, &request.UpdateExtendedProfile{
+		Locale:      &locale,
+		DateOfBirth: &dateOfBirth,
+		Address: &request.Address{
+			City:    "Jakarta",
+			Country: "ID",
+		},
+	})
+	assert.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/users/me/profile", nil)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userGetter: fu,
+	}
+
+	err = server.GetCurrentUserExtendedProfile(ctx)
+	assert.NoError(err)
+
+	var response generated.ExtendedProfileResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal(locale, *response.Locale)
+	assert.Equal(dateOfBirth, *response.DateOfBirth)
+	assert.Nil(response.Timezone)
+	assert.Equal("Jakarta", *response.Address.City)
+	assert.Equal("ID", response.Address.Country)
+	assert.Nil(response.Address.Street)
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/entity"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetCurrentUserPrivacySettings implements generated.ServerInterface.
+func (s *Server) GetCurrentUserPrivacySettings(ctx echo.Context) error {
+	userID := auth.UserID(ctx.Request().Context())
+	settings, err := s.userGetter.GetPrivacySettingsByUserID(ctx.Request().Context(), userID)
+	if err != nil {
+		return parseError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, parsePrivacySettingsResponse(settings))
+}
+
+func parsePrivacySettingsResponse(settings *entity.PrivacySettings) generated.PrivacySettings {
+	return generated.PrivacySettings{
+		ShowFullName:    settings.ShowFullName,
+		ShowAvatar:      settings.ShowAvatar,
+		ShowPhoneNumber: settings.ShowPhoneNumber,
+	}
+}
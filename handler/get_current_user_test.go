@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/problem"
 	"userservice/internal/user/param/request"
 	"userservice/test/fake"
 
@@ -24,7 +26,7 @@ func TestGetCurrentUser_Success(t *testing.T) {
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
-	req = req.WithContext(context.WithValue(req.Context(), "userID", id))
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
 
 	rec := httptest.NewRecorder()
 	ctx := e.NewContext(req, rec)
@@ -51,7 +53,7 @@ func TestGetCurrentUser_UserNotFound(t *testing.T) {
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
-	req = req.WithContext(context.WithValue(req.Context(), "userID", "1232131"))
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "1232131"}))
 
 	rec := httptest.NewRecorder()
 	ctx := e.NewContext(req, rec)
@@ -77,7 +79,7 @@ func TestGetCurrentUser_ErrOnUsecase(t *testing.T) {
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
-	req = req.WithContext(context.WithValue(req.Context(), "userID", "1231232131"))
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "1231232131"}))
 
 	rec := httptest.NewRecorder()
 	ctx := e.NewContext(req, rec)
@@ -97,3 +99,35 @@ func TestGetCurrentUser_ErrOnUsecase(t *testing.T) {
 	assert.Equal(http.StatusInternalServerError, rec.Code)
 	assert.Equal("InternalServerError", response.Type)
 }
+
+func TestGetCurrentUser_ErrOnUsecase_Problem(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
+	req.Header.Set(echo.HeaderAccept, problem.ContentType)
+	req.Header.Set(echo.HeaderXRequestID, "request-id")
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "1231232131"}))
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userGetter: fu,
+	}
+
+	err := server.GetCurrentUser(ctx)
+	assert.NoError(err)
+
+	var response generated.Problem
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusInternalServerError, rec.Code)
+	assert.Equal(problem.ContentType, rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(problem.CodeInternalError, response.Code)
+	assert.Equal("request-id", *response.RequestId)
+	// the error is only logged
+	assert.NotContains(rec.Body.String(), "not Found")
+}
@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetHealthz implements generated.ServerInterface.
+func (s *Server) GetHealthz(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, generated.HealthResponse{
+		Status: generated.Up,
+	})
+}
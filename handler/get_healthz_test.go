@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/generated"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_GetHealthz(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{}
+
+	err := server.GetHealthz(ctx)
+	assert.NoError(err)
+
+	var response generated.HealthResponse
+	assert.NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal(generated.Up, response.Status)
+}
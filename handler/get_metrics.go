@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"userservice/internal/problem"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetMetrics implements generated.ServerInterface.
+func (s *Server) GetMetrics(ctx echo.Context) error {
+	if s.MetricsHandler == nil {
+		return problem.Write(ctx, problem.New(problem.CodeNotFound, "The metrics are not recorded."))
+	}
+	s.MetricsHandler.ServeHTTP(ctx.Response(), ctx.Request())
+	return nil
+}
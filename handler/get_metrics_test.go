@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_GetMetrics_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		MetricsHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("userservice_signups_total 1\n"))
+		}),
+	}
+
+	err := server.GetMetrics(ctx)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal("userservice_signups_total 1\n", rec.Body.String())
+}
+
+func TestServer_GetMetrics_NotRecorded(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{}
+
+	err := server.GetMetrics(ctx)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusNotFound, rec.Code)
+}
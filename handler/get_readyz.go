@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/health"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetReadyz implements generated.ServerInterface.
+func (s *Server) GetReadyz(ctx echo.Context) error {
+	report := s.Health.Ready(ctx.Request().Context())
+	status := http.StatusOK
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	return ctx.JSON(status, parseReadinessResponse(report))
+}
+
+func parseReadinessResponse(report *health.Report) generated.ReadinessResponse {
+	checks := make(map[string]generated.HealthCheck, len(report.Checks))
+	for name, result := range report.Checks {
+		checks[name] = generated.HealthCheck{
+			Status:             generated.HealthStatus(result.Status),
+			LatencyMillisecond: float64(result.Latency.Microseconds()) / 1000,
+		}
+	}
+	return generated.ReadinessResponse{
+		Status: generated.HealthStatus(report.Status),
+		Phase:  generated.ReadinessResponsePhase(report.Phase.String()),
+		Checks: checks,
+	}
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"userservice/generated"
+	"userservice/internal/health"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_GetReadyz(t *testing.T) {
+	tests := []struct {
+		name       string
+		phase      health.Phase
+		checkErr   error
+		wantCode   int
+		wantStatus generated.HealthStatus
+		wantCheck  generated.HealthStatus
+	}{
+		{
+			name:       "when serving and database is up, it should return ok",
+			phase:      health.PhaseServing,
+			wantCode:   http.StatusOK,
+			wantStatus: generated.Up,
+			wantCheck:  generated.Up,
+		},
+		{
+			name:       "when database is down, it should return service unavailable",
+			phase:      health.PhaseServing,
+			checkErr:   errors.New("connection refused"),
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: generated.Down,
+			wantCheck:  generated.Down,
+		},
+		{
+			name:       "when stopping, it should return service unavailable",
+			phase:      health.PhaseStopping,
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: generated.Down,
+			wantCheck:  generated.Up,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			assert := assert.New(t)
+
+			checker := health.NewChecker(time.Second)
+			checker.Add("database", func(ctx context.Context) error { return tt.checkErr })
+			checker.SetPhase(tt.phase)
+			server := &Server{
+				Health: checker,
+			}
+
+			err := server.GetReadyz(ctx)
+			assert.NoError(err)
+
+			var response generated.ReadinessResponse
+			assert.NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(tt.wantCode, rec.Code)
+			assert.Equal(tt.wantStatus, response.Status)
+			assert.Equal(generated.ReadinessResponsePhase(tt.phase.String()), response.Phase)
+			assert.Equal(tt.wantCheck, response.Checks["database"].Status)
+		})
+	}
+}
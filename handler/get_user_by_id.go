@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// GetUserByID implements generated.ServerInterface.
+func (s *Server) GetUserByID(ctx echo.Context, id openapi_types.UUID) error {
+	publicProfile, err := s.userGetter.GetPublicProfileByID(ctx.Request().Context(), id.String())
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.PublicUserResponse{
+		Id:          id,
+		Username:    optionalString(publicProfile.Username),
+		FullName:    optionalString(publicProfile.FullName),
+		AvatarUrl:   optionalString(publicProfile.AvatarURL),
+		PhoneNumber: optionalString(publicProfile.PhoneNumber),
+	})
+}
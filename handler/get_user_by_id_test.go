@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_GetUserByID_ErrOnUsecase(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/id", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userGetter: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.GetUserByID(ctx, uuid.New())
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusInternalServerError, rec.Code)
+	assert.Equal("InternalServerError", response.Type)
+}
+
+func TestServer_GetUserByID_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	params.PhoneNumber = "+628123456789"
+	id, _ := fu.CreateUser(context.Background(), params)
+	fu.UpdatePrivacySettingsByID(context.Background(), id, &request.UpdatePrivacySettings{
+		ShowFullName:    true,
+		ShowPhoneNumber: true,
+	})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+id, nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userGetter: fu,
+	}
+
+	err := server.GetUserByID(ctx, uuid.MustParse(id))
+	assert.NoError(err)
+
+	var response generated.PublicUserResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal(id, response.Id.String())
+	assert.Equal(params.FullName, *response.FullName)
+	assert.Equal("+6281*****789", *response.PhoneNumber)
+	assert.Nil(response.AvatarUrl)
+	assert.Nil(response.Username)
+}
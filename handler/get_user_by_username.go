@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetUserByUsername implements generated.ServerInterface.
+func (s *Server) GetUserByUsername(ctx echo.Context, username string) error {
+	user, err := s.usernameUsecase.GetUserByUsername(ctx.Request().Context(), username)
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, parseUsernameResponse(user))
+}
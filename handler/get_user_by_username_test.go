@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_GetUserByUsername_NotFound(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/usernames/john_doe", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		usernameUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.GetUserByUsername(ctx, "john_doe")
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusNotFound, rec.Code)
+	assert.Equal("RecordNotFound", response.Type)
+}
+
+func TestServer_GetUserByUsername_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	id, _ := fu.CreateUser(context.Background(), params)
+	fu.ChangeUsernameByID(context.Background(), id, &request.ChangeUsername{Username: "John_Doe"})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/usernames/john_doe", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		usernameUsecase: fu,
+	}
+
+	err := server.GetUserByUsername(ctx, "john_doe")
+	assert.NoError(err)
+
+	var response generated.UsernameResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal(id, response.Id.String())
+	assert.Equal("John_Doe", response.Username)
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// GetWebhookDeliveryAttempts implements generated.ServerInterface.
+func (s *Server) GetWebhookDeliveryAttempts(ctx echo.Context, id openapi_types.UUID, params generated.GetWebhookDeliveryAttemptsParams) error {
+	limit := 0
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	attempts, err := s.webhookUsecase.GetWebhookDeliveryAttempts(ctx.Request().Context(), id.String(), limit)
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	response := generated.WebhookDeliveryAttemptsResponse{
+		Attempts: make([]generated.WebhookDeliveryAttempt, 0, len(attempts)),
+	}
+	for _, attempt := range attempts {
+		response.Attempts = append(response.Attempts, generated.WebhookDeliveryAttempt{
+			Id:                  uuid.MustParse(attempt.ID),
+			DeliveryId:          uuid.MustParse(attempt.DeliveryID),
+			EventId:             uuid.MustParse(attempt.EventID),
+			EventType:           generated.WebhookEventType(attempt.EventType),
+			Attempt:             attempt.Attempt,
+			StatusCode:          attempt.StatusCode,
+			Error:               optionalString(attempt.Error),
+			DurationMillisecond: attempt.Duration.Milliseconds(),
+			CreatedAt:           attempt.CreatedAt,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
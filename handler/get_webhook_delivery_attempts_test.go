@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"userservice/generated"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_GetWebhookDeliveryAttempts_InvalidLimit(t *testing.T) {
+	e := echo.New()
+	id := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/webhooks/"+id.String()+"/deliveries?limit=101", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		webhookUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	limit := 101
+	err := server.GetWebhookDeliveryAttempts(ctx, id, generated.GetWebhookDeliveryAttemptsParams{Limit: &limit})
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("limit", response.Messages[0].Name)
+}
+
+func TestServer_GetWebhookDeliveryAttempts_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	subscription, _ := fu.CreateWebhookSubscription(context.Background(), &request.CreateWebhookSubscription{
+		PartnerID:  "acme",
+		URL:        "https://partner.example.com/webhooks",
+		EventTypes: []string{entity.UserCreatedEventType},
+	})
+	delivery := &entity.WebhookDelivery{
+		ID:           uuid.NewString(),
+		Subscription: subscription,
+		EventID:      uuid.NewString(),
+		EventType:    entity.UserCreatedEventType,
+	}
+	now := time.Now()
+	fu.Webhooks.SaveAttempt(context.Background(), delivery, delivery.RecordAttempt(0, errors.New("connection refused"), 5*time.Millisecond, now, 8, time.Second, 20))
+	fu.Webhooks.SaveAttempt(context.Background(), delivery, delivery.RecordAttempt(http.StatusOK, nil, 120*time.Millisecond, now, 8, time.Second, 20))
+
+	e := echo.New()
+	id := uuid.MustParse(subscription.ID)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/webhooks/"+id.String()+"/deliveries", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		webhookUsecase: fu,
+	}
+
+	err := server.GetWebhookDeliveryAttempts(ctx, id, generated.GetWebhookDeliveryAttemptsParams{})
+	assert.NoError(err)
+
+	var response generated.WebhookDeliveryAttemptsResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Len(response.Attempts, 2)
+	assert.Equal(2, response.Attempts[0].Attempt)
+	assert.Equal(http.StatusOK, response.Attempts[0].StatusCode)
+	assert.Nil(response.Attempts[0].Error)
+	assert.Equal(int64(120), response.Attempts[0].DurationMillisecond)
+	assert.Equal(0, response.Attempts[1].StatusCode)
+	assert.Equal("connection refused", *response.Attempts[1].Error)
+}
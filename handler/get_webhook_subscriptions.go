@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/user/entity"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// GetWebhookSubscriptions implements generated.ServerInterface.
+func (s *Server) GetWebhookSubscriptions(ctx echo.Context) error {
+	subscriptions, err := s.webhookUsecase.GetWebhookSubscriptions(ctx.Request().Context())
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	response := generated.WebhookSubscriptionsResponse{
+		Subscriptions: make([]generated.WebhookSubscription, 0, len(subscriptions)),
+	}
+	for _, subscription := range subscriptions {
+		response.Subscriptions = append(response.Subscriptions, parseWebhookSubscriptionResponse(subscription))
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// parseWebhookSubscriptionResponse never contains the secret.
+func parseWebhookSubscriptionResponse(subscription *entity.WebhookSubscription) generated.WebhookSubscription {
+	eventTypes := make([]generated.WebhookEventType, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, generated.WebhookEventType(eventType))
+	}
+
+	return generated.WebhookSubscription{
+		Id:                  uuid.MustParse(subscription.ID),
+		PartnerId:           subscription.PartnerID,
+		Url:                 subscription.URL,
+		EventTypes:          eventTypes,
+		Enabled:             subscription.Enabled,
+		ConsecutiveFailures: subscription.ConsecutiveFailures,
+		DisabledAt:          subscription.DisabledAt,
+		CreatedAt:           subscription.CreatedAt,
+		UpdatedAt:           subscription.UpdatedAt,
+	}
+}
+
+func parseWebhookEventTypes(eventTypes []generated.WebhookEventType) []string {
+	result := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		result = append(result, string(eventType))
+	}
+	return result
+}
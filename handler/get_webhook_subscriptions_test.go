@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_GetWebhookSubscriptions_Error(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/webhooks", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "webhook_error", true))
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		webhookUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.GetWebhookSubscriptions(ctx)
+	assert.NoError(err)
+	assert.Equal(http.StatusInternalServerError, rec.Code)
+}
+
+func TestServer_GetWebhookSubscriptions_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	fu.CreateWebhookSubscription(context.Background(), &request.CreateWebhookSubscription{
+		PartnerID:  "acme",
+		URL:        "https://partner.example.com/webhooks",
+		EventTypes: []string{entity.UserCreatedEventType},
+	})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/webhooks", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		webhookUsecase: fu,
+	}
+
+	err := server.GetWebhookSubscriptions(ctx)
+	assert.NoError(err)
+
+	var response generated.WebhookSubscriptionsResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Len(response.Subscriptions, 1)
+	assert.Equal("https://partner.example.com/webhooks", response.Subscriptions[0].Url)
+	assert.NotContains(rec.Body.String(), "secret")
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_LinkPartnerUser(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	params.PhoneNumber = "+628123456789"
+	id, _ := fu.CreateUser(context.Background(), params)
+
+	tests := []struct {
+		name      string
+		partnerID string
+		userID    uuid.UUID
+		wantCode  int
+	}{
+		{
+			name:      "when partner id is not valid, it should return bad request",
+			partnerID: "Acme",
+			userID:    uuid.MustParse(id),
+			wantCode:  http.StatusBadRequest,
+		},
+		{
+			name:      "when user is not found, it should return not found",
+			partnerID: "acme",
+			userID:    uuid.New(),
+			wantCode:  http.StatusNotFound,
+		},
+		{
+			name:      "when user is found, it should return no content",
+			partnerID: "acme",
+			userID:    uuid.MustParse(id),
+			wantCode:  http.StatusNoContent,
+		},
+		{
+			name:      "when user is already linked, it should return no content",
+			partnerID: "acme",
+			userID:    uuid.MustParse(id),
+			wantCode:  http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/partners/"+tt.partnerID+"/users/"+tt.userID.String(), nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			server := &Server{
+				webhookUsecase: fu,
+			}
+
+			err := server.LinkPartnerUser(ctx, tt.partnerID, tt.userID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
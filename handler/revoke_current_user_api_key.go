@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/internal/auth"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// RevokeCurrentUserApiKey implements generated.ServerInterface.
+func (s *Server) RevokeCurrentUserApiKey(ctx echo.Context, id openapi_types.UUID) error {
+	userID := auth.UserID(ctx.Request().Context())
+	if err := s.APIKeyUsecase.RevokeAPIKey(ctx.Request().Context(), userID, id.String()); err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/internal/auth"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_RevokeCurrentUserApiKey(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	apiKey, _ := fu.CreateAPIKey(context.Background(), "user-id", &request.CreateAPIKey{
+		Name:   "backup script",
+		Scopes: []string{entity.APIKeyScopeUsersRead},
+	})
+
+	tests := []struct {
+		name     string
+		userID   string
+		wantCode int
+	}{
+		{
+			name:     "when key belongs to another user, it should return not found",
+			userID:   "other-user-id",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "when key is found, it should return no content",
+			userID:   "user-id",
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "when key is already revoked, it should return not found",
+			userID:   "user-id",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me/api-keys/"+apiKey.ID, nil)
+			req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: tt.userID}))
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			server := &Server{
+				APIKeyUsecase: fu,
+			}
+
+			err := server.RevokeCurrentUserApiKey(ctx, uuid.MustParse(apiKey.ID))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/user/param/request"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// SearchUsers implements generated.ServerInterface.
+func (s *Server) SearchUsers(ctx echo.Context, params generated.SearchUsersParams) error {
+	searchParams := &request.SearchUsers{
+		Query:  params.Q,
+		Cursor: valueOrEmpty(params.Cursor),
+	}
+	if params.Limit != nil {
+		searchParams.Limit = *params.Limit
+	}
+
+	result, err := s.userSearchUsecase.SearchUsers(ctx.Request().Context(), searchParams)
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	response := generated.SearchUsersResponse{
+		Results:    make([]generated.SearchUserResult, 0, len(result.Results)),
+		NextCursor: optionalString(result.NextCursor),
+	}
+	for _, searchResult := range result.Results {
+		response.Results = append(response.Results, generated.SearchUserResult{
+			Id:          uuid.MustParse(searchResult.User.ID),
+			FullName:    searchResult.User.FullName,
+			PhoneNumber: searchResult.User.PhoneNumber,
+			Email:       optionalString(searchResult.User.Email),
+			Username:    optionalString(searchResult.User.Username),
+			Score:       searchResult.Score,
+			Highlight: generated.SearchUserHighlight{
+				FullName:    searchResult.HighlightedFullName,
+				PhoneNumber: searchResult.HighlightedPhoneNumber,
+			},
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_SearchUsers_InvalidInput(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/search?q=j", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userSearchUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.SearchUsers(ctx, generated.SearchUsersParams{Q: "j"})
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("q", response.Messages[0].Name)
+}
+
+func TestServer_SearchUsers_ErrOnUsecase(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/search?q=john", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "search_error", true))
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userSearchUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.SearchUsers(ctx, generated.SearchUsersParams{Q: "john"})
+	assert.NoError(err)
+
+	assert.Equal(http.StatusInternalServerError, rec.Code)
+}
+
+func TestServer_SearchUsers_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	id, _ := fu.CreateUser(context.Background(), &request.CreateUser{
+		FullName:    "John Doe",
+		PhoneNumber: "+628123456789",
+	})
+	fu.CreateUser(context.Background(), &request.CreateUser{
+		FullName:    "Jane Roe",
+		PhoneNumber: "+628987654321",
+	})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/search?q=john", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userSearchUsecase: fu,
+	}
+
+	limit := 10
+	err := server.SearchUsers(ctx, generated.SearchUsersParams{Q: "john", Limit: &limit})
+	assert.NoError(err)
+
+	var response generated.SearchUsersResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Len(response.Results, 1)
+	assert.Equal(id, response.Results[0].Id.String())
+	assert.Equal("John Doe", response.Results[0].Highlight.FullName)
+	assert.Nil(response.NextCursor)
+}
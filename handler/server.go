@@ -1,36 +1,155 @@
 package handler
 
 import (
+	"net/http"
+	"time"
+
 	"userservice/config"
 	"userservice/infrastructure"
+	"userservice/internal/health"
 	"userservice/internal/user/port/driver"
 	"userservice/internal/user/usecase"
 )
 
 type Server struct {
 	TokenProvider *infrastructure.UserTokenProvider
+	// APIKeyUsecase authenticates the API keys of the users as well
+	APIKeyUsecase driver.APIKeyUsecase
+	// OutboxRelay should be run in the background to publish the user events
+	OutboxRelay *usecase.OutboxRelayUsecase
+	// WebhookDispatcher should be run in the background to send the queued webhook deliveries
+	WebhookDispatcher *usecase.WebhookDispatcherUsecase
+	// MetricsHandler serves the metrics on GET /metrics, the operation is answered with 404 when it is nil
+	MetricsHandler http.Handler
+	// Health answers GET /readyz, its phase is set by main once the service is serving and when it stops
+	Health *health.Checker
+
+	userUsecase     driver.UserUsecase
+	userGetter      driver.UserGetterUsecase
+	passwordUsecase driver.PasswordUsecase
+	emailUsecase    driver.EmailUsecase
+	avatarUsecase   driver.AvatarUsecase
+	usernameUsecase driver.UsernameUsecase
 
-	userUsecase driver.UserUsecase
-	userGetter  driver.UserGetterUsecase
+	internalUserUsecase driver.InternalUserUsecase
+	userSearchUsecase   driver.UserSearchUsecase
+	webhookUsecase      driver.WebhookUsecase
 }
 
 type ServerOptions struct {
 	Conf *config.ApplicationConfig
+	// DB is shared with the gRPC server and the metrics of its pool
+	DB      *infrastructure.PostgreConnection
+	Metrics *infrastructure.PrometheusMetrics
 }
 
 func NewServer(opt *ServerOptions) *Server {
-	db := infrastructure.NewPostgreConnection(&opt.Conf.Postgres)
+	db := opt.DB
 	userDB := infrastructure.NewUserDB(db)
 	tokenProvider := infrastructure.NewUserTokenProvider(&opt.Conf.JWT)
+	encryptor := infrastructure.NewBcryptEncryption(opt.Metrics)
+	webhookDB := infrastructure.NewWebhookDB(db)
 
-	return &Server{
-		userUsecase: usecase.NewUserUsecase(
+	server := NewServerWithUsecases(tokenProvider, &Usecases{
+		User: usecase.NewUserUsecase(
 			userDB,
-			new(infrastructure.BcyrpEncryption),
+			encryptor,
 			userDB,
 			tokenProvider,
+			opt.Metrics,
+		),
+		UserGetter: usecase.NewUserGetterUsecase(userDB),
+		Password: usecase.NewPasswordUsecase(
+			userDB,
+			userDB,
+			encryptor,
+			infrastructure.NewPasswordHistoryDB(db),
+			opt.Conf.Password.HistoryLimit,
+		),
+		Email: usecase.NewEmailUsecase(
+			userDB,
+			userDB,
+			infrastructure.NewMaildirEmailSender(&opt.Conf.Email),
+			infrastructure.NewEmailVerificationTokenProvider(&opt.Conf.Email),
+			opt.Conf.Email.VerificationURL,
+		),
+		Avatar: usecase.NewAvatarUsecase(
+			userDB,
+			userDB,
+			infrastructure.NewImageProcessor(),
+			infrastructure.NewFilesystemBlobStore(&opt.Conf.Blob),
+			opt.Conf.Avatar.MaxSizeByte,
 		),
-		userGetter:    usecase.NewUserGetterUsecase(userDB),
-		TokenProvider: tokenProvider,
+		Username: usecase.NewUsernameUsecase(
+			userDB,
+			userDB,
+			opt.Conf.Username.ChangeIntervalDay,
+			opt.Conf.Username.RedirectGraceDay,
+		),
+		InternalUser: usecase.NewInternalUserUsecase(
+			userDB,
+			opt.Conf.Internal.BatchGetMaxSize,
+		),
+		UserSearch: usecase.NewUserSearchUsecase(userDB),
+		Webhook:    usecase.NewWebhookUsecase(webhookDB, userDB),
+		APIKey:     usecase.NewAPIKeyUsecase(infrastructure.NewAPIKeyDB(db)),
+	})
+	server.OutboxRelay = usecase.NewOutboxRelayUsecase(
+		infrastructure.NewOutboxDB(db),
+		// the events are queued for the webhook subscriptions as well
+		infrastructure.NewMultiEventPublisher(
+			infrastructure.NewFileEventPublisher(&opt.Conf.Outbox),
+			usecase.NewWebhookPublisherUsecase(userDB, webhookDB),
+		),
+		opt.Conf.Outbox.PollIntervalMillisecond,
+		opt.Conf.Outbox.BatchSize,
+		opt.Conf.Outbox.LockSecond,
+	)
+	server.WebhookDispatcher = usecase.NewWebhookDispatcherUsecase(
+		webhookDB,
+		infrastructure.NewHTTPWebhookSender(&opt.Conf.Webhook),
+		opt.Conf.Webhook.PollIntervalMillisecond,
+		opt.Conf.Webhook.BatchSize,
+		opt.Conf.Webhook.LockSecond,
+		opt.Conf.Webhook.MaxAttempts,
+		opt.Conf.Webhook.BackoffBaseSecond,
+		opt.Conf.Webhook.DisableAfterFailures,
+	)
+	server.MetricsHandler = opt.Metrics.Handler()
+	server.Health = health.NewChecker(time.Duration(opt.Conf.Health.CheckTimeoutMillisecond) * time.Millisecond)
+	server.Health.Add("database", db.Ping)
+	server.Health.Add("signing_keys", tokenProvider.CheckSigningKeys)
+	return server
+}
+
+// Usecases are the usecases served by the handlers, the operations of a nil usecase must not be called.
+type Usecases struct {
+	User         driver.UserUsecase
+	UserGetter   driver.UserGetterUsecase
+	Password     driver.PasswordUsecase
+	Email        driver.EmailUsecase
+	Avatar       driver.AvatarUsecase
+	Username     driver.UsernameUsecase
+	InternalUser driver.InternalUserUsecase
+	UserSearch   driver.UserSearchUsecase
+	Webhook      driver.WebhookUsecase
+	APIKey       driver.APIKeyUsecase
+}
+
+// NewServerWithUsecases creates the server of the given usecases without the background workers,
+// e.g. to serve the usecases with in-memory adapters.
+func NewServerWithUsecases(tokenProvider *infrastructure.UserTokenProvider, usecases *Usecases) *Server {
+	return &Server{
+		TokenProvider:       tokenProvider,
+		userUsecase:         usecases.User,
+		userGetter:          usecases.UserGetter,
+		passwordUsecase:     usecases.Password,
+		emailUsecase:        usecases.Email,
+		avatarUsecase:       usecases.Avatar,
+		usernameUsecase:     usecases.Username,
+		internalUserUsecase: usecases.InternalUser,
+		userSearchUsecase:   usecases.UserSearch,
+		webhookUsecase:      usecases.Webhook,
+		APIKeyUsecase:       usecases.APIKey,
 	}
 }
@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// UnlinkPartnerUser implements generated.ServerInterface.
+func (s *Server) UnlinkPartnerUser(ctx echo.Context, partnerId string, userId openapi_types.UUID) error {
+	if err := s.webhookUsecase.UnlinkPartnerUser(ctx.Request().Context(), partnerId, userId.String()); err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
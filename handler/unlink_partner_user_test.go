@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_UnlinkPartnerUser(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	params.PhoneNumber = "+628123456789"
+	id, _ := fu.CreateUser(context.Background(), params)
+	fu.LinkPartnerUser(context.Background(), "acme", id)
+
+	tests := []struct {
+		name     string
+		wantCode int
+	}{
+		{
+			name:     "when user is linked, it should return no content",
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "when user is already unlinked, it should return not found",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/partners/acme/users/"+id, nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			server := &Server{
+				webhookUsecase: fu,
+			}
+
+			err := server.UnlinkPartnerUser(ctx, "acme", uuid.MustParse(id))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
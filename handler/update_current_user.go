@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"userservice/generated"
+	"userservice/internal/auth"
 	"userservice/internal/user/param/request"
 
 	"github.com/labstack/echo/v4"
@@ -14,7 +15,7 @@ func (s *Server) UpdateCurrentUserProfile(ctx echo.Context) error {
 	if err := ctx.Bind(&params); err != nil {
 		return parseError(ctx, err)
 	}
-	userID := ctx.Request().Context().Value("userID").(string)
+	userID := auth.UserID(ctx.Request().Context())
 	user, err := s.userUsecase.UpdateProfileByID(ctx.Request().Context(), userID, &request.UpdateProfile{
 		PhoneNumber: params.PhoneNumber,
 		FullName:    params.FullName,
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/param/request"
+
+	"github.com/labstack/echo/v4"
+)
+
+// UpdateCurrentUserExtendedProfile implements generated.ServerInterface.
+func (s *Server) UpdateCurrentUserExtendedProfile(ctx echo.Context) error {
+	var params generated.UpdateExtendedProfileRequest
+	if err := ctx.Bind(&params); err != nil {
+		return parseError(ctx, err)
+	}
+
+	updateParams := &request.UpdateExtendedProfile{
+		AvatarURL:   params.AvatarUrl,
+		DateOfBirth: params.DateOfBirth,
+		Locale:      params.Locale,
+		Timezone:    params.Timezone,
+	}
+	if params.Address != nil {
+		updateParams.Address = &request.Address{
+			Street:     valueOrEmpty(params.Address.Street),
+			City:       valueOrEmpty(params.Address.City),
+			Province:   valueOrEmpty(params.Address.Province),
+			PostalCode: valueOrEmpty(params.Address.PostalCode),
+			Country:    params.Address.Country,
+		}
+	}
+
+	userID := auth.UserID(ctx.Request().Context())
+	profile, err := s.userUsecase.UpdateExtendedProfileByID(ctx.Request().Context(), userID, updateParams)
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, parseExtendedProfileResponse(profile))
+}
+
+func valueOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
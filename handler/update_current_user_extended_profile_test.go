@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_UpdateCurrentUserExtendedProfile_CannotBindBody(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/api/users/me/profile",
+		strings.NewReader(`{"locale": "id-ID",}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.UpdateCurrentUserExtendedProfile(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("RequestBodyError", response.Type)
+}
+
+func TestServer_UpdateCurrentUserExtendedProfile_ErrValidation(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/api/users/me/profile",
+		strings.NewReader(`{"locale": "invalid"}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: faker.UUIDHyphenated()}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.UpdateCurrentUserExtendedProfile(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("ValidationError", response.Type)
+	assert.Equal("locale", response.Messages[0].Name)
+}
+
+func TestServer_UpdateCurrentUserExtendedProfile_ErrOnUsecase(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/api/users/me/profile",
+		strings.NewReader(`{"locale": "id-ID"}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "1232131"}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.UpdateCurrentUserExtendedProfile(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusInternalServerError, rec.Code)
+	assert.Equal("InternalServerError", response.Type)
+}
+
+func TestServer_UpdateCurrentUserExtendedProfile_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	id := faker.UUIDHyphenated()
+
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/api/users/me/profile",
+		strings.NewReader(`{"timezone": "Asia/Jakarta", "address": {"city": "Jakarta", "country": "ID"}}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userUsecase: fu,
+	}
+
+	err := server.UpdateCurrentUserExtendedProfile(ctx)
+	assert.NoError(err)
+
+	var response generated.ExtendedProfileResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal("Asia/Jakarta", *response.Timezone)
+	assert.Equal("Jakarta", *response.Address.City)
+	assert.Nil(response.Locale)
+
+	profile, _ := fu.GetProfileByUserID(context.Background(), id)
+	assert.Equal("Asia/Jakarta", profile.Timezone)
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/param/request"
+
+	"github.com/labstack/echo/v4"
+)
+
+// UpdateCurrentUserPrivacySettings implements generated.ServerInterface.
+func (s *Server) UpdateCurrentUserPrivacySettings(ctx echo.Context) error {
+	var params generated.PrivacySettings
+	if err := ctx.Bind(&params); err != nil {
+		return parseError(ctx, err)
+	}
+
+	userID := auth.UserID(ctx.Request().Context())
+	settings, err := s.userUsecase.UpdatePrivacySettingsByID(ctx.Request().Context(), userID, &request.UpdatePrivacySettings{
+		ShowFullName:    params.ShowFullName,
+		ShowAvatar:      params.ShowAvatar,
+		ShowPhoneNumber: params.ShowPhoneNumber,
+	})
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, parsePrivacySettingsResponse(settings))
+}
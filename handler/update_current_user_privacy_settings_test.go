@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_UpdateCurrentUserPrivacySettings_CannotBindBody(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/privacy",
+		strings.NewReader(`{"showFullName": "yes"}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.UpdateCurrentUserPrivacySettings(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("RequestBodyError", response.Type)
+}
+
+func TestServer_UpdateCurrentUserPrivacySettings_ErrOnUsecase(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/privacy",
+		strings.NewReader(`{"showFullName": true, "showAvatar": true, "showPhoneNumber": false}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "1232131"}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.UpdateCurrentUserPrivacySettings(ctx)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusInternalServerError, rec.Code)
+}
+
+func TestServer_UpdateCurrentUserPrivacySettings_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	id := faker.UUIDHyphenated()
+
+	e := echo.New()
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/me/privacy",
+		strings.NewReader(`{"showFullName": false, "showAvatar": true, "showPhoneNumber": true}`),
+	)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		userUsecase: fu,
+		userGetter:  fu,
+	}
+
+	err := server.UpdateCurrentUserPrivacySettings(ctx)
+	assert.NoError(err)
+
+	var response generated.PrivacySettings
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal(generated.PrivacySettings{ShowFullName: false, ShowAvatar: true, ShowPhoneNumber: true}, response)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/users/me/privacy", nil)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
+	rec = httptest.NewRecorder()
+
+	err = server.GetCurrentUserPrivacySettings(e.NewContext(req, rec))
+	assert.NoError(err)
+
+	var getResponse generated.PrivacySettings
+	err = json.Unmarshal(rec.Body.Bytes(), &getResponse)
+	assert.NoError(err)
+	assert.Equal(response, getResponse)
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"userservice/generated"
+	"userservice/internal/auth"
 	"userservice/internal/user/param/request"
 	"userservice/test/fake"
 
@@ -53,7 +54,7 @@ func TestServer_UpdateCurrentUser_UnexpectedError(t *testing.T) {
 		"/api/users/me",
 		strings.NewReader(`{"PhoneNumber": "11111111","FullName": "John Doe"}`),
 	)
-	req = req.WithContext(context.WithValue(req.Context(), "userID", "1232131"))
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "1232131"}))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
 	rec := httptest.NewRecorder()
@@ -83,7 +84,7 @@ func TestServer_UpdateCurrentUser_DuplicateError(t *testing.T) {
 		"/api/users/me",
 		strings.NewReader(`{"PhoneNumber": "11111111","FullName": "John Doe"}`),
 	)
-	req = req.WithContext(context.WithValue(req.Context(), "userID", "3333"))
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "3333"}))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
 	rec := httptest.NewRecorder()
@@ -118,7 +119,7 @@ func TestServer_UpdateCurrentUser_Success(t *testing.T) {
 		"/api/users/me",
 		strings.NewReader(`{"PhoneNumber": "11111111","FullName": "John Doe"}`),
 	)
-	req = req.WithContext(context.WithValue(req.Context(), "userID", id))
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
 	rec := httptest.NewRecorder()
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/user/param/request"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// UpdateWebhookSubscription implements generated.ServerInterface.
+func (s *Server) UpdateWebhookSubscription(ctx echo.Context, id openapi_types.UUID) error {
+	var params generated.UpdateWebhookSubscriptionRequest
+	if err := ctx.Bind(&params); err != nil {
+		return parseError(ctx, err)
+	}
+
+	updateParams := &request.UpdateWebhookSubscription{
+		URL:     params.Url,
+		Enabled: params.Enabled,
+	}
+	if params.EventTypes != nil {
+		eventTypes := parseWebhookEventTypes(*params.EventTypes)
+		updateParams.EventTypes = &eventTypes
+	}
+
+	subscription, err := s.webhookUsecase.UpdateWebhookSubscriptionByID(ctx.Request().Context(), id.String(), updateParams)
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, parseWebhookSubscriptionResponse(subscription))
+}
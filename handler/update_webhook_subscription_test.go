@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_UpdateWebhookSubscription_NotFound(t *testing.T) {
+	e := echo.New()
+	id := uuid.New()
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/api/v1/admin/webhooks/"+id.String(),
+		strings.NewReader(`{"enabled": true}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		webhookUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.UpdateWebhookSubscription(ctx, id)
+	assert.NoError(err)
+	assert.Equal(http.StatusNotFound, rec.Code)
+}
+
+func TestServer_UpdateWebhookSubscription_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	subscription, _ := fu.CreateWebhookSubscription(context.Background(), &request.CreateWebhookSubscription{
+		PartnerID:  "acme",
+		URL:        "https://partner.example.com/webhooks",
+		EventTypes: []string{entity.UserCreatedEventType},
+	})
+	subscription.Enabled = false
+	subscription.ConsecutiveFailures = 20
+
+	e := echo.New()
+	id := uuid.MustParse(subscription.ID)
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/api/v1/admin/webhooks/"+id.String(),
+		strings.NewReader(`{"enabled": true, "eventTypes": ["UserLoggedIn"]}`),
+	)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		webhookUsecase: fu,
+	}
+
+	err := server.UpdateWebhookSubscription(ctx, id)
+	assert.NoError(err)
+
+	var response generated.WebhookSubscription
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.True(response.Enabled)
+	assert.Equal(0, response.ConsecutiveFailures)
+	assert.Equal([]generated.WebhookEventType{generated.UserLoggedIn}, response.EventTypes)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/user/entity"
+
+	"github.com/labstack/echo/v4"
+)
+
+// UploadCurrentUserAvatar implements generated.ServerInterface.
+func (s *Server) UploadCurrentUserAvatar(ctx echo.Context) error {
+	fileHeader, err := ctx.FormFile("avatar")
+	if err != nil {
+		return parseError(ctx, customerror.NewValidationErrorWithCode("avatar", customerror.CodeMultipartFile, nil))
+	}
+
+	// the form is already parsed within the body limit of the server,
+	// a larger file is rejected before its data is copied and decoded by the usecase
+	maxSize := s.avatarUsecase.AvatarMaxSize()
+	if err := entity.CheckAvatarSize(fileHeader.Size, maxSize); err != nil {
+		return parseError(ctx, err)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return parseError(ctx, err)
+	}
+	defer file.Close()
+
+	// the usecase rejects the data above the max size, so one more byte is enough to tell it
+	data, err := io.ReadAll(io.LimitReader(file, int64(maxSize)+1))
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	userID := auth.UserID(ctx.Request().Context())
+	avatar, err := s.avatarUsecase.UploadAvatarByID(ctx.Request().Context(), userID, data)
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	response := generated.AvatarResponse{
+		AvatarUrl:  avatar.URL,
+		Thumbnails: []generated.AvatarThumbnail{},
+	}
+	for _, thumbnail := range avatar.Thumbnails {
+		response.Thumbnails = append(response.Thumbnails, generated.AvatarThumbnail{
+			Size: thumbnail.Size,
+			Url:  thumbnail.URL,
+		})
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
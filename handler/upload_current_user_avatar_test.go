@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAvatarRequest(field string, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile(field, "avatar.png")
+	part.Write(data)
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPut, "/api/users/me/avatar", &body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	return req
+}
+
+func TestServer_UploadCurrentUserAvatar_NotMultipart(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/users/me/avatar", strings.NewReader(`{"avatar": "data"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		avatarUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.UploadCurrentUserAvatar(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("ValidationError", response.Type)
+	assert.Equal("avatar", response.Messages[0].Name)
+}
+
+func TestServer_UploadCurrentUserAvatar_ErrValidation(t *testing.T) {
+	e := echo.New()
+	req := newAvatarRequest("avatar", nil)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: faker.UUIDHyphenated()}))
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		avatarUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.UploadCurrentUserAvatar(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("ValidationError", response.Type)
+}
+
+func TestServer_UploadCurrentUserAvatar_ErrMaxSize(t *testing.T) {
+	e := echo.New()
+	req := newAvatarRequest("avatar", bytes.Repeat([]byte("a"), 2048))
+	// the usecase fails for this user, so it must not be called
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "1232131"}))
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		avatarUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.UploadCurrentUserAvatar(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("avatar", response.Messages[0].Name)
+	assert.Equal("max_size", *response.Messages[0].Code)
+}
+
+func TestServer_UploadCurrentUserAvatar_ErrOnUsecase(t *testing.T) {
+	e := echo.New()
+	req := newAvatarRequest("avatar", []byte("data"))
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: "1232131"}))
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		avatarUsecase: fake.NewFakeUserUsecase(),
+	}
+
+	err := server.UploadCurrentUserAvatar(ctx)
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusInternalServerError, rec.Code)
+	assert.Equal("InternalServerError", response.Type)
+}
+
+func TestServer_UploadCurrentUserAvatar_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	id, _ := fu.CreateUser(context.Background(), params)
+
+	e := echo.New()
+	req := newAvatarRequest("avatar", []byte("data"))
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		avatarUsecase: fu,
+		userGetter:    fu,
+	}
+
+	err := server.UploadCurrentUserAvatar(ctx)
+	assert.NoError(err)
+
+	var response generated.AvatarResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal("http://localhost/blobs/avatars/"+id+"/original.png", response.AvatarUrl)
+	assert.Len(response.Thumbnails, 2)
+
+	// the avatar should be shown on the current user
+	req = httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
+	req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Subject: id}))
+	rec = httptest.NewRecorder()
+
+	err = server.GetCurrentUser(e.NewContext(req, rec))
+	assert.NoError(err)
+
+	var userResponse generated.GetUserResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &userResponse)
+	assert.NoError(err)
+	assert.Equal(response.AvatarUrl, *userResponse.AvatarUrl)
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"net/http"
+
+	"userservice/generated"
+
+	"github.com/labstack/echo/v4"
+)
+
+// VerifyUserEmail implements generated.ServerInterface.
+func (s *Server) VerifyUserEmail(ctx echo.Context, params generated.VerifyUserEmailParams) error {
+	user, err := s.emailUsecase.VerifyEmail(ctx.Request().Context(), params.Token)
+	if err != nil {
+		return parseError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.VerifyEmailResponse{
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+	})
+}
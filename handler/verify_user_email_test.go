@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/user/param/request"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_VerifyUserEmail_InvalidToken(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/users/email/verification?token=invalid", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	fu := fake.NewFakeUserUsecase()
+	server := &Server{
+		emailUsecase: fu,
+	}
+
+	err := server.VerifyUserEmail(ctx, generated.VerifyUserEmailParams{Token: "invalid"})
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.Equal("ValidationError", response.Type)
+}
+
+func TestServer_VerifyUserEmail_UnexpectedError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/users/email/verification?token=1232131", nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	fu := fake.NewFakeUserUsecase()
+	server := &Server{
+		emailUsecase: fu,
+	}
+
+	err := server.VerifyUserEmail(ctx, generated.VerifyUserEmailParams{Token: "1232131"})
+	assert.NoError(err)
+
+	var response generated.ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusInternalServerError, rec.Code)
+	assert.Equal("InternalServerError", response.Type)
+}
+
+func TestServer_VerifyUserEmail_Success(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	params := &request.CreateUser{}
+	faker.FakeData(params)
+	id, _ := fu.CreateUser(context.Background(), params)
+	fu.ChangeEmailByID(context.Background(), id, &request.ChangeEmail{Email: "john@example.com"})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/users/email/verification?token="+id, nil)
+
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	assert := assert.New(t)
+
+	server := &Server{
+		emailUsecase: fu,
+	}
+
+	err := server.VerifyUserEmail(ctx, generated.VerifyUserEmailParams{Token: id})
+	assert.NoError(err)
+
+	var response generated.VerifyEmailResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal("john@example.com", response.Email)
+	assert.True(response.EmailVerified)
+}
@@ -0,0 +1,164 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var _ driven.APIKeyStore = new(APIKeyDB)
+
+// apiKeyTouchInterval avoids writing the last used time on every request of the scripts.
+const apiKeyTouchInterval = time.Minute
+
+type APIKeyDB struct {
+	conn *PostgreConnection
+}
+
+func NewAPIKeyDB(db *PostgreConnection) *APIKeyDB {
+	return &APIKeyDB{
+		conn: db,
+	}
+}
+
+// CreateAPIKey implements driven.APIKeyStore.
+func (akdb *APIKeyDB) CreateAPIKey(ctx context.Context, apiKey *entity.APIKey) error {
+	uuidUser, _ := uuid.Parse(apiKey.UserID)
+	return akdb.conn.Db.QueryRowContext(ctx, `
+		INSERT INTO
+			api_keys (user_id, name, prefix, hash, scopes, expires_at, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+		RETURNING
+			id
+	`, uuidUser, apiKey.Name, apiKey.Prefix, apiKey.Hash, pq.Array(apiKey.Scopes), apiKey.ExpiresAt, apiKey.CreatedAt).Scan(&apiKey.ID)
+}
+
+// GetAPIKeysByUserID implements driven.APIKeyStore.
+func (akdb *APIKeyDB) GetAPIKeysByUserID(ctx context.Context, userID string) ([]*entity.APIKey, error) {
+	uuidUser, _ := uuid.Parse(userID)
+	rows, err := akdb.conn.Db.QueryContext(ctx, `
+		SELECT
+			id,
+			user_id,
+			name,
+			prefix,
+			hash,
+			scopes,
+			expires_at,
+			last_used_at,
+			revoked_at,
+			created_at
+		FROM
+			api_keys
+		WHERE
+			user_id = $1
+			AND revoked_at IS NULL
+		ORDER BY
+			created_at DESC
+	`, uuidUser)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apiKeys := []*entity.APIKey{}
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	return apiKeys, rows.Err()
+}
+
+// GetAPIKeyByPrefix implements driven.APIKeyStore.
+func (akdb *APIKeyDB) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	return scanAPIKey(akdb.conn.Db.QueryRowContext(ctx, `
+		SELECT
+			id,
+			user_id,
+			name,
+			prefix,
+			hash,
+			scopes,
+			expires_at,
+			last_used_at,
+			revoked_at,
+			created_at
+		FROM
+			api_keys
+		WHERE
+			prefix = $1
+	`, prefix))
+}
+
+// RevokeAPIKey implements driven.APIKeyStore.
+func (akdb *APIKeyDB) RevokeAPIKey(ctx context.Context, userID, id string, revokedAt time.Time) error {
+	uuidUser, _ := uuid.Parse(userID)
+	uuidAPIKey, _ := uuid.Parse(id)
+	result, err := akdb.conn.Db.ExecContext(ctx, `
+		UPDATE
+			api_keys
+		SET
+			revoked_at = $1
+		WHERE
+			id = $2
+			AND user_id = $3
+			AND revoked_at IS NULL
+	`, revokedAt, uuidAPIKey, uuidUser)
+	return expectAffected(result, err)
+}
+
+// TouchAPIKey implements driven.APIKeyStore.
+func (akdb *APIKeyDB) TouchAPIKey(ctx context.Context, id string, usedAt time.Time) error {
+	uuidAPIKey, _ := uuid.Parse(id)
+	_, err := akdb.conn.Db.ExecContext(ctx, `
+		UPDATE
+			api_keys
+		SET
+			last_used_at = $1
+		WHERE
+			id = $2
+			AND (last_used_at IS NULL OR last_used_at < $3)
+	`, usedAt, uuidAPIKey, usedAt.Add(-apiKeyTouchInterval))
+	return err
+}
+
+func scanAPIKey(row rowScanner) (*entity.APIKey, error) {
+	var apiKey entity.APIKey
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+	err := row.Scan(
+		&apiKey.ID,
+		&apiKey.UserID,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.Hash,
+		pq.Array(&apiKey.Scopes),
+		&expiresAt,
+		&lastUsedAt,
+		&revokedAt,
+		&apiKey.CreatedAt,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	if expiresAt.Valid {
+		apiKey.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		apiKey.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		apiKey.RevokedAt = &revokedAt.Time
+	}
+	return &apiKey, nil
+}
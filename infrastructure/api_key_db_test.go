@@ -0,0 +1,160 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyDB_CreateAPIKey(t *testing.T) {
+	now := time.Now()
+	apiKey := &entity.APIKey{
+		UserID:    faker.UUIDHyphenated(),
+		Name:      "backup script",
+		Prefix:    "usk_1a2b3c4d5e6f",
+		Hash:      "hash",
+		Scopes:    []string{entity.APIKeyScopeUsersRead},
+		ExpiresAt: &now,
+		CreatedAt: now,
+	}
+	id := faker.UUIDHyphenated()
+	conn, dbMock := newMockConn()
+	defer conn.Close()
+	akdb := NewAPIKeyDB(&PostgreConnection{
+		Db: conn,
+	})
+
+	dbMock.ExpectQuery("INSERT INTO api_keys").
+		WithArgs(uuid.MustParse(apiKey.UserID), apiKey.Name, apiKey.Prefix, apiKey.Hash, pq.Array(apiKey.Scopes), apiKey.ExpiresAt, apiKey.CreatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+	assert := assert.New(t)
+	assert.NoError(akdb.CreateAPIKey(context.Background(), apiKey))
+	assert.Equal(id, apiKey.ID)
+	assert.NoError(dbMock.ExpectationsWereMet())
+}
+
+func TestAPIKeyDB_GetAPIKeyByPrefix(t *testing.T) {
+	id := faker.UUIDHyphenated()
+	userID := faker.UUIDHyphenated()
+	now := time.Now()
+	columns := []string{"id", "user_id", "name", "prefix", "hash", "scopes", "expires_at", "last_used_at", "revoked_at", "created_at"}
+	tests := []struct {
+		name       string
+		want       *entity.APIKey
+		wantErr    error
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "when key is not found, it should return not found error",
+			wantErr: driven.ErrNotFound,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM api_keys WHERE prefix").WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name: "when key is found, it should return the optional times",
+			want: &entity.APIKey{
+				ID:         id,
+				UserID:     userID,
+				Name:       "backup script",
+				Prefix:     "usk_1a2b3c4d5e6f",
+				Hash:       "hash",
+				Scopes:     []string{entity.APIKeyScopeUsersRead, entity.APIKeyScopeUsersWrite},
+				LastUsedAt: &now,
+				CreatedAt:  now,
+			},
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM api_keys WHERE prefix").
+					WithArgs("usk_1a2b3c4d5e6f").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(id, userID, "backup script", "usk_1a2b3c4d5e6f", "hash", "{users:read,users:write}", nil, now, nil, now))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			akdb := NewAPIKeyDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			got, err := akdb.GetAPIKeyByPrefix(context.Background(), "usk_1a2b3c4d5e6f")
+
+			assert := assert.New(t)
+			assert.ErrorIs(err, tt.wantErr)
+			assert.Equal(tt.want, got)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAPIKeyDB_RevokeAPIKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantErr    error
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "when key of the user is not found, it should return not found error",
+			wantErr: driven.ErrNotFound,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE api_keys SET revoked_at (.+) AND revoked_at IS NULL").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "when key is revoked, it should return no error",
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE api_keys SET revoked_at (.+) AND revoked_at IS NULL").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			akdb := NewAPIKeyDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			err := akdb.RevokeAPIKey(context.Background(), faker.UUIDHyphenated(), faker.UUIDHyphenated(), time.Now())
+
+			assert := assert.New(t)
+			assert.ErrorIs(err, tt.wantErr)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAPIKeyDB_TouchAPIKey(t *testing.T) {
+	id := faker.UUIDHyphenated()
+	usedAt := time.Now()
+	conn, dbMock := newMockConn()
+	defer conn.Close()
+	akdb := NewAPIKeyDB(&PostgreConnection{
+		Db: conn,
+	})
+
+	// the key used in the last minute is not written again
+	dbMock.ExpectExec("UPDATE api_keys SET last_used_at (.+) AND \\(last_used_at IS NULL OR last_used_at < (.+)\\)").
+		WithArgs(usedAt, uuid.MustParse(id), usedAt.Add(-time.Minute)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert := assert.New(t)
+	assert.NoError(akdb.TouchAPIKey(context.Background(), id, usedAt))
+	assert.NoError(dbMock.ExpectationsWereMet())
+}
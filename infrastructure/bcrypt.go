@@ -1,20 +1,58 @@
 package infrastructure
 
 import (
+	"context"
+	"errors"
+	"time"
+
+	"userservice/internal/tracing"
 	"userservice/internal/user/port/driven"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/crypto/bcrypt"
 )
 
 var _ driven.Encyptor = new(BcyrpEncryption)
 
-type BcyrpEncryption struct{}
+// BcyrpEncryption records the duration of the hashing when it has metrics, the zero value has none.
+type BcyrpEncryption struct {
+	metrics driven.Metrics
+}
+
+func NewBcryptEncryption(metrics driven.Metrics) *BcyrpEncryption {
+	return &BcyrpEncryption{
+		metrics: metrics,
+	}
+}
 
 // Encrypt implements driven.Encyptor.
-func (*BcyrpEncryption) Encrypt(data []byte, cost int) ([]byte, error) {
+func (be *BcyrpEncryption) Encrypt(ctx context.Context, data []byte, cost int) (_ []byte, err error) {
+	_, span := tracing.Start(ctx, "bcrypt.GenerateFromPassword", trace.WithAttributes(attribute.Int("bcrypt.cost", cost)))
+	defer func() { tracing.End(span, err) }()
+	defer be.observe(driven.PasswordHashingHash, time.Now())
+
 	return bcrypt.GenerateFromPassword(data, cost)
 }
 
-func (*BcyrpEncryption) CompareEncryptedAndData(encrypted, data []byte) error {
-	return bcrypt.CompareHashAndPassword(encrypted, data)
+func (be *BcyrpEncryption) CompareEncryptedAndData(ctx context.Context, encrypted, data []byte) error {
+	_, span := tracing.Start(ctx, "bcrypt.CompareHashAndPassword")
+	start := time.Now()
+	err := bcrypt.CompareHashAndPassword(encrypted, data)
+	be.observe(driven.PasswordHashingCompare, start)
+	span.SetAttributes(attribute.Bool("bcrypt.match", err == nil))
+
+	// a wrong password is not a failure of the comparison
+	spanErr := err
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		spanErr = nil
+	}
+	tracing.End(span, spanErr)
+	return err
+}
+
+func (be *BcyrpEncryption) observe(operation string, start time.Time) {
+	if be.metrics != nil {
+		be.metrics.PasswordHashed(operation, time.Since(start))
+	}
 }
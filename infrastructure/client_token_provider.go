@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"time"
+
+	"userservice/config"
+	"userservice/internal/user/param/response"
+	"userservice/internal/user/port/driven"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+var _ driven.TokenProvider[string] = new(ClientTokenProvider)
+
+// ClientTokenProvider issues the tokens of the clients which are not users, e.g. other services or support tooling,
+// it is signed with the same key as the user token so the tokens are validated the same way.
+type ClientTokenProvider struct {
+	userTokenProvider *UserTokenProvider
+	ClientType        string
+	ExpiresSecond     int
+}
+
+func NewClientTokenProvider(conf *config.JWT, clientType string, expiresSecond int) *ClientTokenProvider {
+	return &ClientTokenProvider{
+		userTokenProvider: NewUserTokenProvider(conf),
+		ClientType:        clientType,
+		ExpiresSecond:     expiresSecond,
+	}
+}
+
+// Generate creates the token of the client, the client name is used as the subject.
+func (ctp *ClientTokenProvider) Generate(clientName string) (*response.Token, error) {
+	jwtID, _ := uuid.NewRandom()
+	claims := jwt.MapClaims{
+		"iss":         "SawitPro",
+		"sub":         clientName,
+		"aud":         []string{"user-service"},
+		"exp":         jwt.NewNumericDate(time.Now().Add(time.Second * time.Duration(ctp.ExpiresSecond))),
+		"nbf":         jwt.NewNumericDate(time.Now()),
+		"iat":         jwt.NewNumericDate(time.Now()),
+		"jti":         jwtID.String(),
+		"client_type": ctp.ClientType,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tokenString, err := token.SignedString(ctp.userTokenProvider.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Token{
+		Token:     tokenString,
+		ExpiresIn: ctp.ExpiresSecond,
+		Type:      "Bearer",
+	}, nil
+}
+
+func (ctp *ClientTokenProvider) ValidateJWT(tokenString string) (map[string]interface{}, error) {
+	return ctp.userTokenProvider.ValidateJWT(tokenString)
+}
@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"fmt"
+	"time"
+
+	"userservice/config"
+	"userservice/internal/user/port/driven"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const emailVerificationAudience = "email-verification"
+
+var _ driven.VerificationTokenProvider = new(EmailVerificationTokenProvider)
+
+type EmailVerificationTokenProvider struct {
+	Secret        []byte
+	ExpiresSecond int
+}
+
+type emailVerificationClaims struct {
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+func NewEmailVerificationTokenProvider(conf *config.Email) *EmailVerificationTokenProvider {
+	if conf.VerificationSecret == "" {
+		panic("email verification secret is empty")
+	}
+	return &EmailVerificationTokenProvider{
+		Secret:        []byte(conf.VerificationSecret),
+		ExpiresSecond: conf.VerificationExpiresSecond,
+	}
+}
+
+// Generate implements driven.VerificationTokenProvider.
+func (evtp *EmailVerificationTokenProvider) Generate(userID, email string) (string, error) {
+	claims := emailVerificationClaims{
+		Email: email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "SawitPro",
+			Subject:   userID,
+			Audience:  []string{emailVerificationAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Second * time.Duration(evtp.ExpiresSecond))),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(evtp.Secret)
+}
+
+// Validate implements driven.VerificationTokenProvider.
+func (evtp *EmailVerificationTokenProvider) Validate(tokenString string) (userID, email string, err error) {
+	var claims emailVerificationClaims
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return evtp.Secret, nil
+	}, jwt.WithAudience(emailVerificationAudience))
+	if err != nil {
+		return "", "", err
+	}
+
+	if !token.Valid || claims.Subject == "" || claims.Email == "" {
+		return "", "", ErrorInvalidToken
+	}
+
+	return claims.Subject, claims.Email, nil
+}
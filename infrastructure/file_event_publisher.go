@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"userservice/config"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+)
+
+var _ driven.EventPublisher = new(FileEventPublisher)
+
+// FileEventPublisher is a local stand-in for a real message broker,
+// every event is written as a JSON line into the file, or stdout when the path is empty.
+type FileEventPublisher struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+type publishedEvent struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregateId"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+func NewFileEventPublisher(conf *config.Outbox) *FileEventPublisher {
+	if conf.PublisherPath == "" {
+		return &FileEventPublisher{
+			writer: os.Stdout,
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(conf.PublisherPath), 0o755); err != nil {
+		panic(err)
+	}
+	file, err := os.OpenFile(conf.PublisherPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		panic(err)
+	}
+	return &FileEventPublisher{
+		writer: file,
+	}
+}
+
+// Publish implements driven.EventPublisher.
+// The file is synced before returning, so a published event is not lost on crash.
+func (fep *FileEventPublisher) Publish(ctx context.Context, event *entity.Event) error {
+	line, err := json.Marshal(publishedEvent{
+		ID:          event.ID,
+		Type:        event.Type,
+		AggregateID: event.AggregateID,
+		OccurredAt:  event.OccurredAt,
+		Payload:     event.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	fep.mu.Lock()
+	defer fep.mu.Unlock()
+	if _, err := fep.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if file, ok := fep.writer.(*os.File); ok && file != os.Stdout {
+		return file.Sync()
+	}
+	return nil
+}
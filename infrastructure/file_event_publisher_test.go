@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"userservice/config"
+	"userservice/internal/user/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileEventPublisher_Publish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "events.jsonl")
+	publisher := NewFileEventPublisher(&config.Outbox{
+		PublisherPath: path,
+	})
+
+	created := entity.NewUserCreatedEvent(&entity.User{ID: "5f0c9c5e-8d5f-4b3c-9d8b-2b7c1c0b5a11", FullName: "John Doe"})
+	loggedIn := entity.NewUserLoggedInEvent("5f0c9c5e-8d5f-4b3c-9d8b-2b7c1c0b5a11")
+
+	assert := assert.New(t)
+	assert.NoError(publisher.Publish(context.Background(), created))
+	assert.NoError(publisher.Publish(context.Background(), loggedIn))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(lines, 2)
+
+	var published map[string]any
+	assert.NoError(json.Unmarshal([]byte(lines[0]), &published))
+	assert.Equal(created.ID, published["id"])
+	assert.Equal(entity.UserCreatedEventType, published["type"])
+	assert.Equal("5f0c9c5e-8d5f-4b3c-9d8b-2b7c1c0b5a11", published["aggregateId"])
+	assert.Equal("John Doe", published["payload"].(map[string]any)["fullName"])
+}
@@ -0,0 +1,68 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"userservice/config"
+	"userservice/internal/user/port/driven"
+)
+
+var _ driven.BlobStore = new(FilesystemBlobStore)
+
+// FilesystemBlobStore keeps every blob as a file under the path,
+// the files are expected to be served publicly under the base URL.
+type FilesystemBlobStore struct {
+	Path    string
+	BaseURL string
+}
+
+func NewFilesystemBlobStore(conf *config.Blob) *FilesystemBlobStore {
+	if err := os.MkdirAll(conf.Path, 0o755); err != nil {
+		panic(err)
+	}
+	return &FilesystemBlobStore{
+		Path:    conf.Path,
+		BaseURL: strings.TrimSuffix(conf.BaseURL, "/"),
+	}
+}
+
+// Put implements driven.BlobStore.
+// The content type is not stored, it is derived from the file extension when served.
+func (fbs *FilesystemBlobStore) Put(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	if key == "" || path.Clean("/"+key) != "/"+key {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	filename := filepath.Join(fbs.Path, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return "", err
+	}
+
+	// write to a temporary file first then rename, so reader never see a partial blob
+	tmpFile, err := os.CreateTemp(filepath.Dir(filename), ".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return "", err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmpFile.Name(), 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpFile.Name(), filename); err != nil {
+		return "", err
+	}
+
+	return fbs.BaseURL + "/" + key, nil
+}
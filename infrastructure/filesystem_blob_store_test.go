@@ -0,0 +1,66 @@
+package infrastructure
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"userservice/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesystemBlobStore_Put(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "when key is empty, it should return error",
+			key:     "",
+			wantErr: true,
+		},
+		{
+			name:    "when key escape the path, it should return error",
+			key:     "../avatar.png",
+			wantErr: true,
+		},
+		{
+			name:    "when key is not clean, it should return error",
+			key:     "avatars//avatar.png",
+			wantErr: true,
+		},
+		{
+			name:    "when success, it should write the blob and return the url",
+			key:     "avatars/1/original.png",
+			want:    "http://localhost:8080/blobs/avatars/1/original.png",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			store := NewFilesystemBlobStore(&config.Blob{
+				Path:    dir,
+				BaseURL: "http://localhost:8080/blobs/",
+			})
+
+			got, err := store.Put(context.Background(), tt.key, "image/png", []byte("data"))
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.Equal(tt.want, got)
+			if !tt.wantErr {
+				data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(tt.key)))
+				assert.NoError(err)
+				assert.Equal("data", string(data))
+
+				files, _ := os.ReadDir(filepath.Dir(filepath.Join(dir, filepath.FromSlash(tt.key))))
+				assert.Len(files, 1)
+			}
+		})
+	}
+}
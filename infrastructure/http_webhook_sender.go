@@ -0,0 +1,94 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"userservice/config"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+)
+
+var _ driven.WebhookSender = new(HTTPWebhookSender)
+
+// defaultWebhookTimeout is used when the timeout is not set, a zero timeout would wait for a hanging partner forever
+const defaultWebhookTimeout = 10 * time.Second
+
+// the response body is not used, only a small part is read so the connection can be reused
+const webhookResponseBodyReadLimit = 64 * 1024
+
+var errWebhookAddressNotAllowed = errors.New("webhook address is not allowed")
+
+type HTTPWebhookSender struct {
+	client *http.Client
+}
+
+func NewHTTPWebhookSender(conf *config.Webhook) *HTTPWebhookSender {
+	timeout := time.Duration(conf.TimeoutSecond) * time.Second
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	dialer := &net.Dialer{
+		Timeout: timeout,
+	}
+	if !conf.AllowPrivateNetworks {
+		dialer.Control = denyPrivateNetworks
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would be dialed instead of the webhook address
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &HTTPWebhookSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// a redirect could send the signed payload to another host
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// denyPrivateNetworks runs on every resolved address before it is connected,
+// so a public host name resolving to an internal address is rejected as well.
+func denyPrivateNetworks(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errWebhookAddressNotAllowed, address)
+	}
+	if !entity.WebhookAddressAllowed(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", errWebhookAddressNotAllowed, addrPort.Addr())
+	}
+	return nil
+}
+
+// Send implements driven.WebhookSender.
+func (hws *HTTPWebhookSender) Send(ctx context.Context, request *entity.WebhookRequest) (int, error) {
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Body))
+	if err != nil {
+		return 0, err
+	}
+	for key, value := range request.Headers {
+		httpRequest.Header.Set(key, value)
+	}
+
+	response, err := hws.client.Do(httpRequest)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, webhookResponseBodyReadLimit))
+
+	return response.StatusCode, nil
+}
@@ -0,0 +1,107 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"userservice/config"
+	"userservice/internal/user/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPWebhookSender_Send(t *testing.T) {
+	delivery := &entity.WebhookDelivery{
+		Subscription: &entity.WebhookSubscription{
+			Secret: "0123456789abcdef",
+		},
+		EventID:   "b1d7b6a2-6c0e-4f43-9d0e-2f3c1a4f5e6d",
+		EventType: entity.UserCreatedEventType,
+		Payload:   []byte(`{"id":"1"}`),
+	}
+
+	var gotSignature, gotTimestamp, gotEventType string
+	var gotBody []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(entity.WebhookSignatureHeader)
+		gotTimestamp = r.Header.Get(entity.WebhookTimestampHeader)
+		gotEventType = r.Header.Get(entity.WebhookEventTypeHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer receiver.Close()
+	delivery.Subscription.URL = receiver.URL
+
+	sender := NewHTTPWebhookSender(&config.Webhook{TimeoutSecond: 1, AllowPrivateNetworks: true})
+	statusCode, err := sender.Send(context.Background(), delivery.NewRequest(time.Unix(1700000000, 0)))
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	assert.Equal(http.StatusAccepted, statusCode)
+	assert.Equal(delivery.Payload, gotBody)
+	assert.Equal("1700000000", gotTimestamp)
+	assert.Equal(entity.UserCreatedEventType, gotEventType)
+	assert.Equal("sha256="+entity.SignWebhookPayload("0123456789abcdef", "1700000000", gotBody), gotSignature)
+}
+
+func TestHTTPWebhookSender_Send_DoesNotFollowRedirect(t *testing.T) {
+	redirected := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer receiver.Close()
+
+	sender := NewHTTPWebhookSender(&config.Webhook{TimeoutSecond: 1, AllowPrivateNetworks: true})
+	statusCode, err := sender.Send(context.Background(), &entity.WebhookRequest{URL: receiver.URL})
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	assert.Equal(http.StatusTemporaryRedirect, statusCode)
+	assert.False(redirected)
+}
+
+func TestHTTPWebhookSender_Send_Timeout(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(1500 * time.Millisecond)
+	}))
+	defer receiver.Close()
+
+	sender := NewHTTPWebhookSender(&config.Webhook{TimeoutSecond: 1, AllowPrivateNetworks: true})
+	statusCode, err := sender.Send(context.Background(), &entity.WebhookRequest{URL: receiver.URL})
+
+	assert := assert.New(t)
+	assert.Error(err)
+	assert.Equal(0, statusCode)
+}
+
+func TestNewHTTPWebhookSender_withoutTimeout(t *testing.T) {
+	sender := NewHTTPWebhookSender(&config.Webhook{})
+
+	// the default is used instead of waiting for a hanging partner forever
+	assert.Equal(t, defaultWebhookTimeout, sender.client.Timeout)
+}
+
+func TestHTTPWebhookSender_Send_DeniesPrivateNetworks(t *testing.T) {
+	received := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+	defer receiver.Close()
+
+	sender := NewHTTPWebhookSender(&config.Webhook{TimeoutSecond: 1})
+	statusCode, err := sender.Send(context.Background(), &entity.WebhookRequest{URL: receiver.URL})
+
+	assert := assert.New(t)
+	assert.True(errors.Is(err, errWebhookAddressNotAllowed))
+	assert.Equal(0, statusCode)
+	assert.False(received)
+}
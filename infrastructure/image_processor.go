@@ -0,0 +1,213 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const (
+	jpegQuality            = 90
+	exifOrientationTag     = 0x0112
+	jpegMarkerStartOfScan  = 0xDA
+	jpegMarkerApplication1 = 0xE1
+)
+
+var _ driven.ImageProcessor = new(ImageProcessor)
+
+type decoder struct {
+	decode       func(io.Reader) (image.Image, error)
+	decodeConfig func(io.Reader) (image.Config, error)
+}
+
+var decoders = map[string]decoder{
+	"image/jpeg": {jpeg.Decode, jpeg.DecodeConfig},
+	"image/png":  {png.Decode, png.DecodeConfig},
+	"image/webp": {webp.Decode, webp.DecodeConfig},
+}
+
+// ImageProcessor drops every metadata, including EXIF, by decoding the image
+// into pixels and encoding it again. JPEG stays JPEG, the other formats
+// are encoded as PNG because there is no WebP encoder in pure Go.
+type ImageProcessor struct{}
+
+func NewImageProcessor() *ImageProcessor {
+	return new(ImageProcessor)
+}
+
+// Process implements driven.ImageProcessor.
+func (ImageProcessor) Process(img *entity.Image, thumbnailSizes []int) (*entity.Image, []entity.Image, error) {
+	decoder, ok := decoders[img.ContentType]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported content type %q", img.ContentType)
+	}
+
+	config, err := decoder.decodeConfig(bytes.NewReader(img.Data))
+	if err != nil {
+		return nil, nil, err
+	}
+	if config.Width > entity.AvatarMaxDimension || config.Height > entity.AvatarMaxDimension {
+		return nil, nil, fmt.Errorf("image dimension %dx%d is too large", config.Width, config.Height)
+	}
+
+	decoded, err := decoder.decode(bytes.NewReader(img.Data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contentType := "image/png"
+	if img.ContentType == "image/jpeg" {
+		contentType = "image/jpeg"
+		// the orientation is lost together with the EXIF, so apply it to the pixels
+		decoded = applyOrientation(decoded, jpegOrientation(img.Data))
+	}
+
+	processed, err := encodeImage(decoded, contentType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var thumbnails []entity.Image
+	for _, size := range thumbnailSizes {
+		thumbnail, err := encodeImage(squareThumbnail(decoded, size), contentType)
+		if err != nil {
+			return nil, nil, err
+		}
+		thumbnails = append(thumbnails, *thumbnail)
+	}
+
+	return processed, thumbnails, nil
+}
+
+func encodeImage(img image.Image, contentType string) (*entity.Image, error) {
+	var buf bytes.Buffer
+	var err error
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entity.Image{
+		ContentType: contentType,
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// squareThumbnail crops the center of the image into a square then scales it to the size.
+func squareThumbnail(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Point{
+		X: bounds.Min.X + (bounds.Dx()-side)/2,
+		Y: bounds.Min.Y + (bounds.Dy()-side)/2,
+	})
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(thumbnail, thumbnail.Bounds(), img, crop, draw.Src, nil)
+	return thumbnail
+}
+
+// applyOrientation rotates and flips the image based on the EXIF orientation value.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if orientation >= 5 {
+		width, height = height, width
+	}
+
+	oriented := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var srcX, srcY int
+			switch orientation {
+			case 2: // mirror horizontal
+				srcX, srcY = bounds.Dx()-1-x, y
+			case 3: // rotate 180
+				srcX, srcY = bounds.Dx()-1-x, bounds.Dy()-1-y
+			case 4: // mirror vertical
+				srcX, srcY = x, bounds.Dy()-1-y
+			case 5: // transpose
+				srcX, srcY = y, x
+			case 6: // rotate 90 clockwise
+				srcX, srcY = y, bounds.Dy()-1-x
+			case 7: // transverse
+				srcX, srcY = bounds.Dx()-1-y, bounds.Dy()-1-x
+			case 8: // rotate 90 counter clockwise
+				srcX, srcY = bounds.Dx()-1-y, x
+			}
+			oriented.Set(x, y, img.At(bounds.Min.X+srcX, bounds.Min.Y+srcY))
+		}
+	}
+	return oriented
+}
+
+// jpegOrientation reads the orientation tag from the EXIF segment of the JPEG,
+// it returns 0 when there is no orientation.
+func jpegOrientation(data []byte) int {
+	// skip the start of image marker
+	offset := 2
+	for offset+4 <= len(data) && data[offset] == 0xFF {
+		marker := data[offset+1]
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		if marker == jpegMarkerStartOfScan || length < 2 || offset+2+length > len(data) {
+			return 0
+		}
+
+		segment := data[offset+4 : offset+2+length]
+		if marker == jpegMarkerApplication1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		offset += 2 + length
+	}
+	return 0
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var byteOrder binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		byteOrder = binary.LittleEndian
+	case "MM":
+		byteOrder = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifdOffset := int(byteOrder.Uint32(tiff[4:]))
+	if ifdOffset+2 > len(tiff) {
+		return 0
+	}
+
+	entries := int(byteOrder.Uint16(tiff[ifdOffset:]))
+	for i := 0; i < entries; i++ {
+		entry := ifdOffset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if byteOrder.Uint16(tiff[entry:]) == exifOrientationTag {
+			return int(byteOrder.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 0
+}
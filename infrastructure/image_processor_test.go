@@ -0,0 +1,187 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"userservice/internal/user/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 1x1 lossless WebP
+const webpImage = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+
+func newTestImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func encodeTestPNG(width, height int) []byte {
+	var buf bytes.Buffer
+	png.Encode(&buf, newTestImage(width, height))
+	return buf.Bytes()
+}
+
+// encodeTestJPEG encodes the image with an EXIF segment containing the orientation.
+func encodeTestJPEG(width, height int, orientation uint16) []byte {
+	var buf bytes.Buffer
+	jpeg.Encode(&buf, newTestImage(width, height), nil)
+
+	tiff := []byte{
+		'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08,
+		0x00, 0x01,
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, byte(orientation >> 8), byte(orientation), 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	}
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := append([]byte{0xFF, 0xE1, byte((len(segment) + 2) >> 8), byte(len(segment) + 2)}, segment...)
+
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), app1...), data[2:]...)
+}
+
+func TestImageProcessor_Process(t *testing.T) {
+	webpData, _ := base64.StdEncoding.DecodeString(webpImage)
+	tests := []struct {
+		name            string
+		image           *entity.Image
+		wantContentType string
+		wantWidth       int
+		wantHeight      int
+		wantErr         bool
+	}{
+		{
+			name: "when image is corrupted, it should return error",
+			image: &entity.Image{
+				ContentType: "image/png",
+				Data:        encodeTestPNG(10, 10)[:40],
+			},
+			wantErr: true,
+		},
+		{
+			name: "when image is too large, it should return error",
+			image: &entity.Image{
+				ContentType: "image/png",
+				Data:        encodeTestPNG(entity.AvatarMaxDimension+1, 1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "when image is png, it should return png",
+			image: &entity.Image{
+				ContentType: "image/png",
+				Data:        encodeTestPNG(40, 20),
+			},
+			wantContentType: "image/png",
+			wantWidth:       40,
+			wantHeight:      20,
+		},
+		{
+			name: "when image is webp, it should return png",
+			image: &entity.Image{
+				ContentType: "image/webp",
+				Data:        webpData,
+			},
+			wantContentType: "image/png",
+			wantWidth:       1,
+			wantHeight:      1,
+		},
+		{
+			name: "when jpeg has no rotation, it should keep the dimension",
+			image: &entity.Image{
+				ContentType: "image/jpeg",
+				Data:        encodeTestJPEG(40, 20, 1),
+			},
+			wantContentType: "image/jpeg",
+			wantWidth:       40,
+			wantHeight:      20,
+		},
+		{
+			name: "when jpeg is rotated, it should apply the orientation",
+			image: &entity.Image{
+				ContentType: "image/jpeg",
+				Data:        encodeTestJPEG(40, 20, 6),
+			},
+			wantContentType: "image/jpeg",
+			wantWidth:       20,
+			wantHeight:      40,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processed, thumbnails, err := NewImageProcessor().Process(tt.image, []int{8, 16})
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			if tt.wantErr {
+				return
+			}
+
+			assert.Equal(tt.wantContentType, processed.ContentType)
+			assert.False(bytes.Contains(processed.Data, []byte("Exif")))
+			config, _, err := image.DecodeConfig(bytes.NewReader(processed.Data))
+			assert.NoError(err)
+			assert.Equal(tt.wantWidth, config.Width)
+			assert.Equal(tt.wantHeight, config.Height)
+
+			assert.Len(thumbnails, 2)
+			for i, size := range []int{8, 16} {
+				assert.Equal(tt.wantContentType, thumbnails[i].ContentType)
+				config, _, err := image.DecodeConfig(bytes.NewReader(thumbnails[i].Data))
+				assert.NoError(err)
+				assert.Equal(size, config.Width)
+				assert.Equal(size, config.Height)
+			}
+		})
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// 2x1 image, red on the left and blue on the right
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	img.Set(0, 0, red)
+	img.Set(1, 0, blue)
+
+	tests := []struct {
+		name        string
+		orientation int
+		want        map[image.Point]color.RGBA
+	}{
+		{
+			name:        "when mirrored, it should swap left and right",
+			orientation: 2,
+			want:        map[image.Point]color.RGBA{{0, 0}: blue, {1, 0}: red},
+		},
+		{
+			name:        "when rotated 90 clockwise, it should put left on top",
+			orientation: 6,
+			want:        map[image.Point]color.RGBA{{0, 0}: red, {0, 1}: blue},
+		},
+		{
+			name:        "when rotated 90 counter clockwise, it should put left on bottom",
+			orientation: 8,
+			want:        map[image.Point]color.RGBA{{0, 0}: blue, {0, 1}: red},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyOrientation(img, tt.orientation)
+			for point, want := range tt.want {
+				assert.Equal(t, want, color.RGBAModel.Convert(got.At(point.X, point.Y)))
+			}
+		})
+	}
+}
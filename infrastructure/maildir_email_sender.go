@@ -0,0 +1,64 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"userservice/config"
+	"userservice/internal/user/port/driven"
+
+	"github.com/google/uuid"
+)
+
+var _ driven.EmailSender = new(MaildirEmailSender)
+
+// MaildirEmailSender is a local stand-in for a real email provider,
+// every email is written as a file into the maildir so it can be opened by a mail client.
+type MaildirEmailSender struct {
+	From string
+	Path string
+}
+
+func NewMaildirEmailSender(conf *config.Email) *MaildirEmailSender {
+	for _, dir := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(conf.MaildirPath, dir), 0o755); err != nil {
+			panic(err)
+		}
+	}
+	return &MaildirEmailSender{
+		From: conf.From,
+		Path: conf.MaildirPath,
+	}
+}
+
+// Send implements driven.EmailSender.
+func (mes *MaildirEmailSender) Send(ctx context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return fmt.Errorf("invalid email header")
+	}
+
+	now := time.Now()
+	message := strings.Join([]string{
+		"From: " + mes.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"Date: " + now.Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	// write to tmp first then move to new, so reader never see a partial email
+	filename := fmt.Sprintf("%d.%s.userservice", now.UnixNano(), uuid.NewString())
+	tmpPath := filepath.Join(mes.Path, "tmp", filename)
+	if err := os.WriteFile(tmpPath, []byte(message), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, filepath.Join(mes.Path, "new", filename))
+}
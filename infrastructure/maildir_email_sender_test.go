@@ -0,0 +1,71 @@
+package infrastructure
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"userservice/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaildirEmailSender_Send(t *testing.T) {
+	type args struct {
+		to      string
+		subject string
+		body    string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantErr   bool
+		wantFiles int
+	}{
+		{
+			name: "when header containt new line, it should return error",
+			args: args{
+				to:      "john@example.com\r\nBcc: other@example.com",
+				subject: "Verify your email address",
+				body:    "body",
+			},
+			wantErr:   true,
+			wantFiles: 0,
+		},
+		{
+			name: "when success, it should write email into new directory",
+			args: args{
+				to:      "john@example.com",
+				subject: "Verify your email address",
+				body:    "body",
+			},
+			wantErr:   false,
+			wantFiles: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			sender := NewMaildirEmailSender(&config.Email{
+				From:        "no-reply@localhost",
+				MaildirPath: dir,
+			})
+
+			err := sender.Send(context.Background(), tt.args.to, tt.args.subject, tt.args.body)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+
+			files, _ := os.ReadDir(filepath.Join(dir, "new"))
+			assert.Len(files, tt.wantFiles)
+			if tt.wantFiles > 0 {
+				content, err := os.ReadFile(filepath.Join(dir, "new", files[0].Name()))
+				assert.NoError(err)
+				assert.Contains(string(content), "To: john@example.com\r\n")
+				assert.Contains(string(content), "Subject: Verify your email address\r\n")
+				assert.Contains(string(content), "\r\n\r\nbody")
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package infrastructure
+
+import (
+	"context"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+)
+
+var _ driven.EventPublisher = new(MultiEventPublisher)
+
+// MultiEventPublisher publishes the event to every publisher in order,
+// when one fails the event is published again to all of them, so every publisher must tolerate duplicates.
+type MultiEventPublisher struct {
+	publishers []driven.EventPublisher
+}
+
+func NewMultiEventPublisher(publishers ...driven.EventPublisher) *MultiEventPublisher {
+	return &MultiEventPublisher{
+		publishers: publishers,
+	}
+}
+
+// Publish implements driven.EventPublisher.
+func (mep *MultiEventPublisher) Publish(ctx context.Context, event *entity.Event) error {
+	for _, publisher := range mep.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
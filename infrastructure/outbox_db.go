@@ -0,0 +1,131 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"github.com/google/uuid"
+)
+
+var _ driven.EventOutbox = new(OutboxDB)
+
+type OutboxDB struct {
+	conn *PostgreConnection
+}
+
+func NewOutboxDB(db *PostgreConnection) *OutboxDB {
+	return &OutboxDB{
+		conn: db,
+	}
+}
+
+// insertOutboxEvent stores the event in the transaction of the state change,
+// so the event is never lost nor stored without the change.
+// The payload is sent as string, pq sends []byte as bytea which is not a valid JSON.
+func insertOutboxEvent(ctx context.Context, tx *sql.Tx, event *entity.Event) error {
+	uuidAggregate, _ := uuid.Parse(event.AggregateID)
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO
+			outbox (id, event_type, aggregate_id, payload, occurred_at)
+		VALUES
+			($1, $2, $3, $4, $5)
+	`, event.ID, event.Type, uuidAggregate, string(event.Payload), event.OccurredAt)
+	return err
+}
+
+// ClaimUnpublished implements driven.EventOutbox.
+// SKIP LOCKED lets many relays claim different events at the same time.
+func (odb *OutboxDB) ClaimUnpublished(ctx context.Context, limit int, lockDuration time.Duration) ([]*entity.Event, error) {
+	rows, err := odb.conn.Db.QueryContext(ctx, `
+		UPDATE
+			outbox
+		SET
+			locked_until = NOW() + $2::BIGINT * INTERVAL '1 millisecond'
+		WHERE
+			id IN (
+				SELECT
+					id
+				FROM
+					outbox
+				WHERE
+					published_at IS NULL
+					AND (locked_until IS NULL OR locked_until < NOW())
+				ORDER BY
+					occurred_at
+				LIMIT
+					$1
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING
+			id,
+			event_type,
+			aggregate_id,
+			payload,
+			occurred_at,
+			attempts
+	`, limit, lockDuration.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*entity.Event{}
+	for rows.Next() {
+		var event entity.Event
+		err = rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.AggregateID,
+			&event.Payload,
+			&event.OccurredAt,
+			&event.Attempts,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING does not keep the order of the subquery
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+	return events, nil
+}
+
+// MarkPublished implements driven.EventOutbox.
+func (odb *OutboxDB) MarkPublished(ctx context.Context, id string) error {
+	_, err := odb.conn.Db.ExecContext(ctx, `
+		UPDATE
+			outbox
+		SET
+			published_at = NOW(),
+			locked_until = NULL
+		WHERE
+			id = $1
+	`, id)
+	return err
+}
+
+// MarkFailed implements driven.EventOutbox.
+// The event is still locked, so it is retried after the lock duration.
+func (odb *OutboxDB) MarkFailed(ctx context.Context, id string, reason string) error {
+	_, err := odb.conn.Db.ExecContext(ctx, `
+		UPDATE
+			outbox
+		SET
+			attempts = attempts + 1,
+			last_error = $2
+		WHERE
+			id = $1
+	`, id, reason)
+	return err
+}
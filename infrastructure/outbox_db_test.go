@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"userservice/internal/user/entity"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxDB_ClaimUnpublished(t *testing.T) {
+	older := &entity.Event{
+		ID:          faker.UUIDHyphenated(),
+		Type:        entity.UserCreatedEventType,
+		AggregateID: faker.UUIDHyphenated(),
+		Payload:     []byte(`{"id":"1"}`),
+		OccurredAt:  time.Now().Add(-time.Minute),
+	}
+	newer := &entity.Event{
+		ID:          faker.UUIDHyphenated(),
+		Type:        entity.UserLoggedInEventType,
+		AggregateID: older.AggregateID,
+		Payload:     []byte(`{"id":"1"}`),
+		OccurredAt:  time.Now(),
+		Attempts:    2,
+	}
+	columns := []string{"id", "event_type", "aggregate_id", "payload", "occurred_at", "attempts"}
+	tests := []struct {
+		name       string
+		want       []*entity.Event
+		wantErr    bool
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "when error on database, it should return error",
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE outbox").WithArgs(10, int64(30000)).WillReturnError(errors.New("database error"))
+			},
+		},
+		{
+			name: "when events claimed, it should return the oldest first",
+			want: []*entity.Event{older, newer},
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE outbox SET locked_until (.+) FOR UPDATE SKIP LOCKED").
+					WithArgs(10, int64(30000)).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(newer.ID, newer.Type, newer.AggregateID, newer.Payload, newer.OccurredAt, newer.Attempts).
+						AddRow(older.ID, older.Type, older.AggregateID, older.Payload, older.OccurredAt, older.Attempts))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			odb := NewOutboxDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			got, err := odb.ClaimUnpublished(context.Background(), 10, 30*time.Second)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.Equal(tt.want, got)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOutboxDB_MarkPublished(t *testing.T) {
+	id := faker.UUIDHyphenated()
+	conn, dbMock := newMockConn()
+	defer conn.Close()
+	odb := NewOutboxDB(&PostgreConnection{
+		Db: conn,
+	})
+	dbMock.ExpectExec("UPDATE outbox SET published_at = NOW\\(\\)").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := odb.MarkPublished(context.Background(), id)
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	assert.NoError(dbMock.ExpectationsWereMet())
+}
+
+func TestOutboxDB_MarkFailed(t *testing.T) {
+	id := faker.UUIDHyphenated()
+	conn, dbMock := newMockConn()
+	defer conn.Close()
+	odb := NewOutboxDB(&PostgreConnection{
+		Db: conn,
+	})
+	dbMock.ExpectExec("UPDATE outbox SET attempts = attempts \\+ 1").WithArgs(id, "broker is down").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := odb.MarkFailed(context.Background(), id, "broker is down")
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	assert.NoError(dbMock.ExpectationsWereMet())
+}
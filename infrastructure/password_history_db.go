@@ -0,0 +1,88 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"userservice/internal/user/port/driven"
+
+	"github.com/google/uuid"
+)
+
+var _ driven.PasswordHistory = new(PasswordHistoryDB)
+
+type PasswordHistoryDB struct {
+	conn *PostgreConnection
+}
+
+func NewPasswordHistoryDB(db *PostgreConnection) *PasswordHistoryDB {
+	return &PasswordHistoryDB{
+		conn: db,
+	}
+}
+
+// GetLatestByUserID implements driven.PasswordHistory.
+func (phdb *PasswordHistoryDB) GetLatestByUserID(ctx context.Context, userID string, limit int) ([]string, error) {
+	uuidUser, _ := uuid.Parse(userID)
+	rows, err := phdb.conn.Db.QueryContext(ctx, `
+		SELECT
+			password
+		FROM
+			password_history
+		WHERE
+			user_id = $1
+		ORDER BY
+			created_at DESC, id DESC
+		LIMIT
+			$2
+	`, uuidUser, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	var passwords []string
+	for rows.Next() {
+		var password string
+		if err := rows.Scan(&password); err != nil {
+			return nil, err
+		}
+		passwords = append(passwords, password)
+	}
+
+	return passwords, rows.Err()
+}
+
+// insertPasswordHistory stores the password in the transaction of the password change,
+// and removes the entries older than the latest limit of the user.
+func insertPasswordHistory(ctx context.Context, tx *sql.Tx, userID uuid.UUID, password string, limit int) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO
+			password_history (user_id, password)
+		VALUES
+			($1, $2)
+	`, userID, password)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM
+			password_history
+		WHERE
+			user_id = $1
+			AND id NOT IN (
+				SELECT
+					id
+				FROM
+					password_history
+				WHERE
+					user_id = $1
+				ORDER BY
+					created_at DESC, id DESC
+				LIMIT
+					$2
+			)
+	`, userID, limit)
+	return err
+}
@@ -0,0 +1,91 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordHistoryDB_GetLatestByUserID(t *testing.T) {
+	userID := faker.UUIDHyphenated()
+	type args struct {
+		ctx    context.Context
+		userID string
+		limit  int
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       []string
+		wantErr    bool
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name: "when something went wrong in db, it should return error",
+			args: args{
+				context.Background(),
+				userID,
+				5,
+			},
+			want:    nil,
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM password_history").
+					WithArgs(userID, 5).
+					WillReturnError(errors.New("some database error"))
+			},
+		},
+		{
+			name: "when history empty, it should return empty",
+			args: args{
+				context.Background(),
+				userID,
+				5,
+			},
+			want:    nil,
+			wantErr: false,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM password_history").
+					WithArgs(userID, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"password"}))
+			},
+		},
+		{
+			name: "when history found, it should return passwords",
+			args: args{
+				context.Background(),
+				userID,
+				5,
+			},
+			want:    []string{"hash-2", "hash-1"},
+			wantErr: false,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM password_history").
+					WithArgs(userID, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"password"}).AddRow("hash-2").AddRow("hash-1"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			phdb := NewPasswordHistoryDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			got, err := phdb.GetLatestByUserID(tt.args.ctx, tt.args.userID, tt.args.limit)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.Equal(tt.want, got)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
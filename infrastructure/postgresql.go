@@ -2,12 +2,18 @@
 package infrastructure
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"userservice/config"
+	"userservice/internal/tracing"
+	"userservice/internal/user/port/driven"
 
 	_ "github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type PostgreConnection struct {
@@ -24,3 +30,28 @@ func NewPostgreConnection(c *config.DBConfig) *PostgreConnection {
 		Db: db,
 	}
 }
+
+// Ping checks that the database can be reached, it is a check of the readiness.
+func (pc *PostgreConnection) Ping(ctx context.Context) error {
+	return pc.Db.PingContext(ctx)
+}
+
+// translateError returns driven.ErrNotFound when the row is not found.
+func translateError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return driven.ErrNotFound
+	}
+	return err
+}
+
+// startQuery starts the span of a statement of UserDB, it is named after the method,
+// the values of the statement are never recorded.
+func startQuery(ctx context.Context, statement string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "UserDB."+statement,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation.name", statement),
+		),
+	)
+}
@@ -0,0 +1,129 @@
+package infrastructure
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"userservice/internal/user/port/driven"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var _ driven.Metrics = new(PrometheusMetrics)
+
+const metricsNamespace = "userservice"
+
+// PrometheusMetrics records the metrics in its own registry with the Go, process and connection pool metrics.
+type PrometheusMetrics struct {
+	registry *prometheus.Registry
+
+	requestDuration         *prometheus.HistogramVec
+	passwordHashingDuration *prometheus.HistogramVec
+	signUps                 prometheus.Counter
+	loginSuccesses          prometheus.Counter
+	loginFailures           *prometheus.CounterVec
+	tokenValidationFailures *prometheus.CounterVec
+	profileUpdates          *prometheus.CounterVec
+}
+
+func NewPrometheusMetrics(db *PostgreConnection) *PrometheusMetrics {
+	pm := &PrometheusMetrics{
+		registry: prometheus.NewRegistry(),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of the HTTP requests by operation of api.yml and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "method", "status"}),
+		passwordHashingDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "password_hashing_duration_seconds",
+			Help:      "Duration of the bcrypt hashing and comparison of the passwords.",
+			// bcrypt is slow on purpose, around 50ms at the cost 10
+			Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation"}),
+		signUps: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "signups_total",
+			Help:      "Number of the created users.",
+		}),
+		loginSuccesses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "login_successes_total",
+			Help:      "Number of the generated user tokens.",
+		}),
+		loginFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "login_failures_total",
+			Help:      "Number of the rejected logins by reason.",
+		}, []string{"reason"}),
+		tokenValidationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "token_validation_failures_total",
+			Help:      "Number of the rejected credentials by reason.",
+		}, []string{"reason"}),
+		profileUpdates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "profile_updates_total",
+			Help:      "Number of the updated profiles by kind.",
+		}, []string{"kind"}),
+	}
+
+	pm.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		// the stats of sql.DB.Stats, e.g. go_sql_in_use_connections{db_name="userservice"}
+		collectors.NewDBStatsCollector(db.Db, metricsNamespace),
+		pm.requestDuration,
+		pm.passwordHashingDuration,
+		pm.signUps,
+		pm.loginSuccesses,
+		pm.loginFailures,
+		pm.tokenValidationFailures,
+		pm.profileUpdates,
+	)
+	return pm
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (pm *PrometheusMetrics) Handler() http.Handler {
+	return promhttp.HandlerFor(pm.registry, promhttp.HandlerOpts{Registry: pm.registry})
+}
+
+// RequestServed implements middleware.RequestMetrics.
+func (pm *PrometheusMetrics) RequestServed(operation, method string, status int, duration time.Duration) {
+	pm.requestDuration.WithLabelValues(operation, method, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// UserSignedUp implements driven.Metrics.
+func (pm *PrometheusMetrics) UserSignedUp() {
+	pm.signUps.Inc()
+}
+
+// LoginSucceeded implements driven.Metrics.
+func (pm *PrometheusMetrics) LoginSucceeded() {
+	pm.loginSuccesses.Inc()
+}
+
+// LoginFailed implements driven.Metrics.
+func (pm *PrometheusMetrics) LoginFailed(reason string) {
+	pm.loginFailures.WithLabelValues(reason).Inc()
+}
+
+// TokenValidationFailed implements driven.Metrics.
+func (pm *PrometheusMetrics) TokenValidationFailed(reason string) {
+	pm.tokenValidationFailures.WithLabelValues(reason).Inc()
+}
+
+// ProfileUpdated implements driven.Metrics.
+func (pm *PrometheusMetrics) ProfileUpdated(kind string) {
+	pm.profileUpdates.WithLabelValues(kind).Inc()
+}
+
+// PasswordHashed implements driven.Metrics.
+func (pm *PrometheusMetrics) PasswordHashed(operation string, duration time.Duration) {
+	pm.passwordHashingDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
@@ -0,0 +1,42 @@
+package infrastructure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"userservice/internal/user/port/driven"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheusMetrics_Handler(t *testing.T) {
+	conn, _ := newMockConn()
+	defer conn.Close()
+	pm := NewPrometheusMetrics(&PostgreConnection{
+		Db: conn,
+	})
+
+	pm.UserSignedUp()
+	pm.LoginFailed(driven.LoginFailureWrongPassword)
+	pm.LoginFailed(driven.LoginFailureWrongPassword)
+	pm.TokenValidationFailed("invalid_token")
+	pm.ProfileUpdated(driven.ProfileKindPrivacySettings)
+	pm.PasswordHashed(driven.PasswordHashingCompare, 50*time.Millisecond)
+	pm.RequestServed("GetUserByID", http.MethodGet, http.StatusOK, 10*time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	pm.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert := assert.New(t)
+	assert.Equal(http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(body, "userservice_signups_total 1")
+	assert.Contains(body, `userservice_login_failures_total{reason="wrong_password"} 2`)
+	assert.Contains(body, `userservice_token_validation_failures_total{reason="invalid_token"} 1`)
+	assert.Contains(body, `userservice_profile_updates_total{kind="privacy_settings"} 1`)
+	assert.Contains(body, `userservice_password_hashing_duration_seconds_count{operation="compare"} 1`)
+	assert.Contains(body, `userservice_http_request_duration_seconds_count{method="GET",operation="GetUserByID",status="200"} 1`)
+	assert.Contains(body, `go_sql_open_connections{db_name="userservice"}`)
+}
@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+
+	"github.com/lib/pq"
+)
+
+// GetByIDs implements driven.UserGetter.
+// The ids which are not found are simply not returned, the order of the users is not guaranteed.
+func (udb *UserDB) GetByIDs(ctx context.Context, ids []string) (_ []*entity.User, err error) {
+	ctx, span := startQuery(ctx, "GetByIDs")
+	defer func() { tracing.End(span, err) }()
+
+	return udb.getUsersWhere(ctx, "users.id = ANY($1)", pq.Array(ids))
+}
+
+// GetByPhoneNumbers implements driven.UserGetter.
+// The phone numbers which are not found are simply not returned, the order of the users is not guaranteed.
+func (udb *UserDB) GetByPhoneNumbers(ctx context.Context, phoneNumbers []string) (_ []*entity.User, err error) {
+	ctx, span := startQuery(ctx, "GetByPhoneNumbers")
+	defer func() { tracing.End(span, err) }()
+
+	return udb.getUsersWhere(ctx, "users.phone_number = ANY($1)", pq.Array(phoneNumbers))
+}
+
+// getUsersWhere finds the users in a single query, the password is never selected.
+func (udb *UserDB) getUsersWhere(ctx context.Context, condition string, arg any) ([]*entity.User, error) {
+	rows, err := udb.conn.Db.QueryContext(ctx, `
+		SELECT
+			users.id,
+			users.full_name,
+			users.phone_number,
+			COALESCE(users.email, ''),
+			users.email_verified_at IS NOT NULL,
+			COALESCE(user_profiles.avatar_url, ''),
+			COALESCE(users.username, ''),
+			users.username_changed_at,
+			users.created_at,
+			users.updated_at
+		FROM
+			users
+			LEFT JOIN user_profiles ON user_profiles.user_id = users.id
+		WHERE
+			`+condition, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*entity.User{}
+	for rows.Next() {
+		var user entity.User
+		var usernameChangedAt sql.NullTime
+		err = rows.Scan(
+			&user.ID,
+			&user.FullName,
+			&user.PhoneNumber,
+			&user.Email,
+			&user.EmailVerified,
+			&user.AvatarURL,
+			&user.Username,
+			&usernameChangedAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if usernameChangedAt.Valid {
+			user.UsernameChangedAt = &usernameChangedAt.Time
+		}
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
@@ -0,0 +1,118 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"userservice/internal/user/entity"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var batchUserColumns = []string{"id", "full_name", "phone_number", "email", "email_verified", "avatar_url", "username", "username_changed_at", "created_at", "updated_at"}
+
+func TestUserDB_GetByIDs(t *testing.T) {
+	ids := []string{faker.UUIDHyphenated(), faker.UUIDHyphenated()}
+	usernameChangedAt := time.Now()
+	tests := []struct {
+		name       string
+		want       []*entity.User
+		wantErr    bool
+		expectFunc func(sqlmock.Sqlmock, []*entity.User)
+	}{
+		{
+			name:    "when error on database, it should return error",
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock, _ []*entity.User) {
+				mock.ExpectQuery("SELECT").WithArgs(pq.Array(ids)).WillReturnError(errors.New("database error"))
+			},
+		},
+		{
+			name: "when no user found, it should return empty users",
+			want: []*entity.User{},
+			expectFunc: func(mock sqlmock.Sqlmock, _ []*entity.User) {
+				mock.ExpectQuery("SELECT").WithArgs(pq.Array(ids)).WillReturnRows(sqlmock.NewRows(batchUserColumns))
+			},
+		},
+		{
+			name: "when users found, it should return users in a single query",
+			want: []*entity.User{
+				{
+					ID:          ids[0],
+					FullName:    faker.Name(),
+					PhoneNumber: faker.Phonenumber(),
+					CreatedAt:   time.Now(),
+					UpdatedAt:   time.Now(),
+				},
+				{
+					ID:                ids[1],
+					FullName:          faker.Name(),
+					PhoneNumber:       faker.Phonenumber(),
+					Email:             faker.Email(),
+					EmailVerified:     true,
+					AvatarURL:         "http://localhost:8080/blobs/avatars/" + ids[1] + "/original.png",
+					Username:          "john_doe",
+					UsernameChangedAt: &usernameChangedAt,
+					CreatedAt:         time.Now(),
+					UpdatedAt:         time.Now(),
+				},
+			},
+			expectFunc: func(mock sqlmock.Sqlmock, users []*entity.User) {
+				rows := sqlmock.NewRows(batchUserColumns).
+					AddRow(users[0].ID, users[0].FullName, users[0].PhoneNumber, users[0].Email, users[0].EmailVerified, users[0].AvatarURL, users[0].Username, nil, users[0].CreatedAt, users[0].UpdatedAt).
+					AddRow(users[1].ID, users[1].FullName, users[1].PhoneNumber, users[1].Email, users[1].EmailVerified, users[1].AvatarURL, users[1].Username, *users[1].UsernameChangedAt, users[1].CreatedAt, users[1].UpdatedAt)
+
+				mock.ExpectQuery("SELECT (.+) WHERE users.id = ANY\\(\\$1\\)").WithArgs(pq.Array(ids)).WillReturnRows(rows)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			udb := NewUserDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock, tt.want)
+
+			got, err := udb.GetByIDs(context.Background(), ids)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.Equal(tt.want, got)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDB_GetByPhoneNumbers(t *testing.T) {
+	phoneNumbers := []string{"+628123456789", "+628987654321"}
+	user := &entity.User{
+		ID:          faker.UUIDHyphenated(),
+		FullName:    faker.Name(),
+		PhoneNumber: phoneNumbers[0],
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	conn, dbMock := newMockConn()
+	defer conn.Close()
+	udb := NewUserDB(&PostgreConnection{
+		Db: conn,
+	})
+	dbMock.ExpectQuery("SELECT (.+) WHERE users.phone_number = ANY\\(\\$1\\)").WithArgs(pq.Array(phoneNumbers)).WillReturnRows(sqlmock.NewRows(batchUserColumns).
+		AddRow(user.ID, user.FullName, user.PhoneNumber, "", false, "", "", nil, user.CreatedAt, user.UpdatedAt))
+
+	got, err := udb.GetByPhoneNumbers(context.Background(), phoneNumbers)
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	assert.Equal([]*entity.User{user}, got)
+	assert.NoError(dbMock.ExpectationsWereMet())
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"userservice/internal/tracing"
 	"userservice/internal/user/entity"
 	"userservice/internal/user/param/request"
 	"userservice/internal/user/port/driven"
@@ -28,8 +29,18 @@ func NewUserDB(db *PostgreConnection) *UserDB {
 }
 
 // Create implements driven.UserWriter.
+// The UserCreated event is stored in the same transaction.
 func (udb *UserDB) Create(ctx context.Context, user *entity.User) (id string, err error) {
-	err = udb.conn.Db.QueryRowContext(ctx, `
+	ctx, span := startQuery(ctx, "Create")
+	defer func() { tracing.End(span, err) }()
+
+	tx, err := udb.conn.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
 		INSERT INTO
 			users (full_name, phone_number, password)
 		VALUES
@@ -37,10 +48,28 @@ func (udb *UserDB) Create(ctx context.Context, user *entity.User) (id string, er
 		RETURNING
 			id
 	`, user.FullName, user.PhoneNumber, user.Password).Scan(&id)
-	return
+	if err != nil {
+		return "", err
+	}
+
+	created := *user
+	created.ID = id
+	if err = insertOutboxEvent(ctx, tx, entity.NewUserCreatedEvent(&created)); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", err
+	}
+	return id, nil
 }
 
-func (udb *UserDB) UpdateProfileByID(ctx context.Context, id string, params *request.UpdateProfile) (*entity.User, error) {
+// UpdateProfileByID implements driven.UserWriter.
+// The UserProfileUpdated event is stored in the same transaction.
+func (udb *UserDB) UpdateProfileByID(ctx context.Context, id string, params *request.UpdateProfile) (_ *entity.User, err error) {
+	ctx, span := startQuery(ctx, "UpdateProfileByID")
+	defer func() { tracing.End(span, err) }()
+
 	query := "UPDATE users SET updated_at = now(), "
 	var values []any
 	var index int
@@ -63,21 +92,45 @@ func (udb *UserDB) UpdateProfileByID(ctx context.Context, id string, params *req
 	uuidUser, _ := uuid.Parse(id)
 	values = append(values, uuidUser)
 
+	tx, err := udb.conn.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	var user entity.User
-	err := udb.conn.Db.QueryRowContext(ctx, query, values...).Scan(
+	err = tx.QueryRowContext(ctx, query, values...).Scan(
 		&user.ID,
 		&user.FullName,
 		&user.PhoneNumber,
 	)
 	if err != nil {
+		return nil, translateError(err)
+	}
+
+	if err := insertOutboxEvent(ctx, tx, entity.NewUserProfileUpdatedEvent(&user)); err != nil {
 		return nil, err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
-func (udb *UserDB) UpdateUserToken(ctx context.Context, userId string) error {
-	_, err := udb.conn.Db.ExecContext(ctx, `
+// UpdateUserToken implements driven.UserWriter.
+// The UserLoggedIn event is stored in the same transaction.
+func (udb *UserDB) UpdateUserToken(ctx context.Context, userId string) (err error) {
+	ctx, span := startQuery(ctx, "UpdateUserToken")
+	defer func() { tracing.End(span, err) }()
+
+	tx, err := udb.conn.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
 		INSERT INTO
 			user_tokens (user_id, success_login_count, last_login_at)
 		VALUES
@@ -86,5 +139,114 @@ func (udb *UserDB) UpdateUserToken(ctx context.Context, userId string) error {
 		DO UPDATE SET
     		success_login_count = user_tokens.success_login_count + 1,
     		last_login_at = NOW()`, userId)
-	return err
+	if err != nil {
+		return err
+	}
+
+	if err := insertOutboxEvent(ctx, tx, entity.NewUserLoggedInEvent(userId)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdatePasswordByID implements driven.UserWriter.
+// The previous password is stored in the password history in the same transaction.
+func (udb *UserDB) UpdatePasswordByID(ctx context.Context, id string, password string, previousPassword string, historyLimit int) (err error) {
+	ctx, span := startQuery(ctx, "UpdatePasswordByID")
+	defer func() { tracing.End(span, err) }()
+
+	uuidUser, _ := uuid.Parse(id)
+	tx, err := udb.conn.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE
+			users
+		SET
+			password = $1,
+			updated_at = NOW()
+		WHERE
+			id = $2
+	`, password, uuidUser)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return driven.ErrNotFound
+	}
+
+	if historyLimit > 0 {
+		if err = insertPasswordHistory(ctx, tx, uuidUser, previousPassword, historyLimit); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (udb *UserDB) UpdateEmailByID(ctx context.Context, id string, email string) (err error) {
+	ctx, span := startQuery(ctx, "UpdateEmailByID")
+	defer func() { tracing.End(span, err) }()
+
+	uuidUser, _ := uuid.Parse(id)
+	result, err := udb.conn.Db.ExecContext(ctx, `
+		UPDATE
+			users
+		SET
+			email = $1,
+			email_verified_at = NULL,
+			updated_at = NOW()
+		WHERE
+			id = $2
+	`, email, uuidUser)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return driven.ErrNotFound
+	}
+	return nil
+}
+
+func (udb *UserDB) VerifyEmailByID(ctx context.Context, id string, email string) (err error) {
+	ctx, span := startQuery(ctx, "VerifyEmailByID")
+	defer func() { tracing.End(span, err) }()
+
+	uuidUser, _ := uuid.Parse(id)
+	result, err := udb.conn.Db.ExecContext(ctx, `
+		UPDATE
+			users
+		SET
+			email_verified_at = NOW(),
+			updated_at = NOW()
+		WHERE
+			id = $1
+			AND LOWER(email) = LOWER($2)
+	`, uuidUser, email)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return driven.ErrNotFound
+	}
+	return nil
 }
@@ -49,9 +49,35 @@ func TestUserDB_Create(t *testing.T) {
 			wantId:  "aaaa-bbb-ccc-123",
 			wantErr: false,
 			expectFunc: func(mock sqlmock.Sqlmock, user *entity.User) {
+				mock.ExpectBegin()
 				mock.ExpectQuery("^INSERT INTO users").
 					WithArgs(user.FullName, user.PhoneNumber, user.Password).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("aaaa-bbb-ccc-123"))
+				mock.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), entity.UserCreatedEventType, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "when failed to store the event, it should rollback",
+			args: args{
+				ctx: context.Background(),
+				user: &entity.User{
+					FullName:    faker.Name(),
+					PhoneNumber: faker.Phonenumber(),
+					Password:    faker.Password(),
+				},
+			},
+			wantId:  "",
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock, user *entity.User) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^INSERT INTO users").
+					WithArgs(user.FullName, user.PhoneNumber, user.Password).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("aaaa-bbb-ccc-123"))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnError(errors.New("some database error"))
+				mock.ExpectRollback()
 			},
 		},
 		{
@@ -67,9 +93,11 @@ func TestUserDB_Create(t *testing.T) {
 			wantId:  "",
 			wantErr: true,
 			expectFunc: func(mock sqlmock.Sqlmock, user *entity.User) {
+				mock.ExpectBegin()
 				mock.ExpectQuery("^INSERT INTO users").
 					WithArgs(user.FullName, user.PhoneNumber, user.Password).
 					WillReturnError(errors.New("some database error"))
+				mock.ExpectRollback()
 			},
 		},
 	}
@@ -126,8 +154,9 @@ func TestUserDB_UpdateProfileByID(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 			expectFunc: func(mock sqlmock.Sqlmock, _ *entity.User) {
+				mock.ExpectBegin()
 				mock.ExpectQuery("UPDATE users SET").WillReturnError(sql.ErrNoRows)
-
+				mock.ExpectRollback()
 			},
 		},
 		{
@@ -142,8 +171,10 @@ func TestUserDB_UpdateProfileByID(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 			expectFunc: func(mock sqlmock.Sqlmock, _ *entity.User) {
+				mock.ExpectBegin()
 				mock.ExpectQuery("UPDATE users SET").
 					WillReturnError(errors.New("pq: duplicate key value violates unique constraint"))
+				mock.ExpectRollback()
 			},
 		},
 		{
@@ -158,10 +189,15 @@ func TestUserDB_UpdateProfileByID(t *testing.T) {
 			want:    validUser,
 			wantErr: false,
 			expectFunc: func(mock sqlmock.Sqlmock, expectedUser *entity.User) {
+				mock.ExpectBegin()
 				mock.ExpectQuery("UPDATE users SET").
 					WithArgs(validUser.FullName, validUser.ID).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "full_name", "phone_number"}).
 						AddRow(expectedUser.ID, expectedUser.FullName, expectedUser.PhoneNumber))
+				mock.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), entity.UserProfileUpdatedEventType, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 		},
 		{
@@ -176,10 +212,15 @@ func TestUserDB_UpdateProfileByID(t *testing.T) {
 			want:    validUser,
 			wantErr: false,
 			expectFunc: func(mock sqlmock.Sqlmock, expectedUser *entity.User) {
+				mock.ExpectBegin()
 				mock.ExpectQuery("UPDATE users SET").
 					WithArgs(validUser.PhoneNumber, validUser.ID).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "full_name", "phone_number"}).
 						AddRow(expectedUser.ID, expectedUser.FullName, expectedUser.PhoneNumber))
+				mock.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), entity.UserProfileUpdatedEventType, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 		},
 		{
@@ -195,10 +236,15 @@ func TestUserDB_UpdateProfileByID(t *testing.T) {
 			want:    validUser,
 			wantErr: false,
 			expectFunc: func(mock sqlmock.Sqlmock, expectedUser *entity.User) {
+				mock.ExpectBegin()
 				mock.ExpectQuery("UPDATE users SET").
 					WithArgs(validUser.FullName, validUser.PhoneNumber, validUser.ID).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "full_name", "phone_number"}).
 						AddRow(expectedUser.ID, expectedUser.FullName, expectedUser.PhoneNumber))
+				mock.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), entity.UserProfileUpdatedEventType, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 		},
 	}
@@ -248,22 +294,29 @@ func TestUserDB_UpdateUserToken(t *testing.T) {
 			},
 			wantErr: true,
 			expectFunc: func(mock sqlmock.Sqlmock, userId string) {
+				mock.ExpectBegin()
 				mock.ExpectExec("INSERT INTO user_tokens").
 					WithArgs(userId).
 					WillReturnError(errors.New("some database error"))
+				mock.ExpectRollback()
 			},
 		},
 		{
-			name: "when error on db, it should return error",
+			name: "when success, it should store the login event",
 			args: args{
 				context.Background(),
 				"12313",
 			},
 			wantErr: false,
 			expectFunc: func(mock sqlmock.Sqlmock, userId string) {
+				mock.ExpectBegin()
 				mock.ExpectExec("INSERT INTO user_tokens").
 					WithArgs(userId).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), entity.UserLoggedInEventType, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 		},
 	}
@@ -288,3 +341,320 @@ func TestUserDB_UpdateUserToken(t *testing.T) {
 		})
 	}
 }
+
+func TestUserDB_UpdatePasswordByID(t *testing.T) {
+	userID := faker.UUIDHyphenated()
+	type args struct {
+		ctx              context.Context
+		id               string
+		password         string
+		previousPassword string
+		historyLimit     int
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantErr    bool
+		expectFunc func(sqlmock.Sqlmock, args)
+	}{
+		{
+			name: "when error on db, it should rollback and return error",
+			args: args{
+				context.Background(),
+				userID,
+				"hash",
+				"previous hash",
+				5,
+			},
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock, a args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users").
+					WithArgs(a.password, a.id).
+					WillReturnError(errors.New("some database error"))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "when user not found, it should rollback and return error",
+			args: args{
+				context.Background(),
+				userID,
+				"hash",
+				"previous hash",
+				5,
+			},
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock, a args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users").
+					WithArgs(a.password, a.id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "when insert history failed, it should rollback and return error",
+			args: args{
+				context.Background(),
+				userID,
+				"hash",
+				"previous hash",
+				5,
+			},
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock, a args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users").
+					WithArgs(a.password, a.id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO password_history").
+					WithArgs(a.id, a.previousPassword).
+					WillReturnError(errors.New("some database error"))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "when delete old history failed, it should rollback and return error",
+			args: args{
+				context.Background(),
+				userID,
+				"hash",
+				"previous hash",
+				5,
+			},
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock, a args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users").
+					WithArgs(a.password, a.id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO password_history").
+					WithArgs(a.id, a.previousPassword).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("DELETE FROM password_history").
+					WithArgs(a.id, a.historyLimit).
+					WillReturnError(errors.New("some database error"))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "when success, it should store the previous password and keep only the latest limit",
+			args: args{
+				context.Background(),
+				userID,
+				"hash",
+				"previous hash",
+				5,
+			},
+			wantErr: false,
+			expectFunc: func(mock sqlmock.Sqlmock, a args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users").
+					WithArgs(a.password, a.id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO password_history").
+					WithArgs(a.id, a.previousPassword).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("DELETE FROM password_history").
+					WithArgs(a.id, a.historyLimit).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "when history is disabled, it should only update the password",
+			args: args{
+				context.Background(),
+				userID,
+				"hash",
+				"previous hash",
+				0,
+			},
+			wantErr: false,
+			expectFunc: func(mock sqlmock.Sqlmock, a args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users").
+					WithArgs(a.password, a.id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			pgConn := PostgreConnection{
+				Db: conn,
+			}
+			udb := &UserDB{
+				conn: &pgConn,
+			}
+
+			tt.expectFunc(dbMock, tt.args)
+
+			err := udb.UpdatePasswordByID(tt.args.ctx, tt.args.id, tt.args.password, tt.args.previousPassword, tt.args.historyLimit)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDB_UpdateEmailByID(t *testing.T) {
+	userID := faker.UUIDHyphenated()
+	type args struct {
+		ctx   context.Context
+		id    string
+		email string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantErr    bool
+		expectFunc func(sqlmock.Sqlmock, args)
+	}{
+		{
+			name: "when error on db, it should return error",
+			args: args{
+				context.Background(),
+				userID,
+				"john@example.com",
+			},
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock, a args) {
+				mock.ExpectExec("UPDATE users").
+					WithArgs(a.email, a.id).
+					WillReturnError(errors.New("some database error"))
+			},
+		},
+		{
+			name: "when no row updated, it should return error",
+			args: args{
+				context.Background(),
+				userID,
+				"john@example.com",
+			},
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock, a args) {
+				mock.ExpectExec("UPDATE users").
+					WithArgs(a.email, a.id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "when success, it should not return error",
+			args: args{
+				context.Background(),
+				userID,
+				"john@example.com",
+			},
+			wantErr: false,
+			expectFunc: func(mock sqlmock.Sqlmock, a args) {
+				mock.ExpectExec("UPDATE users").
+					WithArgs(a.email, a.id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			pgConn := PostgreConnection{
+				Db: conn,
+			}
+			udb := &UserDB{
+				conn: &pgConn,
+			}
+
+			tt.expectFunc(dbMock, tt.args)
+
+			err := udb.UpdateEmailByID(tt.args.ctx, tt.args.id, tt.args.email)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDB_VerifyEmailByID(t *testing.T) {
+	userID := faker.UUIDHyphenated()
+	type args struct {
+		ctx   context.Context
+		id    string
+		email string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantErr    bool
+		expectFunc func(sqlmock.Sqlmock, args)
+	}{
+		{
+			name: "when error on db, it should return error",
+			args: args{
+				context.Background(),
+				userID,
+				"john@example.com",
+			},
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock, a args) {
+				mock.ExpectExec("UPDATE users").
+					WithArgs(a.id, a.email).
+					WillReturnError(errors.New("some database error"))
+			},
+		},
+		{
+			name: "when no row updated, it should return error",
+			args: args{
+				context.Background(),
+				userID,
+				"john@example.com",
+			},
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock, a args) {
+				mock.ExpectExec("UPDATE users").
+					WithArgs(a.id, a.email).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "when success, it should not return error",
+			args: args{
+				context.Background(),
+				userID,
+				"john@example.com",
+			},
+			wantErr: false,
+			expectFunc: func(mock sqlmock.Sqlmock, a args) {
+				mock.ExpectExec("UPDATE users").
+					WithArgs(a.id, a.email).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			pgConn := PostgreConnection{
+				Db: conn,
+			}
+			udb := &UserDB{
+				conn: &pgConn,
+			}
+
+			tt.expectFunc(dbMock, tt.args)
+
+			err := udb.VerifyEmailByID(tt.args.ctx, tt.args.id, tt.args.email)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -4,29 +4,93 @@ import (
 	"context"
 	"database/sql"
 
+	"userservice/internal/tracing"
 	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
 
 	"github.com/google/uuid"
 )
 
 // GetByID implements driven.UserGetter.
-func (udb *UserDB) GetByID(ctx context.Context, id string) (*entity.User, error) {
+func (udb *UserDB) GetByID(ctx context.Context, id string) (_ *entity.User, err error) {
+	ctx, span := startQuery(ctx, "GetByID")
+	defer func() { tracing.End(span, err) }()
+
 	uuidUser, _ := uuid.Parse(id)
+	rows, err := udb.conn.Db.QueryContext(ctx, `
+		SELECT
+			users.id,
+			users.full_name,
+			users.phone_number,
+			users.password,
+			COALESCE(users.email, ''),
+			users.email_verified_at IS NOT NULL,
+			COALESCE(user_profiles.avatar_url, ''),
+			COALESCE(users.username, ''),
+			users.username_changed_at,
+			users.created_at,
+			users.updated_at
+		FROM
+			users
+			LEFT JOIN user_profiles ON user_profiles.user_id = users.id
+		WHERE
+			users.id = $1
+		LIMIT
+			1
+	`, uuidUser)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	var user entity.User
+	var usernameChangedAt sql.NullTime
+	if rows.Next() {
+		err = rows.Scan(
+			&user.ID,
+			&user.FullName,
+			&user.PhoneNumber,
+			&user.Password,
+			&user.Email,
+			&user.EmailVerified,
+			&user.AvatarURL,
+			&user.Username,
+			&usernameChangedAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+	} else {
+		return nil, driven.ErrNotFound
+	}
+
+	if usernameChangedAt.Valid {
+		user.UsernameChangedAt = &usernameChangedAt.Time
+	}
+	return &user, err
+}
+
+// GetByPhoneNumber implements driven.UserGetter.
+func (udb *UserDB) GetByPhoneNumber(ctx context.Context, phoneNumber string) (_ *entity.User, err error) {
+	ctx, span := startQuery(ctx, "GetByPhoneNumber")
+	defer func() { tracing.End(span, err) }()
+
 	rows, err := udb.conn.Db.QueryContext(ctx, `
 		SELECT
 			id,
 			full_name,
 			phone_number,
 			password,
+			COALESCE(email, ''),
+			email_verified_at IS NOT NULL,
 			created_at,
 			updated_at
 		FROM
 			users
 		WHERE
-			id = $1
+			phone_number = $1
 		LIMIT
 			1
-	`, uuidUser)
+	`, phoneNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -39,33 +103,43 @@ func (udb *UserDB) GetByID(ctx context.Context, id string) (*entity.User, error)
 			&user.FullName,
 			&user.PhoneNumber,
 			&user.Password,
+			&user.Email,
+			&user.EmailVerified,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
 	} else {
-		return nil, sql.ErrNoRows
+		return nil, driven.ErrNotFound
 	}
 
 	return &user, err
 }
 
-// GetByPhoneNumber implements driven.UserGetter.
-func (udb *UserDB) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*entity.User, error) {
+// GetByEmail implements driven.UserGetter.
+// Only the verified email is unique, its user is returned before the users who did not verify it.
+func (udb *UserDB) GetByEmail(ctx context.Context, email string) (_ *entity.User, err error) {
+	ctx, span := startQuery(ctx, "GetByEmail")
+	defer func() { tracing.End(span, err) }()
+
 	rows, err := udb.conn.Db.QueryContext(ctx, `
 		SELECT
 			id,
 			full_name,
 			phone_number,
 			password,
+			COALESCE(email, ''),
+			email_verified_at IS NOT NULL,
 			created_at,
 			updated_at
 		FROM
 			users
 		WHERE
-			phone_number = $1
+			LOWER(email) = LOWER($1)
+		ORDER BY
+			email_verified_at IS NULL, created_at
 		LIMIT
 			1
-	`, phoneNumber)
+	`, email)
 	if err != nil {
 		return nil, err
 	}
@@ -78,11 +152,13 @@ func (udb *UserDB) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*e
 			&user.FullName,
 			&user.PhoneNumber,
 			&user.Password,
+			&user.Email,
+			&user.EmailVerified,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
 	} else {
-		return nil, sql.ErrNoRows
+		return nil, driven.ErrNotFound
 	}
 
 	return &user, err
@@ -15,6 +15,7 @@ import (
 
 func TestUserDB_GetByID(t *testing.T) {
 	validUserID := faker.UUIDHyphenated()
+	usernameChangedAt := time.Now()
 	type args struct {
 		ctx context.Context
 		id  string
@@ -57,19 +58,22 @@ func TestUserDB_GetByID(t *testing.T) {
 				validUserID,
 			},
 			want: &entity.User{
-				ID:          validUserID,
-				FullName:    faker.Name(),
-				PhoneNumber: faker.Phonenumber(),
-				Password:    faker.Password(),
-				CreatedAt:   time.Now(),
-				UpdatedAt:   time.Now(),
+				ID:                validUserID,
+				FullName:          faker.Name(),
+				PhoneNumber:       faker.Phonenumber(),
+				Password:          faker.Password(),
+				AvatarURL:         "http://localhost:8080/blobs/avatars/" + validUserID + "/original.png",
+				Username:          "john_doe",
+				UsernameChangedAt: &usernameChangedAt,
+				CreatedAt:         time.Now(),
+				UpdatedAt:         time.Now(),
 			},
 			wantErr: false,
 			expectFunc: func(mock sqlmock.Sqlmock, expectedUser *entity.User) {
-				rows := sqlmock.NewRows([]string{"id", "full_name", "phone_number", "password", "created_at", "updated_at"}).
-					AddRow(expectedUser.ID, expectedUser.FullName, expectedUser.PhoneNumber, expectedUser.Password, expectedUser.CreatedAt, expectedUser.UpdatedAt)
+				rows := sqlmock.NewRows([]string{"id", "full_name", "phone_number", "password", "email", "email_verified", "avatar_url", "username", "username_changed_at", "created_at", "updated_at"}).
+					AddRow(expectedUser.ID, expectedUser.FullName, expectedUser.PhoneNumber, expectedUser.Password, expectedUser.Email, expectedUser.EmailVerified, expectedUser.AvatarURL, expectedUser.Username, *expectedUser.UsernameChangedAt, expectedUser.CreatedAt, expectedUser.UpdatedAt)
 
-				mock.ExpectQuery("SELECT").WithArgs(sqlmock.AnyArg()).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) LEFT JOIN user_profiles").WithArgs(sqlmock.AnyArg()).WillReturnRows(rows)
 			},
 		},
 	}
@@ -147,8 +151,8 @@ func TestUserDB_GetByPhoneNumber(t *testing.T) {
 			},
 			wantErr: false,
 			expectFunc: func(mock sqlmock.Sqlmock, expectedUser *entity.User) {
-				rows := sqlmock.NewRows([]string{"id", "full_name", "phone_number", "password", "created_at", "updated_at"}).
-					AddRow(expectedUser.ID, expectedUser.FullName, expectedUser.PhoneNumber, expectedUser.Password, expectedUser.CreatedAt, expectedUser.UpdatedAt)
+				rows := sqlmock.NewRows([]string{"id", "full_name", "phone_number", "password", "email", "email_verified", "created_at", "updated_at"}).
+					AddRow(expectedUser.ID, expectedUser.FullName, expectedUser.PhoneNumber, expectedUser.Password, expectedUser.Email, expectedUser.EmailVerified, expectedUser.CreatedAt, expectedUser.UpdatedAt)
 
 				mock.ExpectQuery("SELECT").WithArgs(validPhoneNumber).WillReturnRows(rows)
 			},
@@ -176,3 +180,88 @@ func TestUserDB_GetByPhoneNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestUserDB_GetByEmail(t *testing.T) {
+	validEmail := faker.Email()
+	type args struct {
+		ctx   context.Context
+		email string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       *entity.User
+		wantErr    bool
+		expectFunc func(sqlmock.Sqlmock, *entity.User)
+	}{
+		{
+			name: "when record not found, it should return error",
+			args: args{
+				context.Background(),
+				faker.Email(),
+			},
+			want:    nil,
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock, _ *entity.User) {
+				mock.ExpectQuery("SELECT").WithArgs(sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{}))
+			},
+		},
+		{
+			name: "when error on database, it should return error",
+			args: args{
+				context.Background(),
+				faker.Email(),
+			},
+			want:    nil,
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock, _ *entity.User) {
+				mock.ExpectQuery("SELECT").WithArgs(sqlmock.AnyArg()).WillReturnError(errors.New("database error"))
+			},
+		},
+		{
+			name: "when user found, it should return user",
+			args: args{
+				context.Background(),
+				validEmail,
+			},
+			want: &entity.User{
+				ID:            faker.UUIDHyphenated(),
+				FullName:      faker.Name(),
+				PhoneNumber:   faker.Phonenumber(),
+				Password:      faker.Password(),
+				Email:         validEmail,
+				EmailVerified: true,
+				CreatedAt:     time.Now(),
+				UpdatedAt:     time.Now(),
+			},
+			wantErr: false,
+			expectFunc: func(mock sqlmock.Sqlmock, expectedUser *entity.User) {
+				rows := sqlmock.NewRows([]string{"id", "full_name", "phone_number", "password", "email", "email_verified", "created_at", "updated_at"}).
+					AddRow(expectedUser.ID, expectedUser.FullName, expectedUser.PhoneNumber, expectedUser.Password, expectedUser.Email, expectedUser.EmailVerified, expectedUser.CreatedAt, expectedUser.UpdatedAt)
+
+				mock.ExpectQuery("SELECT (.+) WHERE LOWER\\(email\\) = LOWER\\(\\$1\\)").WithArgs(validEmail).WillReturnRows(rows)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			pgConn := PostgreConnection{
+				Db: conn,
+			}
+			udb := &UserDB{
+				conn: &pgConn,
+			}
+
+			tt.expectFunc(dbMock, tt.want)
+
+			got, err := udb.GetByEmail(tt.args.ctx, tt.args.email)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.Equal(tt.want, got)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"context"
+
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+
+	"github.com/google/uuid"
+)
+
+// GetPrivacySettingsByUserID implements driven.UserGetter.
+func (udb *UserDB) GetPrivacySettingsByUserID(ctx context.Context, userID string) (_ *entity.PrivacySettings, err error) {
+	ctx, span := startQuery(ctx, "GetPrivacySettingsByUserID")
+	defer func() { tracing.End(span, err) }()
+
+	uuidUser, _ := uuid.Parse(userID)
+	var settings entity.PrivacySettings
+	err = udb.conn.Db.QueryRowContext(ctx, `
+		SELECT
+			user_id,
+			show_full_name,
+			show_avatar,
+			show_phone_number,
+			updated_at
+		FROM
+			user_privacy_settings
+		WHERE
+			user_id = $1
+	`, uuidUser).Scan(
+		&settings.UserID,
+		&settings.ShowFullName,
+		&settings.ShowAvatar,
+		&settings.ShowPhoneNumber,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &settings, nil
+}
+
+// UpsertPrivacySettings implements driven.UserWriter.
+func (udb *UserDB) UpsertPrivacySettings(ctx context.Context, settings *entity.PrivacySettings) (err error) {
+	ctx, span := startQuery(ctx, "UpsertPrivacySettings")
+	defer func() { tracing.End(span, err) }()
+
+	uuidUser, _ := uuid.Parse(settings.UserID)
+	return udb.conn.Db.QueryRowContext(ctx, `
+		INSERT INTO
+			user_privacy_settings (user_id, show_full_name, show_avatar, show_phone_number)
+		VALUES
+			($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET
+			show_full_name = EXCLUDED.show_full_name,
+			show_avatar = EXCLUDED.show_avatar,
+			show_phone_number = EXCLUDED.show_phone_number,
+			updated_at = NOW()
+		RETURNING
+			updated_at
+	`, uuidUser, settings.ShowFullName, settings.ShowAvatar, settings.ShowPhoneNumber).Scan(&settings.UpdatedAt)
+}
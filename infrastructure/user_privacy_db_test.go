@@ -0,0 +1,121 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserDB_GetPrivacySettingsByUserID(t *testing.T) {
+	userID := faker.UUIDHyphenated()
+	updatedAt := time.Now()
+	columns := []string{"user_id", "show_full_name", "show_avatar", "show_phone_number", "updated_at"}
+	tests := []struct {
+		name       string
+		want       *entity.PrivacySettings
+		wantErr    error
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "when settings not found, it should return error",
+			want:    nil,
+			wantErr: driven.ErrNotFound,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM user_privacy_settings").WithArgs(userID).WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name: "when settings found, it should return settings",
+			want: &entity.PrivacySettings{
+				UserID:          userID,
+				ShowFullName:    false,
+				ShowAvatar:      true,
+				ShowPhoneNumber: true,
+				UpdatedAt:       updatedAt,
+			},
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM user_privacy_settings").WithArgs(userID).WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(userID, false, true, true, updatedAt))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			udb := NewUserDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			got, err := udb.GetPrivacySettingsByUserID(context.Background(), userID)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err)
+			assert.Equal(tt.want, got)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDB_UpsertPrivacySettings(t *testing.T) {
+	userID := faker.UUIDHyphenated()
+	updatedAt := time.Now()
+	tests := []struct {
+		name       string
+		wantErr    bool
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "when error on db, it should return error",
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO user_privacy_settings").WillReturnError(errors.New("some database error"))
+			},
+		},
+		{
+			name:    "when success, it should set updated at",
+			wantErr: false,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO user_privacy_settings (.+) ON CONFLICT").
+					WithArgs(userID, true, false, true).
+					WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			udb := NewUserDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			settings := &entity.PrivacySettings{
+				UserID:          userID,
+				ShowFullName:    true,
+				ShowAvatar:      false,
+				ShowPhoneNumber: true,
+			}
+			err := udb.UpsertPrivacySettings(context.Background(), settings)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(updatedAt, settings.UpdatedAt)
+			}
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+
+	"github.com/google/uuid"
+)
+
+// GetProfileByUserID implements driven.UserGetter.
+func (udb *UserDB) GetProfileByUserID(ctx context.Context, userID string) (_ *entity.Profile, err error) {
+	ctx, span := startQuery(ctx, "GetProfileByUserID")
+	defer func() { tracing.End(span, err) }()
+
+	uuidUser, _ := uuid.Parse(userID)
+	var (
+		profile           entity.Profile
+		avatarURL         sql.NullString
+		dateOfBirth       sql.NullTime
+		locale            sql.NullString
+		timezone          sql.NullString
+		addressStreet     sql.NullString
+		addressCity       sql.NullString
+		addressProvince   sql.NullString
+		addressPostalCode sql.NullString
+		addressCountry    sql.NullString
+	)
+	err = udb.conn.Db.QueryRowContext(ctx, `
+		SELECT
+			user_id,
+			avatar_url,
+			date_of_birth,
+			locale,
+			timezone,
+			address_street,
+			address_city,
+			address_province,
+			address_postal_code,
+			address_country,
+			updated_at
+		FROM
+			user_profiles
+		WHERE
+			user_id = $1
+	`, uuidUser).Scan(
+		&profile.UserID,
+		&avatarURL,
+		&dateOfBirth,
+		&locale,
+		&timezone,
+		&addressStreet,
+		&addressCity,
+		&addressProvince,
+		&addressPostalCode,
+		&addressCountry,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	profile.AvatarURL = avatarURL.String
+	profile.Locale = locale.String
+	profile.Timezone = timezone.String
+	if dateOfBirth.Valid {
+		profile.DateOfBirth = &dateOfBirth.Time
+	}
+	// country is mandatory for an address, so it marks whether the address exists
+	if addressCountry.Valid {
+		profile.Address = &entity.Address{
+			Street:     addressStreet.String,
+			City:       addressCity.String,
+			Province:   addressProvince.String,
+			PostalCode: addressPostalCode.String,
+			Country:    addressCountry.String,
+		}
+	}
+
+	return &profile, nil
+}
+
+// UpsertProfile implements driven.UserWriter.
+func (udb *UserDB) UpsertProfile(ctx context.Context, profile *entity.Profile) (err error) {
+	ctx, span := startQuery(ctx, "UpsertProfile")
+	defer func() { tracing.End(span, err) }()
+
+	uuidUser, _ := uuid.Parse(profile.UserID)
+	var address entity.Address
+	if profile.Address != nil {
+		address = *profile.Address
+	}
+
+	return udb.conn.Db.QueryRowContext(ctx, `
+		INSERT INTO
+			user_profiles (
+				user_id,
+				avatar_url,
+				date_of_birth,
+				locale,
+				timezone,
+				address_street,
+				address_city,
+				address_province,
+				address_postal_code,
+				address_country
+			)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+			avatar_url = EXCLUDED.avatar_url,
+			date_of_birth = EXCLUDED.date_of_birth,
+			locale = EXCLUDED.locale,
+			timezone = EXCLUDED.timezone,
+			address_street = EXCLUDED.address_street,
+			address_city = EXCLUDED.address_city,
+			address_province = EXCLUDED.address_province,
+			address_postal_code = EXCLUDED.address_postal_code,
+			address_country = EXCLUDED.address_country,
+			updated_at = NOW()
+		RETURNING
+			updated_at
+	`,
+		uuidUser,
+		nullString(profile.AvatarURL),
+		profile.DateOfBirth,
+		nullString(profile.Locale),
+		nullString(profile.Timezone),
+		nullString(address.Street),
+		nullString(address.City),
+		nullString(address.Province),
+		nullString(address.PostalCode),
+		nullString(address.Country),
+	).Scan(&profile.UpdatedAt)
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{
+		String: s,
+		Valid:  s != "",
+	}
+}
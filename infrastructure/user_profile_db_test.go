@@ -0,0 +1,161 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserDB_GetProfileByUserID(t *testing.T) {
+	userID := faker.UUIDHyphenated()
+	dateOfBirth := time.Date(1990, 12, 31, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Now()
+	columns := []string{"user_id", "avatar_url", "date_of_birth", "locale", "timezone", "address_street", "address_city", "address_province", "address_postal_code", "address_country", "updated_at"}
+	tests := []struct {
+		name       string
+		want       *entity.Profile
+		wantErr    error
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "when profile not found, it should return error",
+			want:    nil,
+			wantErr: driven.ErrNotFound,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM user_profiles").WithArgs(userID).WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name: "when profile found without address, it should return profile",
+			want: &entity.Profile{
+				UserID:    userID,
+				Locale:    "id-ID",
+				UpdatedAt: updatedAt,
+			},
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM user_profiles").WithArgs(userID).WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(userID, nil, nil, "id-ID", nil, nil, nil, nil, nil, nil, updatedAt))
+			},
+		},
+		{
+			name: "when profile found with all fields, it should return profile",
+			want: &entity.Profile{
+				UserID:      userID,
+				AvatarURL:   "https://example.com/avatar.png",
+				DateOfBirth: &dateOfBirth,
+				Locale:      "id-ID",
+				Timezone:    "Asia/Jakarta",
+				Address: &entity.Address{
+					Street:     "Jl. Sudirman No. 1",
+					City:       "Jakarta",
+					Province:   "DKI Jakarta",
+					PostalCode: "10220",
+					Country:    "ID",
+				},
+				UpdatedAt: updatedAt,
+			},
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM user_profiles").WithArgs(userID).WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(userID, "https://example.com/avatar.png", dateOfBirth, "id-ID", "Asia/Jakarta", "Jl. Sudirman No. 1", "Jakarta", "DKI Jakarta", "10220", "ID", updatedAt))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			udb := NewUserDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			got, err := udb.GetProfileByUserID(context.Background(), userID)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err)
+			assert.Equal(tt.want, got)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDB_UpsertProfile(t *testing.T) {
+	userID := faker.UUIDHyphenated()
+	updatedAt := time.Now()
+	tests := []struct {
+		name       string
+		profile    *entity.Profile
+		wantErr    bool
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name: "when error on db, it should return error",
+			profile: &entity.Profile{
+				UserID: userID,
+				Locale: "id-ID",
+			},
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO user_profiles").WillReturnError(errors.New("some database error"))
+			},
+		},
+		{
+			name: "when address empty, it should save address as null",
+			profile: &entity.Profile{
+				UserID: userID,
+				Locale: "id-ID",
+			},
+			wantErr: false,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO user_profiles").
+					WithArgs(userID, nil, nil, "id-ID", nil, nil, nil, nil, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+			},
+		},
+		{
+			name: "when address present, it should save address",
+			profile: &entity.Profile{
+				UserID: userID,
+				Address: &entity.Address{
+					City:    "Jakarta",
+					Country: "ID",
+				},
+			},
+			wantErr: false,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO user_profiles").
+					WithArgs(userID, nil, nil, nil, nil, nil, "Jakarta", nil, nil, "ID").
+					WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			udb := NewUserDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			err := udb.UpsertProfile(context.Background(), tt.profile)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(updatedAt, tt.profile.UpdatedAt)
+			}
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
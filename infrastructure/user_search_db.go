@@ -0,0 +1,97 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+)
+
+var _ driven.UserSearcher = new(UserDB)
+
+// Search implements driven.UserSearcher.
+// The full name is matched with pg_trgm word similarity so typos like "Jon Doe" still find "John Doe",
+// a phone number prefix match always has the highest score.
+// The cursor is compared as real because the score is calculated as real by pg_trgm.
+func (udb *UserDB) Search(ctx context.Context, query *entity.UserSearchQuery) (_ []*entity.UserSearchResult, err error) {
+	ctx, span := startQuery(ctx, "Search")
+	defer func() { tracing.End(span, err) }()
+
+	var afterScore sql.NullFloat64
+	var afterID sql.NullString
+	if query.After != nil {
+		afterScore = sql.NullFloat64{Float64: query.After.Score, Valid: true}
+		afterID = sql.NullString{String: query.After.ID, Valid: true}
+	}
+
+	rows, err := udb.conn.Db.QueryContext(ctx, `
+		SELECT
+			id,
+			full_name,
+			phone_number,
+			email,
+			username,
+			created_at,
+			updated_at,
+			score
+		FROM (
+			SELECT
+				users.id,
+				users.full_name,
+				users.phone_number,
+				COALESCE(users.email, '') AS email,
+				COALESCE(users.username, '') AS username,
+				users.created_at,
+				users.updated_at,
+				GREATEST(
+					word_similarity($1, users.full_name),
+					CASE WHEN $2 <> '' AND users.phone_number LIKE $2 || '%' THEN 1 ELSE 0 END
+				) AS score
+			FROM
+				users
+			WHERE
+				$1 <% users.full_name
+				OR ($2 <> '' AND users.phone_number LIKE $2 || '%')
+		) AS results
+		WHERE
+			$3::real IS NULL
+			OR score < $3::real
+			OR (score = $3::real AND id > $4::uuid)
+		ORDER BY
+			score DESC,
+			id
+		LIMIT
+			$5
+	`, query.Text, query.PhoneNumberPrefix, afterScore, afterID, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*entity.UserSearchResult{}
+	for rows.Next() {
+		var user entity.User
+		var score float64
+		err = rows.Scan(
+			&user.ID,
+			&user.FullName,
+			&user.PhoneNumber,
+			&user.Email,
+			&user.Username,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&score,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &entity.UserSearchResult{
+			User:  &user,
+			Score: score,
+		})
+	}
+
+	return results, rows.Err()
+}
@@ -0,0 +1,95 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"userservice/internal/user/entity"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserDB_Search(t *testing.T) {
+	afterID := faker.UUIDHyphenated()
+	user := &entity.User{
+		ID:          faker.UUIDHyphenated(),
+		FullName:    "John Doe",
+		PhoneNumber: "+628123456789",
+		Username:    "john_doe",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	columns := []string{"id", "full_name", "phone_number", "email", "username", "created_at", "updated_at", "score"}
+	tests := []struct {
+		name       string
+		query      *entity.UserSearchQuery
+		want       []*entity.UserSearchResult
+		wantErr    bool
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name: "when error on database, it should return error",
+			query: &entity.UserSearchQuery{
+				Text:  "Jon Doe",
+				Limit: 21,
+			},
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT").WillReturnError(errors.New("database error"))
+			},
+		},
+		{
+			name: "when first page, it should search without cursor",
+			query: &entity.UserSearchQuery{
+				Text:  "Jon Doe",
+				Limit: 21,
+			},
+			want: []*entity.UserSearchResult{
+				{User: user, Score: 0.75},
+			},
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) word_similarity\\(\\$1, users.full_name\\)(.+) \\$1 <% users.full_name (.+) ORDER BY score DESC, id").
+					WithArgs("Jon Doe", "", nil, nil, 21).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(user.ID, user.FullName, user.PhoneNumber, user.Email, user.Username, user.CreatedAt, user.UpdatedAt, 0.75))
+			},
+		},
+		{
+			name: "when next page, it should search after the cursor",
+			query: &entity.UserSearchQuery{
+				Text:              "0812",
+				PhoneNumberPrefix: "+62812",
+				After:             &entity.UserSearchCursor{Score: 1, ID: afterID},
+				Limit:             21,
+			},
+			want: []*entity.UserSearchResult{},
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) users.phone_number LIKE \\$2").
+					WithArgs("0812", "+62812", float64(1), afterID, 21).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			udb := NewUserDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			got, err := udb.Search(context.Background(), tt.query)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.Equal(tt.want, got)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
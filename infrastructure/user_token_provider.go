@@ -1,6 +1,7 @@
 package infrastructure
 
 import (
+	"context"
 	"crypto/rsa"
 	"errors"
 	"fmt"
@@ -89,3 +90,15 @@ func (uc *UserTokenProvider) ValidateJWT(tokenString string) (map[string]interfa
 
 	return claims, nil
 }
+
+// CheckSigningKeys checks that the keys are loaded and are a pair, the tokens signed with another
+// private key are rejected by every service, it is a check of the readiness.
+func (utp *UserTokenProvider) CheckSigningKeys(ctx context.Context) error {
+	if utp.PrivateKey == nil || utp.PublicKey == nil {
+		return errors.New("signing keys are not loaded")
+	}
+	if !utp.PrivateKey.PublicKey.Equal(utp.PublicKey) {
+		return errors.New("public key does not match the private key")
+	}
+	return nil
+}
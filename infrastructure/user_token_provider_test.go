@@ -0,0 +1,49 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserTokenProvider_CheckSigningKeys(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		utp     *UserTokenProvider
+		wantErr string
+	}{
+		{
+			name: "when keys are a pair, it should return no error",
+			utp:  &UserTokenProvider{PrivateKey: privateKey, PublicKey: &privateKey.PublicKey},
+		},
+		{
+			name:    "when keys are not loaded, it should return error",
+			utp:     &UserTokenProvider{},
+			wantErr: "signing keys are not loaded",
+		},
+		{
+			name:    "when keys are not a pair, it should return error",
+			utp:     &UserTokenProvider{PrivateKey: privateKey, PublicKey: &otherKey.PublicKey},
+			wantErr: "public key does not match the private key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.utp.CheckSigningKeys(context.Background())
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"github.com/google/uuid"
+)
+
+// GetByUsername implements driven.UserGetter.
+func (udb *UserDB) GetByUsername(ctx context.Context, username string) (_ *entity.User, err error) {
+	ctx, span := startQuery(ctx, "GetByUsername")
+	defer func() { tracing.End(span, err) }()
+
+	var user entity.User
+	var usernameChangedAt sql.NullTime
+	err = udb.conn.Db.QueryRowContext(ctx, `
+		SELECT
+			id,
+			full_name,
+			phone_number,
+			username,
+			username_changed_at,
+			created_at,
+			updated_at
+		FROM
+			users
+		WHERE
+			LOWER(username) = LOWER($1)
+		LIMIT
+			1
+	`, username).Scan(
+		&user.ID,
+		&user.FullName,
+		&user.PhoneNumber,
+		&user.Username,
+		&usernameChangedAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	if usernameChangedAt.Valid {
+		user.UsernameChangedAt = &usernameChangedAt.Time
+	}
+	return &user, nil
+}
+
+// GetUsernameRedirect implements driven.UserGetter.
+func (udb *UserDB) GetUsernameRedirect(ctx context.Context, username string) (_ *entity.UsernameRedirect, err error) {
+	ctx, span := startQuery(ctx, "GetUsernameRedirect")
+	defer func() { tracing.End(span, err) }()
+
+	var redirect entity.UsernameRedirect
+	err = udb.conn.Db.QueryRowContext(ctx, `
+		SELECT
+			username,
+			user_id,
+			expires_at
+		FROM
+			username_redirects
+		WHERE
+			username = LOWER($1)
+			AND expires_at > NOW()
+	`, username).Scan(
+		&redirect.Username,
+		&redirect.UserID,
+		&redirect.ExpiresAt,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &redirect, nil
+}
+
+// UpdateUsername implements driven.UserWriter.
+// The redirect of the new username is removed, it could be the old username of the user itself.
+func (udb *UserDB) UpdateUsername(ctx context.Context, user *entity.User, redirect *entity.UsernameRedirect) (err error) {
+	ctx, span := startQuery(ctx, "UpdateUsername")
+	defer func() { tracing.End(span, err) }()
+
+	uuidUser, _ := uuid.Parse(user.ID)
+	tx, err := udb.conn.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE
+			users
+		SET
+			username = $1,
+			username_changed_at = $2,
+			updated_at = NOW()
+		WHERE
+			id = $3
+	`, user.Username, user.UsernameChangedAt, uuidUser)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return driven.ErrNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM
+			username_redirects
+		WHERE
+			username = $1
+	`, strings.ToLower(user.Username))
+	if err != nil {
+		return err
+	}
+
+	if redirect != nil {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO
+				username_redirects (username, user_id, expires_at)
+			VALUES
+				($1, $2, $3)
+			ON CONFLICT (username) DO UPDATE SET
+				user_id = EXCLUDED.user_id,
+				expires_at = EXCLUDED.expires_at,
+				created_at = NOW()
+		`, redirect.Username, uuidUser, redirect.ExpiresAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
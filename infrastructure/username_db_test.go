@@ -0,0 +1,202 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserDB_GetByUsername(t *testing.T) {
+	usernameChangedAt := time.Now()
+	columns := []string{"id", "full_name", "phone_number", "username", "username_changed_at", "created_at", "updated_at"}
+	tests := []struct {
+		name       string
+		want       *entity.User
+		wantErr    error
+		expectFunc func(sqlmock.Sqlmock, *entity.User)
+	}{
+		{
+			name:    "when record not found, it should return error",
+			want:    nil,
+			wantErr: driven.ErrNotFound,
+			expectFunc: func(mock sqlmock.Sqlmock, _ *entity.User) {
+				mock.ExpectQuery("SELECT").WithArgs("John_Doe").WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name: "when user found, it should return user",
+			want: &entity.User{
+				ID:                faker.UUIDHyphenated(),
+				FullName:          faker.Name(),
+				PhoneNumber:       faker.Phonenumber(),
+				Username:          "john_doe",
+				UsernameChangedAt: &usernameChangedAt,
+				CreatedAt:         time.Now(),
+				UpdatedAt:         time.Now(),
+			},
+			expectFunc: func(mock sqlmock.Sqlmock, expectedUser *entity.User) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(expectedUser.ID, expectedUser.FullName, expectedUser.PhoneNumber, expectedUser.Username, *expectedUser.UsernameChangedAt, expectedUser.CreatedAt, expectedUser.UpdatedAt)
+
+				mock.ExpectQuery("SELECT (.+) WHERE LOWER\\(username\\) = LOWER\\(\\$1\\)").WithArgs("John_Doe").WillReturnRows(rows)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			udb := NewUserDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock, tt.want)
+
+			got, err := udb.GetByUsername(context.Background(), "John_Doe")
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err)
+			assert.Equal(tt.want, got)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDB_GetUsernameRedirect(t *testing.T) {
+	userID := faker.UUIDHyphenated()
+	expiresAt := time.Now().Add(time.Hour)
+	columns := []string{"username", "user_id", "expires_at"}
+	tests := []struct {
+		name       string
+		want       *entity.UsernameRedirect
+		wantErr    error
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "when redirect not found or expired, it should return error",
+			want:    nil,
+			wantErr: driven.ErrNotFound,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM username_redirects (.+) expires_at > NOW\\(\\)").WithArgs("John_Doe").WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name: "when redirect found, it should return redirect",
+			want: &entity.UsernameRedirect{
+				Username:  "john_doe",
+				UserID:    userID,
+				ExpiresAt: expiresAt,
+			},
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM username_redirects").WithArgs("John_Doe").WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("john_doe", userID, expiresAt))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			udb := NewUserDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			got, err := udb.GetUsernameRedirect(context.Background(), "John_Doe")
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err)
+			assert.Equal(tt.want, got)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserDB_UpdateUsername(t *testing.T) {
+	userID := faker.UUIDHyphenated()
+	changedAt := time.Now()
+	user := &entity.User{
+		ID:                userID,
+		Username:          "John_Doe",
+		UsernameChangedAt: &changedAt,
+	}
+	redirect := &entity.UsernameRedirect{
+		Username:  "old_john",
+		UserID:    userID,
+		ExpiresAt: changedAt.Add(time.Hour),
+	}
+	tests := []struct {
+		name       string
+		redirect   *entity.UsernameRedirect
+		wantErr    error
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "when user not found, it should return error",
+			wantErr: driven.ErrNotFound,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users").WithArgs("John_Doe", &changedAt, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name:     "when failed to insert redirect, it should rollback",
+			redirect: redirect,
+			wantErr:  errors.New("some database error"),
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users").WithArgs("John_Doe", &changedAt, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM username_redirects").WithArgs("john_doe").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO username_redirects").WithArgs("old_john", sqlmock.AnyArg(), redirect.ExpiresAt).WillReturnError(errors.New("some database error"))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "when there is no redirect, it should only update username",
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users").WithArgs("John_Doe", &changedAt, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM username_redirects").WithArgs("john_doe").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "when there is redirect, it should store the redirect",
+			redirect: redirect,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users").WithArgs("John_Doe", &changedAt, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM username_redirects").WithArgs("john_doe").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO username_redirects").WithArgs("old_john", sqlmock.AnyArg(), redirect.ExpiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			udb := NewUserDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			err := udb.UpdateUsername(context.Background(), user, tt.redirect)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
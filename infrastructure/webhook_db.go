@@ -0,0 +1,437 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var _ driven.WebhookStore = new(WebhookDB)
+
+type WebhookDB struct {
+	conn *PostgreConnection
+}
+
+func NewWebhookDB(db *PostgreConnection) *WebhookDB {
+	return &WebhookDB{
+		conn: db,
+	}
+}
+
+// CreateSubscription implements driven.WebhookStore.
+func (wdb *WebhookDB) CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	return wdb.conn.Db.QueryRowContext(ctx, `
+		INSERT INTO
+			webhook_subscriptions (partner_id, url, event_types, secret, enabled)
+		VALUES
+			($1, $2, $3, $4, $5)
+		RETURNING
+			id,
+			created_at,
+			updated_at
+	`, subscription.PartnerID, subscription.URL, pq.Array(subscription.EventTypes), subscription.Secret, subscription.Enabled).Scan(
+		&subscription.ID,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	)
+}
+
+// GetSubscriptions implements driven.WebhookStore.
+func (wdb *WebhookDB) GetSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	rows, err := wdb.conn.Db.QueryContext(ctx, `
+		SELECT
+			id,
+			partner_id,
+			url,
+			event_types,
+			secret,
+			enabled,
+			consecutive_failures,
+			disabled_at,
+			created_at,
+			updated_at
+		FROM
+			webhook_subscriptions
+		ORDER BY
+			created_at
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []*entity.WebhookSubscription{}
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+// GetSubscriptionByID implements driven.WebhookStore.
+func (wdb *WebhookDB) GetSubscriptionByID(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	uuidSubscription, _ := uuid.Parse(id)
+	return scanWebhookSubscription(wdb.conn.Db.QueryRowContext(ctx, `
+		SELECT
+			id,
+			partner_id,
+			url,
+			event_types,
+			secret,
+			enabled,
+			consecutive_failures,
+			disabled_at,
+			created_at,
+			updated_at
+		FROM
+			webhook_subscriptions
+		WHERE
+			id = $1
+	`, uuidSubscription))
+}
+
+// UpdateSubscription implements driven.WebhookStore.
+func (wdb *WebhookDB) UpdateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	uuidSubscription, _ := uuid.Parse(subscription.ID)
+	result, err := wdb.conn.Db.ExecContext(ctx, `
+		UPDATE
+			webhook_subscriptions
+		SET
+			url = $1,
+			event_types = $2,
+			enabled = $3,
+			consecutive_failures = $4,
+			disabled_at = $5,
+			updated_at = NOW()
+		WHERE
+			id = $6
+	`, subscription.URL, pq.Array(subscription.EventTypes), subscription.Enabled, subscription.ConsecutiveFailures, subscription.DisabledAt, uuidSubscription)
+	return expectAffected(result, err)
+}
+
+// DeleteSubscription implements driven.WebhookStore.
+// The deliveries and the attempts of the subscription are deleted as well.
+func (wdb *WebhookDB) DeleteSubscription(ctx context.Context, id string) error {
+	uuidSubscription, _ := uuid.Parse(id)
+	result, err := wdb.conn.Db.ExecContext(ctx, `
+		DELETE FROM
+			webhook_subscriptions
+		WHERE
+			id = $1
+	`, uuidSubscription)
+	return expectAffected(result, err)
+}
+
+// LinkPartnerUser implements driven.WebhookStore.
+func (wdb *WebhookDB) LinkPartnerUser(ctx context.Context, partnerID, userID string) error {
+	uuidUser, _ := uuid.Parse(userID)
+	_, err := wdb.conn.Db.ExecContext(ctx, `
+		INSERT INTO
+			partner_users (partner_id, user_id)
+		VALUES
+			($1, $2)
+		ON CONFLICT (partner_id, user_id) DO NOTHING
+	`, partnerID, uuidUser)
+	return err
+}
+
+// UnlinkPartnerUser implements driven.WebhookStore.
+// The deliveries which are already queued are still sent.
+func (wdb *WebhookDB) UnlinkPartnerUser(ctx context.Context, partnerID, userID string) error {
+	uuidUser, _ := uuid.Parse(userID)
+	result, err := wdb.conn.Db.ExecContext(ctx, `
+		DELETE FROM
+			partner_users
+		WHERE
+			partner_id = $1
+			AND user_id = $2
+	`, partnerID, uuidUser)
+	return expectAffected(result, err)
+}
+
+// QueueDeliveries implements driven.WebhookStore.
+func (wdb *WebhookDB) QueueDeliveries(ctx context.Context, event *entity.Event) error {
+	// the event is marked as failed by the outbox relay instead of being queued for nobody
+	uuidUser, err := uuid.Parse(event.AggregateID)
+	if err != nil {
+		return fmt.Errorf("invalid aggregate id of event %s: %w", event.ID, err)
+	}
+	_, err = wdb.conn.Db.ExecContext(ctx, `
+		INSERT INTO
+			webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT
+			webhook_subscriptions.id,
+			$1,
+			$2,
+			$3
+		FROM
+			webhook_subscriptions
+			JOIN partner_users ON partner_users.partner_id = webhook_subscriptions.partner_id
+		WHERE
+			partner_users.user_id = $4
+			AND webhook_subscriptions.enabled
+			AND $2 = ANY(webhook_subscriptions.event_types)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`, event.ID, event.Type, string(event.Payload), uuidUser)
+	return err
+}
+
+// ClaimDueDeliveries implements driven.WebhookStore.
+func (wdb *WebhookDB) ClaimDueDeliveries(ctx context.Context, limit int, lockDuration time.Duration) ([]*entity.WebhookDelivery, error) {
+	rows, err := wdb.conn.Db.QueryContext(ctx, `
+		WITH claimed AS (
+			UPDATE
+				webhook_deliveries
+			SET
+				locked_until = NOW() + $2::BIGINT * INTERVAL '1 millisecond'
+			WHERE
+				id IN (
+					SELECT
+						webhook_deliveries.id
+					FROM
+						webhook_deliveries
+						JOIN webhook_subscriptions ON webhook_subscriptions.id = webhook_deliveries.subscription_id
+					WHERE
+						webhook_deliveries.status = 'pending'
+						AND webhook_deliveries.next_attempt_at <= NOW()
+						AND (webhook_deliveries.locked_until IS NULL OR webhook_deliveries.locked_until < NOW())
+						AND webhook_subscriptions.enabled
+					ORDER BY
+						webhook_deliveries.next_attempt_at
+					LIMIT
+						$1
+					FOR UPDATE OF webhook_deliveries SKIP LOCKED
+				)
+			RETURNING
+				id,
+				subscription_id,
+				event_id,
+				event_type,
+				payload,
+				status,
+				attempts,
+				next_attempt_at
+		)
+		SELECT
+			claimed.id,
+			claimed.event_id,
+			claimed.event_type,
+			claimed.payload,
+			claimed.status,
+			claimed.attempts,
+			claimed.next_attempt_at,
+			webhook_subscriptions.id,
+			webhook_subscriptions.url,
+			webhook_subscriptions.secret,
+			webhook_subscriptions.enabled,
+			webhook_subscriptions.consecutive_failures
+		FROM
+			claimed
+			JOIN webhook_subscriptions ON webhook_subscriptions.id = claimed.subscription_id
+		ORDER BY
+			claimed.next_attempt_at
+	`, limit, lockDuration.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := make(map[string]*entity.WebhookSubscription)
+	deliveries := []*entity.WebhookDelivery{}
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		var subscription entity.WebhookSubscription
+		err = rows.Scan(
+			&delivery.ID,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&subscription.ID,
+			&subscription.URL,
+			&subscription.Secret,
+			&subscription.Enabled,
+			&subscription.ConsecutiveFailures,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// share the subscription so the failures of its deliveries are counted together
+		if _, ok := subscriptions[subscription.ID]; !ok {
+			subscriptions[subscription.ID] = &subscription
+		}
+		delivery.Subscription = subscriptions[subscription.ID]
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// SaveAttempt implements driven.WebhookStore.
+func (wdb *WebhookDB) SaveAttempt(ctx context.Context, delivery *entity.WebhookDelivery, attempt *entity.WebhookDeliveryAttempt) error {
+	tx, err := wdb.conn.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var attemptError sql.NullString
+	if attempt.Error != "" {
+		attemptError = sql.NullString{String: attempt.Error, Valid: true}
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO
+			webhook_delivery_attempts (delivery_id, subscription_id, attempt, status_code, error, duration_ms, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+		RETURNING
+			id
+	`, attempt.DeliveryID, attempt.SubscriptionID, attempt.Attempt, attempt.StatusCode, attemptError, attempt.Duration.Milliseconds(), attempt.CreatedAt).Scan(&attempt.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE
+			webhook_deliveries
+		SET
+			status = $1,
+			attempts = $2,
+			next_attempt_at = $3,
+			locked_until = NULL,
+			updated_at = NOW()
+		WHERE
+			id = $4
+	`, delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE
+			webhook_subscriptions
+		SET
+			consecutive_failures = $1,
+			enabled = $2,
+			disabled_at = $3,
+			updated_at = NOW()
+		WHERE
+			id = $4
+	`, delivery.Subscription.ConsecutiveFailures, delivery.Subscription.Enabled, delivery.Subscription.DisabledAt, delivery.Subscription.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetDeliveryAttempts implements driven.WebhookStore.
+func (wdb *WebhookDB) GetDeliveryAttempts(ctx context.Context, subscriptionID string, limit int) ([]*entity.WebhookDeliveryAttempt, error) {
+	uuidSubscription, _ := uuid.Parse(subscriptionID)
+	rows, err := wdb.conn.Db.QueryContext(ctx, `
+		SELECT
+			webhook_delivery_attempts.id,
+			webhook_delivery_attempts.delivery_id,
+			webhook_delivery_attempts.subscription_id,
+			webhook_deliveries.event_id,
+			webhook_deliveries.event_type,
+			webhook_delivery_attempts.attempt,
+			webhook_delivery_attempts.status_code,
+			COALESCE(webhook_delivery_attempts.error, ''),
+			webhook_delivery_attempts.duration_ms,
+			webhook_delivery_attempts.created_at
+		FROM
+			webhook_delivery_attempts
+			JOIN webhook_deliveries ON webhook_deliveries.id = webhook_delivery_attempts.delivery_id
+		WHERE
+			webhook_delivery_attempts.subscription_id = $1
+		ORDER BY
+			webhook_delivery_attempts.created_at DESC
+		LIMIT
+			$2
+	`, uuidSubscription, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := []*entity.WebhookDeliveryAttempt{}
+	for rows.Next() {
+		var attempt entity.WebhookDeliveryAttempt
+		var durationMs int64
+		err = rows.Scan(
+			&attempt.ID,
+			&attempt.DeliveryID,
+			&attempt.SubscriptionID,
+			&attempt.EventID,
+			&attempt.EventType,
+			&attempt.Attempt,
+			&attempt.StatusCode,
+			&attempt.Error,
+			&durationMs,
+			&attempt.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		attempt.Duration = time.Duration(durationMs) * time.Millisecond
+		attempts = append(attempts, &attempt)
+	}
+	return attempts, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhookSubscription(row rowScanner) (*entity.WebhookSubscription, error) {
+	var subscription entity.WebhookSubscription
+	var disabledAt sql.NullTime
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.PartnerID,
+		&subscription.URL,
+		pq.Array(&subscription.EventTypes),
+		&subscription.Secret,
+		&subscription.Enabled,
+		&subscription.ConsecutiveFailures,
+		&disabledAt,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	if disabledAt.Valid {
+		subscription.DisabledAt = &disabledAt.Time
+	}
+	return &subscription, nil
+}
+
+// expectAffected returns driven.ErrNotFound when nothing is changed.
+func expectAffected(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return driven.ErrNotFound
+	}
+	return nil
+}
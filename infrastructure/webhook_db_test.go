@@ -0,0 +1,370 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-faker/faker/v4"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookDB_QueueDeliveries(t *testing.T) {
+	event := entity.NewUserCreatedEvent(&entity.User{
+		ID:       faker.UUIDHyphenated(),
+		FullName: "John Doe",
+	})
+	conn, dbMock := newMockConn()
+	defer conn.Close()
+	wdb := NewWebhookDB(&PostgreConnection{
+		Db: conn,
+	})
+
+	dbMock.ExpectExec("INSERT INTO webhook_deliveries (.+) FROM webhook_subscriptions JOIN partner_users (.+) WHERE partner_users.user_id = (.+) ON CONFLICT").
+		WithArgs(event.ID, event.Type, string(event.Payload), event.AggregateID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	assert := assert.New(t)
+	assert.NoError(wdb.QueueDeliveries(context.Background(), event))
+	assert.NoError(dbMock.ExpectationsWereMet())
+}
+
+func TestWebhookDB_QueueDeliveries_InvalidAggregateID(t *testing.T) {
+	event := entity.NewUserCreatedEvent(&entity.User{ID: "1"})
+	conn, dbMock := newMockConn()
+	defer conn.Close()
+	wdb := NewWebhookDB(&PostgreConnection{
+		Db: conn,
+	})
+
+	assert := assert.New(t)
+	assert.Error(wdb.QueueDeliveries(context.Background(), event))
+	assert.NoError(dbMock.ExpectationsWereMet())
+}
+
+func TestWebhookDB_LinkPartnerUser(t *testing.T) {
+	userID := faker.UUIDHyphenated()
+	conn, dbMock := newMockConn()
+	defer conn.Close()
+	wdb := NewWebhookDB(&PostgreConnection{
+		Db: conn,
+	})
+
+	dbMock.ExpectExec("INSERT INTO partner_users (.+) ON CONFLICT").
+		WithArgs("acme", userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert := assert.New(t)
+	assert.NoError(wdb.LinkPartnerUser(context.Background(), "acme", userID))
+	assert.NoError(dbMock.ExpectationsWereMet())
+}
+
+func TestWebhookDB_UnlinkPartnerUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantErr    error
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "when link is not found, it should return not found error",
+			wantErr: driven.ErrNotFound,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM partner_users").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "when link is deleted, it should return no error",
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM partner_users").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			wdb := NewWebhookDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			err := wdb.UnlinkPartnerUser(context.Background(), "acme", faker.UUIDHyphenated())
+
+			assert := assert.New(t)
+			assert.ErrorIs(err, tt.wantErr)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookDB_CreateSubscription(t *testing.T) {
+	subscription := &entity.WebhookSubscription{
+		PartnerID:  "acme",
+		URL:        "https://partner.example.com/webhooks",
+		EventTypes: []string{entity.UserCreatedEventType},
+		Secret:     "0123456789abcdef",
+		Enabled:    true,
+	}
+	id := faker.UUIDHyphenated()
+	now := time.Now()
+	conn, dbMock := newMockConn()
+	defer conn.Close()
+	wdb := NewWebhookDB(&PostgreConnection{
+		Db: conn,
+	})
+
+	dbMock.ExpectQuery("INSERT INTO webhook_subscriptions").
+		WithArgs(subscription.PartnerID, subscription.URL, pq.Array(subscription.EventTypes), subscription.Secret, true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(id, now, now))
+
+	assert := assert.New(t)
+	assert.NoError(wdb.CreateSubscription(context.Background(), subscription))
+	assert.Equal(id, subscription.ID)
+	assert.Equal(now, subscription.CreatedAt)
+	assert.NoError(dbMock.ExpectationsWereMet())
+}
+
+func TestWebhookDB_GetSubscriptionByID(t *testing.T) {
+	id := faker.UUIDHyphenated()
+	now := time.Now()
+	columns := []string{"id", "partner_id", "url", "event_types", "secret", "enabled", "consecutive_failures", "disabled_at", "created_at", "updated_at"}
+	tests := []struct {
+		name       string
+		want       *entity.WebhookSubscription
+		wantErr    error
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "when subscription is not found, it should return not found error",
+			wantErr: driven.ErrNotFound,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM webhook_subscriptions WHERE id").WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name: "when subscription is disabled, it should return the disabled time",
+			want: &entity.WebhookSubscription{
+				ID:                  id,
+				PartnerID:           "acme",
+				URL:                 "https://partner.example.com/webhooks",
+				EventTypes:          []string{entity.UserCreatedEventType, entity.UserLoggedInEventType},
+				Secret:              "0123456789abcdef",
+				ConsecutiveFailures: 20,
+				DisabledAt:          &now,
+				CreatedAt:           now,
+				UpdatedAt:           now,
+			},
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM webhook_subscriptions WHERE id").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(id, "acme", "https://partner.example.com/webhooks", "{UserCreated,UserLoggedIn}", "0123456789abcdef", false, 20, now, now, now))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			wdb := NewWebhookDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			got, err := wdb.GetSubscriptionByID(context.Background(), id)
+
+			assert := assert.New(t)
+			assert.ErrorIs(err, tt.wantErr)
+			assert.Equal(tt.want, got)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookDB_DeleteSubscription(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantErr    error
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "when subscription is not found, it should return not found error",
+			wantErr: driven.ErrNotFound,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM webhook_subscriptions").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "when subscription is deleted, it should return no error",
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM webhook_subscriptions").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			wdb := NewWebhookDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			err := wdb.DeleteSubscription(context.Background(), faker.UUIDHyphenated())
+
+			assert := assert.New(t)
+			assert.ErrorIs(err, tt.wantErr)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookDB_ClaimDueDeliveries(t *testing.T) {
+	subscriptionID := faker.UUIDHyphenated()
+	now := time.Now()
+	columns := []string{
+		"id", "event_id", "event_type", "payload", "status", "attempts", "next_attempt_at",
+		"subscription_id", "url", "secret", "enabled", "consecutive_failures",
+	}
+	conn, dbMock := newMockConn()
+	defer conn.Close()
+	wdb := NewWebhookDB(&PostgreConnection{
+		Db: conn,
+	})
+
+	dbMock.ExpectQuery("WITH claimed AS (.+) FOR UPDATE OF webhook_deliveries SKIP LOCKED").
+		WithArgs(50, int64(60000)).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(faker.UUIDHyphenated(), faker.UUIDHyphenated(), entity.UserCreatedEventType, []byte(`{}`), "pending", 0, now, subscriptionID, "https://a.example.com", "0123456789abcdef", true, 3).
+			AddRow(faker.UUIDHyphenated(), faker.UUIDHyphenated(), entity.UserLoggedInEventType, []byte(`{}`), "pending", 2, now, subscriptionID, "https://a.example.com", "0123456789abcdef", true, 3))
+
+	got, err := wdb.ClaimDueDeliveries(context.Background(), 50, time.Minute)
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	assert.Len(got, 2)
+	assert.Same(got[0].Subscription, got[1].Subscription)
+	assert.Equal(3, got[0].Subscription.ConsecutiveFailures)
+	assert.Equal(2, got[1].Attempts)
+	assert.NoError(dbMock.ExpectationsWereMet())
+}
+
+func TestWebhookDB_SaveAttempt(t *testing.T) {
+	now := time.Now()
+	delivery := &entity.WebhookDelivery{
+		ID: faker.UUIDHyphenated(),
+		Subscription: &entity.WebhookSubscription{
+			ID:                  faker.UUIDHyphenated(),
+			Enabled:             false,
+			ConsecutiveFailures: 20,
+			DisabledAt:          &now,
+		},
+		Status:        entity.WebhookDeliveryPending,
+		Attempts:      3,
+		NextAttemptAt: now.Add(time.Minute),
+	}
+	attempt := &entity.WebhookDeliveryAttempt{
+		DeliveryID:     delivery.ID,
+		SubscriptionID: delivery.Subscription.ID,
+		Attempt:        3,
+		StatusCode:     500,
+		Error:          "unexpected status code 500",
+		Duration:       120 * time.Millisecond,
+		CreatedAt:      now,
+	}
+	attemptID := faker.UUIDHyphenated()
+	tests := []struct {
+		name       string
+		wantErr    bool
+		expectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "when failed to update subscription, it should rollback",
+			wantErr: true,
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO webhook_delivery_attempts").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(attemptID))
+				mock.ExpectExec("UPDATE webhook_deliveries").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE webhook_subscriptions").WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "when attempt saved, it should update the delivery and subscription in one transaction",
+			expectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO webhook_delivery_attempts").
+					WithArgs(delivery.ID, delivery.Subscription.ID, 3, 500, "unexpected status code 500", int64(120), now).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(attemptID))
+				mock.ExpectExec("UPDATE webhook_deliveries SET status = (.+) locked_until = NULL").
+					WithArgs(entity.WebhookDeliveryPending, 3, delivery.NextAttemptAt, delivery.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE webhook_subscriptions SET consecutive_failures").
+					WithArgs(20, false, &now, delivery.Subscription.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, dbMock := newMockConn()
+			defer conn.Close()
+			wdb := NewWebhookDB(&PostgreConnection{
+				Db: conn,
+			})
+
+			tt.expectFunc(dbMock)
+
+			err := wdb.SaveAttempt(context.Background(), delivery, attempt)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.NoError(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookDB_GetDeliveryAttempts(t *testing.T) {
+	subscriptionID := faker.UUIDHyphenated()
+	now := time.Now()
+	conn, dbMock := newMockConn()
+	defer conn.Close()
+	wdb := NewWebhookDB(&PostgreConnection{
+		Db: conn,
+	})
+
+	dbMock.ExpectQuery("SELECT (.+) FROM webhook_delivery_attempts (.+) ORDER BY webhook_delivery_attempts.created_at DESC").
+		WithArgs(sqlmock.AnyArg(), 10).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "delivery_id", "subscription_id", "event_id", "event_type", "attempt", "status_code", "error", "duration_ms", "created_at",
+		}).AddRow("1", "2", subscriptionID, "3", entity.UserCreatedEventType, 1, 0, "timeout", 10000, now))
+
+	got, err := wdb.GetDeliveryAttempts(context.Background(), subscriptionID, 10)
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	assert.Equal([]*entity.WebhookDeliveryAttempt{
+		{
+			ID:             "1",
+			DeliveryID:     "2",
+			SubscriptionID: subscriptionID,
+			EventID:        "3",
+			EventType:      entity.UserCreatedEventType,
+			Attempt:        1,
+			Error:          "timeout",
+			Duration:       10 * time.Second,
+			CreatedAt:      now,
+		},
+	}, got)
+	assert.NoError(dbMock.ExpectationsWereMet())
+}
@@ -0,0 +1,62 @@
+// Package auth carries the claims of the authenticated token through the request context.
+package auth
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// Claims are the claims of a validated token.
+type Claims struct {
+	// Subject is the user ID for the user tokens and the client name for the client tokens
+	Subject string
+	// ClientType is empty for the user tokens, e.g. entity.ServiceClientType for the client tokens
+	ClientType string
+	// Scopes are granted to the token, they are checked against the scopes of the operation in api.yml
+	Scopes []string
+}
+
+// NewClaims reads the claims validated by driven.TokenProvider.
+func NewClaims(claims map[string]interface{}) *Claims {
+	subject, _ := claims["sub"].(string)
+	clientType, _ := claims["client_type"].(string)
+	// the scopes are space separated like the scope of OAuth 2.0
+	scope, _ := claims["scope"].(string)
+	return &Claims{
+		Subject:    subject,
+		ClientType: clientType,
+		Scopes:     strings.Fields(scope),
+	}
+}
+
+// HasScopes reports whether every given scope is granted.
+func (c *Claims) HasScopes(scopes []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+type claimsContextKey struct{}
+
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*Claims)
+	return claims, ok
+}
+
+// UserID returns the user of the user token, the auth middleware only lets the user tokens
+// reach the operations of the current user so it is empty only when the middleware is missing.
+func UserID(ctx context.Context) string {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok || claims.ClientType != "" {
+		return ""
+	}
+	return claims.Subject
+}
@@ -0,0 +1,280 @@
+package customerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	LanguageEnglish    = "en"
+	LanguageIndonesian = "id"
+)
+
+// Languages are the languages of the messages, the first one is the default.
+var Languages = []string{LanguageEnglish, LanguageIndonesian}
+
+// the codes are part of the API, they must not be renamed
+const (
+	CodeAtLeastOneField           = "at_least_one_field"
+	CodeLengthBetween             = "length_between"
+	CodeMinLength                 = "min_length"
+	CodeMaxLength                 = "max_length"
+	CodeRangeBetween              = "range_between"
+	CodeInvalid                   = "invalid"
+	CodeEmpty                     = "empty"
+	CodeRequired                  = "required"
+	CodeOneOf                     = "one_of"
+	CodeReserved                  = "reserved"
+	CodeTaken                     = "taken"
+	CodeInvalidUUID               = "invalid_uuid"
+	CodeInvalidDateTime           = "invalid_date_time"
+	CodePasswordComplexity        = "password_complexity"
+	CodeWrongPassword             = "wrong_password"
+	CodeWrongPhoneNumberPassword  = "wrong_phone_number_password"
+	CodeWrongEmailPassword        = "wrong_email_password"
+	CodeRecentlyUsedPassword      = "recently_used_password"
+	CodeInvalidEmail              = "invalid_email"
+	CodeInvalidVerificationToken  = "invalid_verification_token"
+	CodeInvalidPhoneNumber        = "invalid_phone_number"
+	CodeUnsupportedCountryCode    = "unsupported_country_code"
+	CodePhoneNumberLength         = "phone_number_length"
+	CodePhoneNumberPrefix         = "phone_number_prefix"
+	CodeInvalidUsername           = "invalid_username"
+	CodeInvalidUsernameUnderscore = "invalid_username_underscore"
+	CodeSameUsername              = "same_username"
+	CodeUsernameChangeInterval    = "username_change_interval"
+	CodeInvalidURL                = "invalid_url"
+	CodeInvalidDate               = "invalid_date"
+	CodeDateOfBirthRange          = "date_of_birth_range"
+	CodeInvalidLocale             = "invalid_locale"
+	CodeInvalidTimezone           = "invalid_timezone"
+	CodeInvalidPostalCode         = "invalid_postal_code"
+	CodeInvalidCountry            = "invalid_country"
+	CodeMaxSize                   = "max_size"
+	CodeUnsupportedImage          = "unsupported_image"
+	CodeInvalidImage              = "invalid_image"
+	CodeMultipartFile             = "multipart_file"
+	CodeAtLeastOneIDOrPhoneNumber = "at_least_one_id_or_phone_number"
+	CodeBatchMaxSize              = "batch_max_size"
+	CodeInvalidWebhookURL         = "invalid_webhook_url"
+	CodeInvalidPartnerID          = "invalid_partner_id"
+	CodeAtLeastOneEventType       = "at_least_one_event_type"
+	CodeUnsupportedEventType      = "unsupported_event_type"
+	CodeAtLeastOneScope           = "at_least_one_scope"
+	CodeUnsupportedScope          = "unsupported_scope"
+	CodeFutureTime                = "future_time"
+)
+
+// messages are the templates of every code by language, {name} is replaced by the param of the name.
+var messages = map[string]map[string]string{
+	CodeAtLeastOneField: {
+		LanguageEnglish:    "at least 1 field must be present",
+		LanguageIndonesian: "minimal 1 kolom harus diisi",
+	},
+	CodeLengthBetween: {
+		LanguageEnglish:    "must be between {min} and {max} characters in length",
+		LanguageIndonesian: "harus terdiri dari {min} sampai {max} karakter",
+	},
+	CodeMinLength: {
+		LanguageEnglish:    "must be at least {min} characters in length",
+		LanguageIndonesian: "harus terdiri dari minimal {min} karakter",
+	},
+	CodeMaxLength: {
+		LanguageEnglish:    "must be at most {max} characters in length",
+		LanguageIndonesian: "harus terdiri dari maksimal {max} karakter",
+	},
+	CodeRangeBetween: {
+		LanguageEnglish:    "must be between {min} and {max}",
+		LanguageIndonesian: "harus di antara {min} sampai {max}",
+	},
+	CodeInvalid: {
+		LanguageEnglish:    "is not valid",
+		LanguageIndonesian: "tidak valid",
+	},
+	CodeEmpty: {
+		LanguageEnglish:    "must not be empty",
+		LanguageIndonesian: "tidak boleh kosong",
+	},
+	CodeRequired: {
+		LanguageEnglish:    "is required",
+		LanguageIndonesian: "wajib diisi",
+	},
+	CodeOneOf: {
+		LanguageEnglish:    "must be one of {values}",
+		LanguageIndonesian: "harus salah satu dari {values}",
+	},
+	CodeReserved: {
+		LanguageEnglish:    "is reserved",
+		LanguageIndonesian: "sudah dicadangkan",
+	},
+	CodeTaken: {
+		LanguageEnglish:    "is already taken",
+		LanguageIndonesian: "sudah digunakan",
+	},
+	CodeInvalidUUID: {
+		LanguageEnglish:    "must be a valid UUID",
+		LanguageIndonesian: "harus berupa UUID yang valid",
+	},
+	CodeInvalidDateTime: {
+		LanguageEnglish:    "must be a date and time in RFC 3339 format, e.g. 2024-01-31T13:00:00Z",
+		LanguageIndonesian: "harus berupa tanggal dan waktu dalam format RFC 3339, misalnya 2024-01-31T13:00:00Z",
+	},
+	CodePasswordComplexity: {
+		LanguageEnglish:    "containing at least 1 capital characters AND 1 number AND 1 special (nonalpha-numeric) characters",
+		LanguageIndonesian: "harus mengandung minimal 1 huruf kapital, 1 angka dan 1 karakter khusus (selain huruf dan angka)",
+	},
+	CodeWrongPassword: {
+		LanguageEnglish:    "wrong password",
+		LanguageIndonesian: "kata sandi salah",
+	},
+	CodeWrongPhoneNumberPassword: {
+		LanguageEnglish:    "wrong phone number/password",
+		LanguageIndonesian: "nomor telepon/kata sandi salah",
+	},
+	CodeWrongEmailPassword: {
+		LanguageEnglish:    "wrong email/password",
+		LanguageIndonesian: "email/kata sandi salah",
+	},
+	CodeRecentlyUsedPassword: {
+		LanguageEnglish:    "must be different from recently used passwords",
+		LanguageIndonesian: "harus berbeda dari kata sandi yang baru saja digunakan",
+	},
+	CodeInvalidEmail: {
+		LanguageEnglish:    "must be a valid email address",
+		LanguageIndonesian: "harus berupa alamat email yang valid",
+	},
+	CodeInvalidVerificationToken: {
+		LanguageEnglish:    "invalid or expired verification token",
+		LanguageIndonesian: "token verifikasi tidak valid atau sudah kedaluwarsa",
+	},
+	CodeInvalidPhoneNumber: {
+		LanguageEnglish:    "must be a valid phone number in international or national format",
+		LanguageIndonesian: "harus berupa nomor telepon yang valid dalam format internasional atau nasional",
+	},
+	CodeUnsupportedCountryCode: {
+		LanguageEnglish:    "country calling code is not supported",
+		LanguageIndonesian: "kode negara tidak didukung",
+	},
+	CodePhoneNumberLength: {
+		LanguageEnglish:    "must be between {min} and {max} digits after country code +{countryCode}",
+		LanguageIndonesian: "harus terdiri dari {min} sampai {max} digit setelah kode negara +{countryCode}",
+	},
+	CodePhoneNumberPrefix: {
+		LanguageEnglish:    "must start with a valid prefix for {region}",
+		LanguageIndonesian: "harus diawali dengan prefiks yang valid untuk {region}",
+	},
+	CodeInvalidUsername: {
+		LanguageEnglish:    "must start with a letter and only contain letters, numbers and underscores",
+		LanguageIndonesian: "harus diawali huruf dan hanya berisi huruf, angka dan garis bawah",
+	},
+	CodeInvalidUsernameUnderscore: {
+		LanguageEnglish:    "must not end with or contain consecutive underscores",
+		LanguageIndonesian: "tidak boleh diakhiri atau berisi garis bawah berturut-turut",
+	},
+	CodeSameUsername: {
+		LanguageEnglish:    "must be different from the current username",
+		LanguageIndonesian: "harus berbeda dari username saat ini",
+	},
+	CodeUsernameChangeInterval: {
+		LanguageEnglish:    "can only be changed once every {days} days (next change is allowed after {nextChangeAt})",
+		LanguageIndonesian: "hanya dapat diubah sekali setiap {days} hari (perubahan berikutnya dapat dilakukan setelah {nextChangeAt})",
+	},
+	CodeInvalidURL: {
+		LanguageEnglish:    "must be an absolute http or https url",
+		LanguageIndonesian: "harus berupa url http atau https yang absolut",
+	},
+	CodeInvalidDate: {
+		LanguageEnglish:    "must be a valid date in YYYY-MM-DD format",
+		LanguageIndonesian: "harus berupa tanggal yang valid dalam format YYYY-MM-DD",
+	},
+	CodeDateOfBirthRange: {
+		LanguageEnglish:    "must be in the past and within the last {maxAge} years",
+		LanguageIndonesian: "harus di masa lalu dan dalam {maxAge} tahun terakhir",
+	},
+	CodeInvalidLocale: {
+		LanguageEnglish:    "must be a language tag like 'id' or 'en-US'",
+		LanguageIndonesian: "harus berupa tag bahasa seperti 'id' atau 'en-US'",
+	},
+	CodeInvalidTimezone: {
+		LanguageEnglish:    "must be a valid IANA time zone like 'Asia/Jakarta'",
+		LanguageIndonesian: "harus berupa zona waktu IANA yang valid seperti 'Asia/Jakarta'",
+	},
+	CodeInvalidPostalCode: {
+		LanguageEnglish:    "must be at most {max} letters or numbers",
+		LanguageIndonesian: "harus terdiri dari maksimal {max} huruf atau angka",
+	},
+	CodeInvalidCountry: {
+		LanguageEnglish:    "must be an ISO 3166-1 alpha-2 country code like 'ID'",
+		LanguageIndonesian: "harus berupa kode negara ISO 3166-1 alpha-2 seperti 'ID'",
+	},
+	CodeMaxSize: {
+		LanguageEnglish:    "must be at most {max} bytes in size",
+		LanguageIndonesian: "harus berukuran maksimal {max} byte",
+	},
+	CodeUnsupportedImage: {
+		LanguageEnglish:    "must be a JPEG, PNG or WebP image",
+		LanguageIndonesian: "harus berupa gambar JPEG, PNG atau WebP",
+	},
+	CodeInvalidImage: {
+		LanguageEnglish:    "must be a valid image of at most {maxDimension}x{maxDimension} pixels",
+		LanguageIndonesian: "harus berupa gambar yang valid berukuran maksimal {maxDimension}x{maxDimension} piksel",
+	},
+	CodeMultipartFile: {
+		LanguageEnglish:    "must be uploaded as multipart form file",
+		LanguageIndonesian: "harus diunggah sebagai file multipart form",
+	},
+	CodeAtLeastOneIDOrPhoneNumber: {
+		LanguageEnglish:    "at least 1 id or phone number must be present",
+		LanguageIndonesian: "minimal 1 id atau nomor telepon harus diisi",
+	},
+	CodeBatchMaxSize: {
+		LanguageEnglish:    "must be at most {max} ids and phone numbers in total",
+		LanguageIndonesian: "total id dan nomor telepon maksimal {max}",
+	},
+	CodeInvalidWebhookURL: {
+		LanguageEnglish:    "must be an absolute https URL of a public host of at most {max} characters",
+		LanguageIndonesian: "harus berupa URL https yang absolut ke host publik dengan maksimal {max} karakter",
+	},
+	CodeInvalidPartnerID: {
+		LanguageEnglish:    "must be at most {max} lowercase letters, digits and hyphens, starting with a letter or a digit",
+		LanguageIndonesian: "harus berupa maksimal {max} huruf kecil, angka, dan tanda hubung, diawali huruf atau angka",
+	},
+	CodeAtLeastOneEventType: {
+		LanguageEnglish:    "at least 1 event type must be present",
+		LanguageIndonesian: "minimal 1 jenis event harus diisi",
+	},
+	CodeUnsupportedEventType: {
+		LanguageEnglish:    `"{eventType}" is not a supported event type`,
+		LanguageIndonesian: `"{eventType}" bukan jenis event yang didukung`,
+	},
+	CodeAtLeastOneScope: {
+		LanguageEnglish:    "at least 1 scope must be present",
+		LanguageIndonesian: "minimal 1 scope harus diisi",
+	},
+	CodeUnsupportedScope: {
+		LanguageEnglish:    `"{scope}" is not a supported scope`,
+		LanguageIndonesian: `"{scope}" bukan scope yang didukung`,
+	},
+	CodeFutureTime: {
+		LanguageEnglish:    "must be in the future",
+		LanguageIndonesian: "harus di masa depan",
+	},
+}
+
+// renderMessage returns the code itself when it has no message so a missing one is noticed.
+func renderMessage(language, code string, params Params) string {
+	templates, ok := messages[code]
+	if !ok {
+		return code
+	}
+	template, ok := templates[language]
+	if !ok {
+		template = templates[LanguageEnglish]
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
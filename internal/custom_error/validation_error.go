@@ -5,34 +5,64 @@ import (
 	"strings"
 )
 
+// Params are the values of the placeholders in the message of a code, e.g. {"min": 3, "max": 60}.
+type Params map[string]any
+
+// FieldError is an invalid field, its message is rendered from the code and the params
+// so the clients get it in their language.
+type FieldError struct {
+	Field  string
+	Code   string
+	Params Params
+}
+
+// Message renders the message in the given language, English is used for the other languages.
+func (fe FieldError) Message(language string) string {
+	return renderMessage(language, fe.Code, fe.Params)
+}
+
+// ValidationError keeps the field errors in the order they are added.
 type ValidationError struct {
-	errors map[string]string
+	errors []FieldError
 }
 
 func NewValidationError() *ValidationError {
-	return &ValidationError{errors: make(map[string]string)}
+	return &ValidationError{}
 }
 
-func NewValidationErrorWithMessage(key, message string) *ValidationError {
-	return &ValidationError{
-		errors: map[string]string{
-			key: message,
-		},
-	}
+func NewValidationErrorWithCode(field, code string, params Params) *ValidationError {
+	validationError := NewValidationError()
+	validationError.AddError(field, code, params)
+	return validationError
 }
 
-func (ve *ValidationError) AddError(key, message string) {
-	if val, ok := ve.errors[key]; ok {
-		ve.errors[key] = val + "," + message
-	} else {
-		ve.errors[key] = message
-	}
+func (ve *ValidationError) AddError(field, code string, params Params) {
+	ve.errors = append(ve.errors, FieldError{
+		Field:  field,
+		Code:   code,
+		Params: params,
+	})
+}
+
+func (ve ValidationError) Errors() []FieldError {
+	return ve.errors
 }
 
+// Error lists the English messages grouped by field, e.g. "password: m1,m2;fullName: m3".
+// It is meant for the logs, the clients get the structured errors.
 func (ve ValidationError) Error() string {
-	var errorMessages []string
-	for key, msg := range ve.errors {
-		errorMessages = append(errorMessages, fmt.Sprintf("%s: %s", key, msg))
+	var fields []string
+	messages := make(map[string][]string)
+	for _, fieldError := range ve.errors {
+		if _, ok := messages[fieldError.Field]; !ok {
+			fields = append(fields, fieldError.Field)
+		}
+		messages[fieldError.Field] = append(messages[fieldError.Field], fieldError.Message(LanguageEnglish))
+	}
+
+	errorMessages := make([]string, 0, len(fields))
+	for _, field := range fields {
+		errorMessages = append(errorMessages, fmt.Sprintf("%s: %s", field, strings.Join(messages[field], ",")))
 	}
 	return strings.Join(errorMessages, ";")
 }
@@ -44,9 +74,7 @@ func (ve ValidationError) HasError() bool {
 func (ve *ValidationError) Merge(otherErr error) {
 	if otherErr != nil {
 		if otherValidationError, ok := otherErr.(*ValidationError); ok {
-			for key, msg := range otherValidationError.errors {
-				ve.AddError(key, msg)
-			}
+			ve.errors = append(ve.errors, otherValidationError.errors...)
 		}
 	}
 }
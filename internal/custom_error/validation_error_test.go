@@ -0,0 +1,75 @@
+package customerror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationError(t *testing.T) {
+	validationError := NewValidationError()
+	validationError.AddError("password", CodeLengthBetween, Params{"min": 6, "max": 64})
+	validationError.AddError("fullName", CodeAtLeastOneField, nil)
+	validationError.Merge(NewValidationErrorWithCode("password", CodePasswordComplexity, nil))
+
+	assert := assert.New(t)
+	assert.True(validationError.HasError())
+	assert.Equal([]FieldError{
+		{Field: "password", Code: CodeLengthBetween, Params: Params{"min": 6, "max": 64}},
+		{Field: "fullName", Code: CodeAtLeastOneField},
+		{Field: "password", Code: CodePasswordComplexity},
+	}, validationError.Errors())
+	assert.Equal(
+		"password: must be between 6 and 64 characters in length,"+
+			"containing at least 1 capital characters AND 1 number AND 1 special (nonalpha-numeric) characters;"+
+			"fullName: at least 1 field must be present",
+		validationError.Error(),
+	)
+}
+
+func TestFieldError_Message(t *testing.T) {
+	tests := []struct {
+		name       string
+		fieldError FieldError
+		language   string
+		want       string
+	}{
+		{
+			name:       "when language is english, it should render english message",
+			fieldError: FieldError{Field: "fullName", Code: CodeLengthBetween, Params: Params{"min": 3, "max": 60}},
+			language:   LanguageEnglish,
+			want:       "must be between 3 and 60 characters in length",
+		},
+		{
+			name:       "when language is indonesian, it should render indonesian message",
+			fieldError: FieldError{Field: "fullName", Code: CodeLengthBetween, Params: Params{"min": 3, "max": 60}},
+			language:   LanguageIndonesian,
+			want:       "harus terdiri dari 3 sampai 60 karakter",
+		},
+		{
+			name:       "when language is not supported, it should render english message",
+			fieldError: FieldError{Field: "eventTypes", Code: CodeUnsupportedEventType, Params: Params{"eventType": "user.deleted"}},
+			language:   "fr",
+			want:       `"user.deleted" is not a supported event type`,
+		},
+		{
+			name:       "when code has no message, it should return the code",
+			fieldError: FieldError{Field: "id", Code: "unknown_code"},
+			language:   LanguageEnglish,
+			want:       "unknown_code",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.fieldError.Message(tt.language))
+		})
+	}
+}
+
+func TestMessages_EveryLanguage(t *testing.T) {
+	for code, templates := range messages {
+		for _, language := range Languages {
+			assert.NotEmpty(t, templates[language], "%s has no %s message", code, language)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Package health reports the readiness of the service from its lifecycle and the checks of its dependencies.
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"userservice/internal/logging"
+)
+
+// the status of a check and of the service
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Phase is the lifecycle of the service, it is ready only while serving.
+type Phase int32
+
+const (
+	// PhaseStarting is the phase until the startup is done, e.g. while the migrations run
+	PhaseStarting Phase = iota
+	PhaseServing
+	// PhaseStopping is the phase once the shutdown started, the load balancers stop sending requests then
+	PhaseStopping
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseServing:
+		return "serving"
+	case PhaseStopping:
+		return "stopping"
+	default:
+		return "starting"
+	}
+}
+
+// Check returns an error when the dependency cannot be used.
+type Check func(ctx context.Context) error
+
+type CheckResult struct {
+	Status  string
+	Latency time.Duration
+}
+
+type Report struct {
+	// Status is up only when the service is serving and every check is up
+	Status string
+	Phase  Phase
+	Checks map[string]CheckResult
+}
+
+// Checker runs the checks of the readiness, it starts in PhaseStarting.
+type Checker struct {
+	timeout time.Duration
+	checks  map[string]Check
+	phase   atomic.Int32
+}
+
+// NewChecker creates a checker whose checks fail after the timeout.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		checks:  make(map[string]Check),
+	}
+}
+
+// Add adds the check of a dependency, the checks are added before the service is serving.
+func (c *Checker) Add(name string, check Check) {
+	c.checks[name] = check
+}
+
+func (c *Checker) SetPhase(phase Phase) {
+	c.phase.Store(int32(phase))
+}
+
+func (c *Checker) Phase() Phase {
+	return Phase(c.phase.Load())
+}
+
+// Ready runs all the checks concurrently, the errors of the checks are logged and never reported
+// as the readiness is public.
+func (c *Checker) Ready(ctx context.Context) *Report {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	report := &Report{
+		Status: StatusUp,
+		Phase:  c.Phase(),
+		Checks: make(map[string]CheckResult, len(c.checks)),
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := check(ctx)
+			result := CheckResult{Status: StatusUp, Latency: time.Since(start)}
+			if err != nil {
+				logging.FromContext(ctx).Warn("health check failed", "check", name, "error", err)
+				result.Status = StatusDown
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if err != nil {
+				report.Status = StatusDown
+			}
+		}()
+	}
+	wg.Wait()
+
+	if report.Phase != PhaseServing {
+		report.Status = StatusDown
+	}
+	return report
+}
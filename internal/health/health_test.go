@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Ready(t *testing.T) {
+	tests := []struct {
+		name       string
+		phase      Phase
+		check      Check
+		wantStatus string
+		wantCheck  string
+	}{
+		{
+			name:       "when serving and check is up, it should be up",
+			phase:      PhaseServing,
+			check:      func(ctx context.Context) error { return nil },
+			wantStatus: StatusUp,
+			wantCheck:  StatusUp,
+		},
+		{
+			name:       "when check fails, it should be down",
+			phase:      PhaseServing,
+			check:      func(ctx context.Context) error { return errors.New("connection refused") },
+			wantStatus: StatusDown,
+			wantCheck:  StatusDown,
+		},
+		{
+			name:  "when check is slower than timeout, it should be down",
+			phase: PhaseServing,
+			check: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			wantStatus: StatusDown,
+			wantCheck:  StatusDown,
+		},
+		{
+			name:       "when starting, it should be down",
+			phase:      PhaseStarting,
+			check:      func(ctx context.Context) error { return nil },
+			wantStatus: StatusDown,
+			wantCheck:  StatusUp,
+		},
+		{
+			name:       "when stopping, it should be down",
+			phase:      PhaseStopping,
+			check:      func(ctx context.Context) error { return nil },
+			wantStatus: StatusDown,
+			wantCheck:  StatusUp,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(10 * time.Millisecond)
+			checker.Add("database", tt.check)
+			checker.Add("signing_keys", func(ctx context.Context) error { return nil })
+			checker.SetPhase(tt.phase)
+
+			report := checker.Ready(context.Background())
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantStatus, report.Status)
+			assert.Equal(tt.phase, report.Phase)
+			assert.Equal(tt.wantCheck, report.Checks["database"].Status)
+			assert.Equal(StatusUp, report.Checks["signing_keys"].Status)
+		})
+	}
+}
+
+func TestNewChecker_Starting(t *testing.T) {
+	checker := NewChecker(time.Second)
+
+	assert.Equal(t, PhaseStarting, checker.Phase())
+	assert.Equal(t, "starting", checker.Phase().String())
+}
@@ -0,0 +1,58 @@
+// Package logging is the structured logging of the service. The logger of a request is carried by its context
+// with the request ID and the user, and every value is redacted before it is written.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type Options struct {
+	// Level is debug, info, warn or error, info when it is empty
+	Level string
+	// Format is FormatJSON or FormatText, FormatJSON when it is empty
+	Format string
+}
+
+// New creates the logger which redacts the phone numbers, the passwords and the tokens.
+func New(w io.Writer, opt *Options) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(opt.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+	handlerOptions := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	}
+
+	if strings.EqualFold(opt.Format, FormatText) {
+		return slog.New(slog.NewTextHandler(w, handlerOptions))
+	}
+	return slog.New(slog.NewJSONHandler(w, handlerOptions))
+}
+
+type loggerContextKey struct{}
+
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the logger of the request, slog.Default when the context has none.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With adds the attributes to the logger of the context, e.g. With(ctx, "user_id", id).
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"userservice/internal/user/entity"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are never written, the keys are compared in lower case without "_" and "-"
+var sensitiveKeys = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+	"apikey":        true,
+	"key":           true,
+}
+
+// sensitiveKeySuffixes cover e.g. password, new_password, access_token and webhook_secret
+var sensitiveKeySuffixes = []string{"password", "token", "secret"}
+
+var (
+	authorizationPattern = regexp.MustCompile(`(?i)\b(bearer|apikey)\s+[A-Za-z0-9._~+/=-]+`)
+	jwtPattern           = regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]*\.[A-Za-z0-9_-]*`)
+	apiKeyPattern        = regexp.MustCompile(`\b(usk_[0-9a-f]+)_[0-9a-f]+`)
+	// the international numbers, and the national numbers starting with 0, e.g. in the database errors
+	phoneNumberPattern = regexp.MustCompile(`\+\d{8,15}\b|\b0\d{9,13}\b`)
+)
+
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	key := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(a.Key))
+	if isSensitiveKey(key) {
+		return slog.String(a.Key, redacted)
+	}
+
+	value := a.Value.Resolve()
+	switch {
+	case strings.Contains(key, "phone") && value.Kind() == slog.KindString:
+		return slog.String(a.Key, entity.MaskPhoneNumber(value.String()))
+	case value.Kind() == slog.KindString:
+		return slog.String(a.Key, Redact(value.String()))
+	case value.Kind() == slog.KindAny:
+		// e.g. the errors, their messages may contain the values of the request
+		if err, ok := value.Any().(error); ok {
+			return slog.String(a.Key, Redact(err.Error()))
+		}
+	}
+	return a
+}
+
+func isSensitiveKey(key string) bool {
+	if sensitiveKeys[key] {
+		return true
+	}
+	for _, suffix := range sensitiveKeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact masks the phone numbers and removes the tokens and the API keys found in the text.
+func Redact(text string) string {
+	text = authorizationPattern.ReplaceAllString(text, "$1 "+redacted)
+	text = jwtPattern.ReplaceAllString(text, redacted)
+	text = apiKeyPattern.ReplaceAllString(text, "${1}_"+redacted)
+	return phoneNumberPattern.ReplaceAllStringFunc(text, entity.MaskPhoneNumber)
+}
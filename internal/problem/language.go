@@ -0,0 +1,46 @@
+package problem
+
+import (
+	"userservice/generated"
+	customerror "userservice/internal/custom_error"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+var languageMatcher = newLanguageMatcher()
+
+func newLanguageMatcher() language.Matcher {
+	tags := make([]language.Tag, 0, len(customerror.Languages))
+	for _, lang := range customerror.Languages {
+		tags = append(tags, language.Make(lang))
+	}
+	return language.NewMatcher(tags)
+}
+
+// RequestLanguage returns the language of the messages from Accept-Language, English by default.
+func RequestLanguage(c echo.Context) string {
+	_, index := language.MatchStrings(languageMatcher, c.Request().Header.Get("Accept-Language"))
+	return customerror.Languages[index]
+}
+
+// ValidationItems renders the field errors in the language of the request.
+func ValidationItems(c echo.Context, err *customerror.ValidationError) []generated.ErrorResponseItem {
+	language := RequestLanguage(c)
+	c.Response().Header().Set("Content-Language", language)
+
+	var items []generated.ErrorResponseItem
+	for _, fieldError := range err.Errors() {
+		item := generated.ErrorResponseItem{
+			Name:   fieldError.Field,
+			Reason: fieldError.Message(language),
+			Code:   optionalString(fieldError.Code),
+		}
+		if len(fieldError.Params) > 0 {
+			params := map[string]interface{}(fieldError.Params)
+			item.Params = &params
+		}
+		items = append(items, item)
+	}
+	return items
+}
@@ -0,0 +1,146 @@
+// Package problem writes the HTTP error responses as RFC 7807 problem details
+// or as the ErrorResponse of the clients which do not accept application/problem+json.
+package problem
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"userservice/generated"
+
+	"github.com/labstack/echo/v4"
+)
+
+const ContentType = "application/problem+json"
+
+// the codes are part of the API, they must not be renamed
+const (
+	CodeValidationFailed   = "validation_failed"
+	CodeInvalidRequestBody = "invalid_request_body"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeNotFound           = "not_found"
+	CodeDuplicateResource  = "duplicate_resource"
+	CodeInternalError      = "internal_error"
+)
+
+type definition struct {
+	status int
+	title  string
+	// legacyType and legacyName are the type and the message name of ErrorResponse
+	legacyType string
+	legacyName string
+}
+
+var definitions = map[string]definition{
+	CodeValidationFailed:   {http.StatusBadRequest, "Validation failed", "ValidationError", ""},
+	CodeInvalidRequestBody: {http.StatusBadRequest, "Invalid request body", "RequestBodyError", "request body"},
+	CodeUnauthorized:       {http.StatusUnauthorized, "Unauthorized", "Unauthorized", "authorization"},
+	CodeForbidden:          {http.StatusForbidden, "Forbidden", "Forbidden", "authorization"},
+	CodeNotFound:           {http.StatusNotFound, "Resource not found", "RecordNotFound", "data"},
+	CodeDuplicateResource:  {http.StatusConflict, "Duplicate resource", "DuplicateResource", "resource"},
+	CodeInternalError:      {http.StatusInternalServerError, "Internal server error", "InternalServerError", "UnexpectedError"},
+}
+
+type Problem struct {
+	Code string
+	// Status replaces the status of the code when it is set
+	Status int
+	Detail string
+	// Errors are the invalid fields of CodeValidationFailed
+	Errors []generated.ErrorResponseItem
+}
+
+func New(code, detail string) *Problem {
+	return &Problem{
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+func Validation(errors []generated.ErrorResponseItem) *Problem {
+	return &Problem{
+		Code:   CodeValidationFailed,
+		Detail: "The request has invalid fields.",
+		Errors: errors,
+	}
+}
+
+// Write sends the problem with the status of its code.
+func Write(c echo.Context, p *Problem) error {
+	def := definitions[p.Code]
+	if p.Status != 0 {
+		def.status = p.Status
+	}
+	if !acceptsProblem(c.Request()) {
+		return c.JSON(def.status, p.errorResponse(def))
+	}
+
+	body, err := json.Marshal(generated.Problem{
+		Type:      "urn:userservice:problem:" + p.Code,
+		Title:     def.title,
+		Status:    def.status,
+		Code:      p.Code,
+		Detail:    optionalString(p.Detail),
+		Instance:  optionalString(c.Request().URL.Path),
+		RequestId: optionalString(RequestID(c)),
+		Errors:    optionalItems(p.Errors),
+	})
+	if err != nil {
+		return err
+	}
+	return c.Blob(def.status, ContentType, body)
+}
+
+// RequestID returns the X-Request-Id of the response, it is set by the echo RequestID middleware.
+func RequestID(c echo.Context) string {
+	if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
+
+func (p *Problem) errorResponse(def definition) generated.ErrorResponse {
+	if len(p.Errors) > 0 {
+		return generated.ErrorResponse{
+			Type:     def.legacyType,
+			Messages: p.Errors,
+		}
+	}
+	return generated.ErrorResponse{
+		Type: def.legacyType,
+		Messages: []generated.ErrorResponseItem{
+			{
+				Name:   def.legacyName,
+				Reason: p.Detail,
+			},
+		},
+	}
+}
+
+// acceptsProblem ignores the quality values, problem+json is sent when it is accepted at all.
+func acceptsProblem(req *http.Request) bool {
+	for _, accept := range strings.Split(req.Header.Get(echo.HeaderAccept), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err == nil && mediaType == ContentType {
+			return true
+		}
+	}
+	return false
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func optionalItems(items []generated.ErrorResponseItem) *[]generated.ErrorResponseItem {
+	if len(items) == 0 {
+		return nil
+	}
+	return &items
+}
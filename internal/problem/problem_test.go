@@ -0,0 +1,100 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/generated"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name            string
+		accept          string
+		problem         *Problem
+		wantCode        int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "when problem json is accepted, it should write problem",
+			accept:          "application/json, application/problem+json;q=0.9",
+			problem:         New(CodeNotFound, "The resource is not found."),
+			wantCode:        http.StatusNotFound,
+			wantContentType: ContentType,
+			wantBody:        `{"type":"urn:userservice:problem:not_found","title":"Resource not found","status":404,"code":"not_found","detail":"The resource is not found.","instance":"/api/v1/users/me","requestId":"request-id"}`,
+		},
+		{
+			name:            "when validation problem json is accepted, it should write the fields",
+			accept:          ContentType,
+			problem:         Validation([]generated.ErrorResponseItem{{Name: "fullName", Reason: "is required"}}),
+			wantCode:        http.StatusBadRequest,
+			wantContentType: ContentType,
+			wantBody:        `{"type":"urn:userservice:problem:validation_failed","title":"Validation failed","status":400,"code":"validation_failed","detail":"The request has invalid fields.","instance":"/api/v1/users/me","requestId":"request-id","errors":[{"name":"fullName","reason":"is required"}]}`,
+		},
+		{
+			name:            "when problem json is not accepted, it should write error response",
+			problem:         New(CodeInternalError, "An unexpected error occurred."),
+			wantCode:        http.StatusInternalServerError,
+			wantContentType: echo.MIMEApplicationJSON,
+			wantBody:        `{"type":"InternalServerError","messages":[{"name":"UnexpectedError","reason":"An unexpected error occurred."}]}`,
+		},
+		{
+			name:            "when validation problem json is not accepted, it should write the fields as messages",
+			accept:          echo.MIMEApplicationJSON,
+			problem:         Validation([]generated.ErrorResponseItem{{Name: "fullName", Reason: "is required"}}),
+			wantCode:        http.StatusBadRequest,
+			wantContentType: echo.MIMEApplicationJSON,
+			wantBody:        `{"type":"ValidationError","messages":[{"name":"fullName","reason":"is required"}]}`,
+		},
+		{
+			name:            "when status is set, it should replace the status of the code",
+			accept:          ContentType,
+			problem:         &Problem{Code: CodeInvalidRequestBody, Status: http.StatusUnsupportedMediaType},
+			wantCode:        http.StatusUnsupportedMediaType,
+			wantContentType: ContentType,
+			wantBody:        `{"type":"urn:userservice:problem:invalid_request_body","title":"Invalid request body","status":415,"code":"invalid_request_body","instance":"/api/v1/users/me","requestId":"request-id"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+			req.Header.Set(echo.HeaderAccept, tt.accept)
+			rec := httptest.NewRecorder()
+			rec.Header().Set(echo.HeaderXRequestID, "request-id")
+			ctx := e.NewContext(req, rec)
+
+			err := Write(ctx, tt.problem)
+
+			assert := assert.New(t)
+			assert.NoError(err)
+			assert.Equal(tt.wantCode, rec.Code)
+			assert.Contains(rec.Header().Get(echo.HeaderContentType), tt.wantContentType)
+			assert.JSONEq(tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestWrite_ProblemDecodes(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	req.Header.Set(echo.HeaderAccept, ContentType)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	err := Write(ctx, New(CodeDuplicateResource, "duplicate"))
+
+	var response generated.Problem
+	assert := assert.New(t)
+	assert.NoError(err)
+	assert.NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(CodeDuplicateResource, response.Code)
+	assert.Equal(http.StatusConflict, response.Status)
+	assert.Nil(response.RequestId)
+}
@@ -0,0 +1,160 @@
+// Package tlsconfig serves TLS from certificate files which are reloaded when they change,
+// e.g. when they are renewed by cert-manager, with the optional verification of the client certificates.
+package tlsconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"userservice/internal/logging"
+)
+
+type Options struct {
+	CertPath string
+	KeyPath  string
+	// ClientCAPath verifies the client certificates when it is set, the clients without certificate
+	// are still accepted unless RequireClientCert is set
+	ClientCAPath      string
+	RequireClientCert bool
+}
+
+// Reloader keeps the certificate and the client CA of the files, the handshakes use the latest valid ones.
+type Reloader struct {
+	opt *Options
+
+	mu          sync.RWMutex
+	files       [][]byte
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+}
+
+// NewReloader fails when the files cannot be loaded.
+func NewReloader(opt *Options) (*Reloader, error) {
+	if opt.CertPath == "" || opt.KeyPath == "" {
+		return nil, errors.New("the certificate and the key must be set")
+	}
+	if opt.RequireClientCert && opt.ClientCAPath == "" {
+		return nil, errors.New("the client CA must be set to require the client certificates")
+	}
+
+	r := &Reloader{opt: opt}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload loads the files when they changed, the previous certificate is kept when they are not valid.
+func (r *Reloader) Reload() (reloaded bool, err error) {
+	paths := []string{r.opt.CertPath, r.opt.KeyPath}
+	if r.opt.ClientCAPath != "" {
+		paths = append(paths, r.opt.ClientCAPath)
+	}
+	files := make([][]byte, len(paths))
+	for i, path := range paths {
+		if files[i], err = os.ReadFile(path); err != nil {
+			return false, err
+		}
+	}
+
+	r.mu.RLock()
+	changed := !equalFiles(r.files, files)
+	r.mu.RUnlock()
+	if !changed {
+		return false, nil
+	}
+
+	certificate, err := tls.X509KeyPair(files[0], files[1])
+	if err != nil {
+		return false, fmt.Errorf("failed to load certificate: %w", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.opt.ClientCAPath != "" {
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(files[2]) {
+			return false, errors.New("failed to load client CA: no certificate found")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.files = files
+	r.certificate = &certificate
+	r.clientCAs = clientCAs
+	return true, nil
+}
+
+// Run reloads the files on every interval until the context is done.
+func (r *Reloader) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		reloaded, err := r.Reload()
+		if err != nil {
+			logging.FromContext(ctx).Error("failed to reload tls certificate", "error", err)
+		} else if reloaded {
+			logging.FromContext(ctx).Info("tls certificate reloaded", "not_after", r.currentCertificate().Leaf.NotAfter)
+		}
+	}
+}
+
+// TLSConfig is the config of the server, every handshake gets the latest certificate and client CA.
+func (r *Reloader) TLSConfig() *tls.Config {
+	clientAuth := tls.NoClientCert
+	switch {
+	case r.opt.RequireClientCert:
+		clientAuth = tls.RequireAndVerifyClientCert
+	case r.opt.ClientCAPath != "":
+		clientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// http.Server.ServeTLS needs a certificate in the config, the handshakes use GetConfigForClient
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return r.currentCertificate(), nil
+		},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.certificate},
+				ClientAuth:   clientAuth,
+				ClientCAs:    r.clientCAs,
+				NextProtos:   []string{"h2", "http/1.1"},
+			}, nil
+		},
+	}
+}
+
+func (r *Reloader) currentCertificate() *tls.Certificate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.certificate
+}
+
+func equalFiles(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,222 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCertificate creates a certificate signed by the parent, or a self-signed CA when the parent is nil.
+func newTestCertificate(t *testing.T, commonName string, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signerCert, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeTestFiles(t *testing.T, dir string, server, ca *testCertificate) *Options {
+	opt := &Options{
+		CertPath:     filepath.Join(dir, "tls.crt"),
+		KeyPath:      filepath.Join(dir, "tls.key"),
+		ClientCAPath: filepath.Join(dir, "ca.crt"),
+	}
+	require.NoError(t, os.WriteFile(opt.CertPath, server.certPEM, 0o600))
+	require.NoError(t, os.WriteFile(opt.KeyPath, server.keyPEM, 0o600))
+	require.NoError(t, os.WriteFile(opt.ClientCAPath, ca.certPEM, 0o600))
+	return opt
+}
+
+func TestNewReloader(t *testing.T) {
+	ca := newTestCertificate(t, "test-ca", nil)
+	server := newTestCertificate(t, "localhost", ca)
+	opt := writeTestFiles(t, t.TempDir(), server, ca)
+
+	tests := []struct {
+		name    string
+		opt     *Options
+		wantErr string
+	}{
+		{
+			name: "when files are valid, it should load them",
+			opt:  opt,
+		},
+		{
+			name:    "when key is missing, it should return error",
+			opt:     &Options{CertPath: opt.CertPath},
+			wantErr: "the certificate and the key must be set",
+		},
+		{
+			name:    "when client certificate is required without CA, it should return error",
+			opt:     &Options{CertPath: opt.CertPath, KeyPath: opt.KeyPath, RequireClientCert: true},
+			wantErr: "the client CA must be set",
+		},
+		{
+			name:    "when key does not match, it should return error",
+			opt:     &Options{CertPath: opt.CertPath, KeyPath: opt.ClientCAPath},
+			wantErr: "failed to load certificate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReloader(tt.opt)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReloader_Reload(t *testing.T) {
+	ca := newTestCertificate(t, "test-ca", nil)
+	server := newTestCertificate(t, "localhost", ca)
+	opt := writeTestFiles(t, t.TempDir(), server, ca)
+	reloader, err := NewReloader(opt)
+	require.NoError(t, err)
+	assert := assert.New(t)
+
+	reloaded, err := reloader.Reload()
+	assert.NoError(err)
+	assert.False(reloaded)
+
+	renewed := newTestCertificate(t, "localhost", ca)
+	require.NoError(t, os.WriteFile(opt.CertPath, renewed.certPEM, 0o600))
+	require.NoError(t, os.WriteFile(opt.KeyPath, renewed.keyPEM, 0o600))
+	reloaded, err = reloader.Reload()
+	assert.NoError(err)
+	assert.True(reloaded)
+	assert.Equal(renewed.cert.SerialNumber, reloader.currentCertificate().Leaf.SerialNumber)
+
+	// e.g. the key is written after the certificate
+	require.NoError(t, os.WriteFile(opt.KeyPath, server.keyPEM, 0o600))
+	_, err = reloader.Reload()
+	assert.Error(err)
+	assert.Equal(renewed.cert.SerialNumber, reloader.currentCertificate().Leaf.SerialNumber)
+}
+
+func TestReloader_TLSConfig(t *testing.T) {
+	ca := newTestCertificate(t, "test-ca", nil)
+	server := newTestCertificate(t, "localhost", ca)
+	client := newTestCertificate(t, "order-service", ca)
+	untrustedClient := newTestCertificate(t, "order-service", newTestCertificate(t, "other-ca", nil))
+
+	tests := []struct {
+		name              string
+		requireClientCert bool
+		clientCert        *testCertificate
+		wantErr           bool
+		wantSubject       string
+	}{
+		{
+			name:        "when client certificate is signed by the CA, it should be verified",
+			clientCert:  client,
+			wantSubject: "order-service",
+		},
+		{
+			name: "when client certificate is not required, it should accept the client without certificate",
+		},
+		{
+			name:              "when client certificate is required, it should reject the client without certificate",
+			requireClientCert: true,
+			wantErr:           true,
+		},
+		{
+			// the client does not send a certificate whose issuer is not requested by the server
+			name:              "when client certificate is not signed by the CA, it should reject the client",
+			requireClientCert: true,
+			clientCert:        untrustedClient,
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := writeTestFiles(t, t.TempDir(), server, ca)
+			opt.RequireClientCert = tt.requireClientCert
+			reloader, err := NewReloader(opt)
+			require.NoError(t, err)
+
+			httpServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if len(r.TLS.VerifiedChains) > 0 {
+					w.Write([]byte(r.TLS.VerifiedChains[0][0].Subject.CommonName))
+				}
+			}))
+			httpServer.TLS = reloader.TLSConfig()
+			httpServer.StartTLS()
+			defer httpServer.Close()
+
+			rootCAs := x509.NewCertPool()
+			rootCAs.AddCert(ca.cert)
+			clientTLS := &tls.Config{RootCAs: rootCAs, ServerName: "localhost"}
+			if tt.clientCert != nil {
+				certificate, err := tls.X509KeyPair(tt.clientCert.certPEM, tt.clientCert.keyPEM)
+				require.NoError(t, err)
+				clientTLS.Certificates = []tls.Certificate{certificate}
+			}
+			httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS}}
+
+			resp, err := httpClient.Get(httpServer.URL)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSubject, string(body))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Package tracing is the OpenTelemetry tracing of the service, the spans are exported to an OTLP collector
+// and the trace context of the requests follows the W3C Trace Context.
+package tracing
+
+import (
+	"context"
+
+	"userservice/internal/logging"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "userservice"
+
+type Options struct {
+	// ServiceName is the service.name of the spans
+	ServiceName string
+	// Endpoint is the host:port of the OTLP gRPC collector, e.g. localhost:4317,
+	// the spans are not exported when it is empty
+	Endpoint string
+	// Insecure disables the TLS to the collector, e.g. for a local collector
+	Insecure bool
+	// Exporter replaces the OTLP exporter, e.g. tracetest.NewInMemoryExporter in the tests
+	Exporter sdktrace.SpanExporter
+}
+
+// Setup sets the global tracer provider and the W3C trace context propagator,
+// the provider must be shut down to export the remaining spans.
+func Setup(ctx context.Context, opt *Options) (*sdktrace.TracerProvider, error) {
+	exporter := opt.Exporter
+	if exporter == nil && opt.Endpoint != "" {
+		clientOptions := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opt.Endpoint)}
+		if opt.Insecure {
+			clientOptions = append(clientOptions, otlptracegrpc.WithInsecure())
+		}
+		var err error
+		exporter, err = otlptracegrpc.New(ctx, clientOptions...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	providerOptions := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", opt.ServiceName))),
+	}
+	if exporter != nil {
+		providerOptions = append(providerOptions, sdktrace.WithBatcher(exporter))
+	}
+	provider := sdktrace.NewTracerProvider(providerOptions...)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider, nil
+}
+
+// Start starts a span of the global tracer provider, it is a no-op until Setup is called.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End records the error, if any, and ends the span, e.g. defer func() { tracing.End(span, err) }().
+// The error message is redacted as in the logs.
+func End(span trace.Span, err error) {
+	if err != nil {
+		message := logging.Redact(err.Error())
+		span.AddEvent("exception", trace.WithAttributes(attribute.String("exception.message", message)))
+		span.SetStatus(codes.Error, message)
+	}
+	span.End()
+}
@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEnd(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider, err := Setup(context.Background(), &Options{
+		ServiceName: "userservice",
+		Exporter:    exporter,
+	})
+	require.NoError(t, err)
+
+	ctx, parent := Start(context.Background(), "UserUsecase.GenerateUserToken")
+	_, child := Start(ctx, "UserDB.GetByPhoneNumber")
+	End(child, errors.New("user +6281234567890 not found"))
+	End(parent, nil)
+	require.NoError(t, provider.ForceFlush(context.Background()))
+
+	spans := exporter.GetSpans()
+	assert := assert.New(t)
+	require.Len(t, spans, 2)
+	assert.Equal("UserDB.GetByPhoneNumber", spans[0].Name)
+	assert.Equal(spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(codes.Error, spans[0].Status.Code)
+	// the error is redacted as in the logs
+	assert.Equal("user +6281******890 not found", spans[0].Status.Description)
+	assert.Equal(codes.Unset, spans[1].Status.Code)
+}
@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	customerror "userservice/internal/custom_error"
+)
+
+const (
+	// APIKeyScopeUsersRead allows reading the current user
+	APIKeyScopeUsersRead = "users:read"
+	// APIKeyScopeUsersWrite allows updating the profile of the current user,
+	// the password, the email and the API keys can only be changed with the user token
+	APIKeyScopeUsersWrite = "users:write"
+
+	// APIKeyAuthorizationScheme is the scheme of the Authorization header, e.g. "ApiKey usk_..."
+	APIKeyAuthorizationScheme = "ApiKey"
+
+	apiKeyPrefix        = "usk_"
+	apiKeyLookupBytes   = 6
+	apiKeySecretBytes   = 32
+	apiKeyNameMaxLength = 100
+)
+
+// ErrInvalidAPIKey is returned when the key is not found, revoked or expired.
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// APIKeyScopes are the scopes which can be granted to an API key.
+var APIKeyScopes = map[string]bool{
+	APIKeyScopeUsersRead:  true,
+	APIKeyScopeUsersWrite: true,
+}
+
+// APIKey is a long-lived credential of a user for the scripts,
+// it is formatted as "usk_{lookup}_{secret}" and only its hash is stored.
+type APIKey struct {
+	ID     string
+	UserID string
+	Name   string
+	// Prefix is "usk_{lookup}", it is shown to recognize the key and used to find it
+	Prefix string
+	// Hash is the hex SHA-256 of the key, a slow hash is not needed because the key is random
+	Hash       string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	// Key is only set when the key is created, it cannot be shown anymore afterward
+	Key string
+}
+
+// NewAPIKey generates the key, expiresAt is optional.
+func NewAPIKey(userID, name string, scopes []string, expiresAt *time.Time, now time.Time) (*APIKey, error) {
+	validationError := customerror.NewValidationError()
+	name = strings.TrimSpace(name)
+	if nameLength := utf8.RuneCountInString(name); nameLength < 1 || nameLength > apiKeyNameMaxLength {
+		validationError.AddError("name", customerror.CodeLengthBetween, customerror.Params{"min": 1, "max": apiKeyNameMaxLength})
+	}
+	validationError.Merge(validateAPIKeyScopes(scopes))
+	if expiresAt != nil && !expiresAt.After(now) {
+		validationError.AddError("expiresAt", customerror.CodeFutureTime, nil)
+	}
+	if validationError.HasError() {
+		return nil, validationError
+	}
+
+	prefix := apiKeyPrefix + randomHex(apiKeyLookupBytes)
+	key := prefix + "_" + randomHex(apiKeySecretBytes)
+	return &APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		Hash:      hashAPIKey(key),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		Key:       key,
+	}, nil
+}
+
+// ParseAPIKeyPrefix returns the prefix to find the key, false when the key is not formatted as an API key.
+func ParseAPIKeyPrefix(key string) (string, bool) {
+	lookup, ok := strings.CutPrefix(key, apiKeyPrefix)
+	if !ok {
+		return "", false
+	}
+	lookup, secret, ok := strings.Cut(lookup, "_")
+	if !ok || len(lookup) != apiKeyLookupBytes*2 || len(secret) != apiKeySecretBytes*2 {
+		return "", false
+	}
+	return apiKeyPrefix + lookup, true
+}
+
+// Verify returns ErrInvalidAPIKey when the key does not match the hash or cannot be used anymore.
+func (ak *APIKey) Verify(key string, now time.Time) error {
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(key)), []byte(ak.Hash)) != 1 {
+		return ErrInvalidAPIKey
+	}
+	if ak.RevokedAt != nil || (ak.ExpiresAt != nil && !ak.ExpiresAt.After(now)) {
+		return ErrInvalidAPIKey
+	}
+	return nil
+}
+
+func validateAPIKeyScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return customerror.NewValidationErrorWithCode("scopes", customerror.CodeAtLeastOneScope, nil)
+	}
+	for _, scope := range scopes {
+		if !APIKeyScopes[scope] {
+			return customerror.NewValidationErrorWithCode("scopes", customerror.CodeUnsupportedScope, customerror.Params{"scope": scope})
+		}
+	}
+	return nil
+}
+
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func randomHex(size int) string {
+	value := make([]byte, size)
+	if _, err := rand.Read(value); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(value)
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"bytes"
+
+	customerror "userservice/internal/custom_error"
+)
+
+const (
+	// AvatarMaxDimension limits the width and height of the uploaded avatar
+	// so a small file cannot be decoded into a huge bitmap.
+	AvatarMaxDimension = 4096
+)
+
+var (
+	jpegSignature = []byte{0xFF, 0xD8, 0xFF}
+	pngSignature  = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}
+	riffSignature = []byte("RIFF")
+	webpSignature = []byte("WEBP")
+)
+
+// Image is an encoded image, the content type is detected from its content.
+type Image struct {
+	ContentType string
+	Data        []byte
+}
+
+// Avatar is the stored avatar of the user with its thumbnails.
+type Avatar struct {
+	URL        string
+	Thumbnails []Thumbnail
+}
+
+type Thumbnail struct {
+	Size int
+	URL  string
+}
+
+// NewAvatarImage validates the uploaded avatar by its size and magic bytes,
+// the content type declared by the client is never trusted.
+func NewAvatarImage(data []byte, maxSize int) (*Image, error) {
+	if len(data) == 0 {
+		return nil, customerror.NewValidationErrorWithCode("avatar", customerror.CodeEmpty, nil)
+	}
+
+	if err := CheckAvatarSize(int64(len(data)), maxSize); err != nil {
+		return nil, err
+	}
+
+	contentType := detectImageContentType(data)
+	if contentType == "" {
+		return nil, customerror.NewValidationErrorWithCode("avatar", customerror.CodeUnsupportedImage, nil)
+	}
+
+	return &Image{
+		ContentType: contentType,
+		Data:        data,
+	}, nil
+}
+
+// CheckAvatarSize validates the size of the avatar, e.g. the size of the uploaded file before it is read.
+func CheckAvatarSize(size int64, maxSize int) error {
+	if size > int64(maxSize) {
+		return customerror.NewValidationErrorWithCode("avatar", customerror.CodeMaxSize, customerror.Params{"max": maxSize})
+	}
+	return nil
+}
+
+// Extension returns the file extension matching the content type.
+func (image Image) Extension() string {
+	switch image.ContentType {
+	case "image/jpeg":
+		return "jpg"
+	case "image/png":
+		return "png"
+	case "image/webp":
+		return "webp"
+	}
+	return ""
+}
+
+func detectImageContentType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, jpegSignature):
+		return "image/jpeg"
+	case bytes.HasPrefix(data, pngSignature):
+		return "image/png"
+	case len(data) >= 12 && bytes.HasPrefix(data, riffSignature) && bytes.Equal(data[8:12], webpSignature):
+		return "image/webp"
+	}
+	return ""
+}
@@ -0,0 +1,13 @@
+package entity
+
+const (
+	// ServiceClientType is the client type claim of the token issued to other services,
+	// the token can only access the internal API and never act as a user.
+	ServiceClientType = "service"
+	// AdminClientType is the client type claim of the token issued to support tooling,
+	// the token can only access the admin API and never act as a user.
+	AdminClientType = "admin"
+	// MonitoringClientType is the client type claim of the token issued to the Prometheus scraper,
+	// the token can only read the metrics and never act as a user.
+	MonitoringClientType = "monitoring"
+)
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"net/mail"
+	"strings"
+
+	customerror "userservice/internal/custom_error"
+)
+
+const emailMaxLen = 254
+
+// NormalizeEmail validates the email and returns it in lower case,
+// so the same address in different case is treated as the same email.
+func NormalizeEmail(email string) (string, error) {
+	normalizedEmail := strings.ToLower(strings.TrimSpace(email))
+	if len(normalizedEmail) > emailMaxLen {
+		return "", customerror.NewValidationErrorWithCode("email", customerror.CodeMaxLength, customerror.Params{"max": emailMaxLen})
+	}
+
+	// reject display name format like "John <john@example.com>"
+	address, err := mail.ParseAddress(normalizedEmail)
+	if err != nil || address.Address != normalizedEmail {
+		return "", customerror.NewValidationErrorWithCode("email", customerror.CodeInvalidEmail, nil)
+	}
+
+	return normalizedEmail, nil
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UserCreatedEventType        = "UserCreated"
+	UserProfileUpdatedEventType = "UserProfileUpdated"
+	UserLoggedInEventType       = "UserLoggedIn"
+)
+
+// Event is a domain event of a user, it is stored in the outbox together with the state change
+// and published afterward at least once, so the consumers should deduplicate it by the id.
+type Event struct {
+	ID          string
+	Type        string
+	AggregateID string
+	// Payload is the JSON encoded data of the event
+	Payload    []byte
+	OccurredAt time.Time
+	// Attempts is the number of failed publish attempts
+	Attempts int
+}
+
+type userEventPayload struct {
+	ID          string `json:"id"`
+	FullName    string `json:"fullName,omitempty"`
+	PhoneNumber string `json:"phoneNumber,omitempty"`
+}
+
+func NewUserCreatedEvent(user *User) *Event {
+	return newUserEvent(UserCreatedEventType, userEventPayload{
+		ID:          user.ID,
+		FullName:    user.FullName,
+		PhoneNumber: user.PhoneNumber,
+	})
+}
+
+// NewUserProfileUpdatedEvent contains the profile after the update.
+func NewUserProfileUpdatedEvent(user *User) *Event {
+	return newUserEvent(UserProfileUpdatedEventType, userEventPayload{
+		ID:          user.ID,
+		FullName:    user.FullName,
+		PhoneNumber: user.PhoneNumber,
+	})
+}
+
+func NewUserLoggedInEvent(userID string) *Event {
+	return newUserEvent(UserLoggedInEventType, userEventPayload{
+		ID: userID,
+	})
+}
+
+// NewPublicUserEvent applies the privacy settings of the user to the payload of the event,
+// it is the event sent outside of the service, e.g. to the webhooks of the partners.
+func NewPublicUserEvent(event *Event, settings *PrivacySettings) (*Event, error) {
+	var payload userEventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return nil, err
+	}
+
+	publicProfile := NewPublicProfile(&User{
+		ID:          payload.ID,
+		FullName:    payload.FullName,
+		PhoneNumber: payload.PhoneNumber,
+	}, settings)
+	data, err := json.Marshal(userEventPayload{
+		ID:          publicProfile.ID,
+		FullName:    publicProfile.FullName,
+		PhoneNumber: publicProfile.PhoneNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publicEvent := *event
+	publicEvent.Payload = data
+	return &publicEvent, nil
+}
+
+func newUserEvent(eventType string, payload userEventPayload) *Event {
+	data, _ := json.Marshal(payload)
+	return &Event{
+		ID:          uuid.NewString(),
+		Type:        eventType,
+		AggregateID: payload.ID,
+		Payload:     data,
+		OccurredAt:  time.Now(),
+	}
+}
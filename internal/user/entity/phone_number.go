@@ -0,0 +1,178 @@
+package entity
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	customerror "userservice/internal/custom_error"
+)
+
+// national formats (e.g. 0812...) are parsed as numbers of this region
+const defaultPhoneRegion = "ID"
+
+//go:embed phone_metadata.json
+var phoneMetadataJSON []byte
+
+type phoneRegion struct {
+	Region         string   `json:"region"`
+	Name           string   `json:"name"`
+	CountryCode    string   `json:"countryCode"`
+	NationalPrefix string   `json:"nationalPrefix"`
+	MinLength      int      `json:"minLength"`
+	MaxLength      int      `json:"maxLength"`
+	LeadingDigits  []string `json:"leadingDigits"`
+}
+
+var (
+	phoneRegionsByCode   = make(map[string]phoneRegion)
+	phoneRegionsByRegion = make(map[string]phoneRegion)
+)
+
+func init() {
+	var metadata struct {
+		Regions []phoneRegion `json:"regions"`
+	}
+	if err := json.Unmarshal(phoneMetadataJSON, &metadata); err != nil {
+		panic(fmt.Errorf("failed to load phone metadata: %s", err))
+	}
+
+	for _, region := range metadata.Regions {
+		phoneRegionsByCode[region.CountryCode] = region
+		phoneRegionsByRegion[region.Region] = region
+	}
+}
+
+// PhoneNumber is a phone number that already validated against its country rules.
+type PhoneNumber struct {
+	Region         string
+	CountryCode    string
+	NationalNumber string
+}
+
+// ParsePhoneNumber accepts international (+62812..., 0062812...) and
+// national (0812...) formats, separators like space, dash, dot and parentheses are ignored.
+func ParsePhoneNumber(raw string) (*PhoneNumber, error) {
+	number := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, raw)
+
+	international := false
+	if strings.HasPrefix(number, "+") {
+		number = strings.TrimPrefix(number, "+")
+		international = true
+	} else if strings.HasPrefix(number, "00") {
+		number = strings.TrimPrefix(number, "00")
+		international = true
+	}
+
+	if number == "" || !onlyDigits(number) {
+		return nil, customerror.NewValidationErrorWithCode("phoneNumber", customerror.CodeInvalidPhoneNumber, nil)
+	}
+
+	var region phoneRegion
+	if international {
+		var ok bool
+		region, ok = findPhoneRegion(number)
+		if !ok {
+			return nil, customerror.NewValidationErrorWithCode("phoneNumber", customerror.CodeUnsupportedCountryCode, nil)
+		}
+		number = strings.TrimPrefix(number, region.CountryCode)
+	} else {
+		region = phoneRegionsByRegion[defaultPhoneRegion]
+		number = strings.TrimPrefix(number, region.NationalPrefix)
+	}
+
+	phoneNumber := &PhoneNumber{
+		Region:         region.Region,
+		CountryCode:    region.CountryCode,
+		NationalNumber: number,
+	}
+	if err := phoneNumber.validate(region); err != nil {
+		return nil, err
+	}
+
+	return phoneNumber, nil
+}
+
+// NormalizePhoneNumberPrefix converts the beginning of a phone number into the E.164 format
+// so it can be matched against the stored phone numbers, e.g. "0812-34" becomes "+6281234".
+// It returns false when the text does not look like a phone number.
+func NormalizePhoneNumberPrefix(raw string) (string, bool) {
+	number := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, raw)
+
+	switch {
+	case strings.HasPrefix(number, "+"):
+		number = strings.TrimPrefix(number, "+")
+	case strings.HasPrefix(number, "00"):
+		number = strings.TrimPrefix(number, "00")
+	case strings.HasPrefix(number, "0"):
+		region := phoneRegionsByRegion[defaultPhoneRegion]
+		number = region.CountryCode + strings.TrimPrefix(number, region.NationalPrefix)
+	}
+
+	// too short prefix matches almost every user
+	if len(number) < 3 || !onlyDigits(number) {
+		return "", false
+	}
+	return "+" + number, true
+}
+
+// E164 returns the canonical format that used for storing and lookup.
+func (pn PhoneNumber) E164() string {
+	return "+" + pn.CountryCode + pn.NationalNumber
+}
+
+func (pn PhoneNumber) validate(region phoneRegion) error {
+	validationError := customerror.NewValidationError()
+	if len(pn.NationalNumber) < region.MinLength || len(pn.NationalNumber) > region.MaxLength {
+		validationError.AddError("phoneNumber", customerror.CodePhoneNumberLength, customerror.Params{"min": region.MinLength, "max": region.MaxLength, "countryCode": region.CountryCode})
+	}
+
+	validPrefix := false
+	for _, leadingDigit := range region.LeadingDigits {
+		if strings.HasPrefix(pn.NationalNumber, leadingDigit) {
+			validPrefix = true
+			break
+		}
+	}
+	if !validPrefix {
+		validationError.AddError("phoneNumber", customerror.CodePhoneNumberPrefix, customerror.Params{"region": region.Name})
+	}
+
+	if validationError.HasError() {
+		return validationError
+	}
+
+	return nil
+}
+
+// calling codes are prefix free, so the first match is the only match
+func findPhoneRegion(number string) (phoneRegion, bool) {
+	for length := 1; length <= 3 && length <= len(number); length++ {
+		if region, ok := phoneRegionsByCode[number[:length]]; ok {
+			return region, true
+		}
+	}
+	return phoneRegion{}, false
+}
+
+func onlyDigits(s string) bool {
+	for _, char := range s {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
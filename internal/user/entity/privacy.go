@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	maskedPhoneNumberVisiblePrefix = 5
+	maskedPhoneNumberVisibleSuffix = 3
+)
+
+// PrivacySettings controls which fields of the user are shown to other users and services.
+type PrivacySettings struct {
+	UserID          string
+	ShowFullName    bool
+	ShowAvatar      bool
+	ShowPhoneNumber bool
+	UpdatedAt       time.Time
+}
+
+// DefaultPrivacySettings is used when the user never change the settings,
+// the phone number is hidden unless the user choose to show it.
+func DefaultPrivacySettings(userID string) *PrivacySettings {
+	return &PrivacySettings{
+		UserID:       userID,
+		ShowFullName: true,
+		ShowAvatar:   true,
+	}
+}
+
+// PublicProfile is the projection of the user visible to others, hidden field is left empty.
+type PublicProfile struct {
+	ID          string
+	Username    string
+	FullName    string
+	AvatarURL   string
+	PhoneNumber string
+}
+
+// NewPublicProfile applies the privacy settings to the user,
+// the phone number is never shown in full.
+func NewPublicProfile(user *User, settings *PrivacySettings) *PublicProfile {
+	publicProfile := &PublicProfile{
+		ID:       user.ID,
+		Username: user.Username,
+	}
+	if settings.ShowFullName {
+		publicProfile.FullName = user.FullName
+	}
+	if settings.ShowAvatar {
+		publicProfile.AvatarURL = user.AvatarURL
+	}
+	if settings.ShowPhoneNumber {
+		publicProfile.PhoneNumber = MaskPhoneNumber(user.PhoneNumber)
+	}
+	return publicProfile
+}
+
+// MaskPhoneNumber keeps the country code and the last digits, e.g. +628123456789 becomes +6281*****789.
+func MaskPhoneNumber(phoneNumber string) string {
+	if len(phoneNumber) <= maskedPhoneNumberVisiblePrefix+maskedPhoneNumberVisibleSuffix {
+		return strings.Repeat("*", len(phoneNumber))
+	}
+
+	maskedLen := len(phoneNumber) - maskedPhoneNumberVisiblePrefix - maskedPhoneNumberVisibleSuffix
+	return phoneNumber[:maskedPhoneNumberVisiblePrefix] + strings.Repeat("*", maskedLen) + phoneNumber[len(phoneNumber)-maskedPhoneNumberVisibleSuffix:]
+}
@@ -0,0 +1,178 @@
+package entity
+
+import (
+	"net/url"
+	"regexp"
+	"time"
+
+	customerror "userservice/internal/custom_error"
+)
+
+const (
+	avatarURLMaxLen         = 2048
+	dateOfBirthLayout       = "2006-01-02"
+	maxAge                  = 150
+	addressStreetMaxLen     = 255
+	addressCityMaxLen       = 100
+	addressProvinceMaxLen   = 100
+	addressPostalCodeMaxLen = 10
+)
+
+var (
+	localeRegex     = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+	countryRegex    = regexp.MustCompile(`^[A-Z]{2}$`)
+	postalCodeRegex = regexp.MustCompile(`^[A-Za-z0-9 -]+$`)
+)
+
+// Profile is the extended profile of the user, every field is optional.
+type Profile struct {
+	UserID      string
+	AvatarURL   string
+	DateOfBirth *time.Time
+	Locale      string
+	Timezone    string
+	Address     *Address
+	UpdatedAt   time.Time
+}
+
+type Address struct {
+	Street     string
+	City       string
+	Province   string
+	PostalCode string
+	Country    string
+}
+
+// Update only changes the given fields, empty string will remove the field.
+// The address is replaced as a whole.
+func (profile *Profile) Update(avatarURL, dateOfBirth, locale, timezone *string, address *Address) error {
+	validationError := customerror.NewValidationError()
+	if avatarURL == nil && dateOfBirth == nil && locale == nil && timezone == nil && address == nil {
+		for _, key := range []string{"avatarUrl", "dateOfBirth", "locale", "timezone", "address"} {
+			validationError.AddError(key, customerror.CodeAtLeastOneField, nil)
+		}
+		return validationError
+	}
+
+	if avatarURL != nil {
+		profile.AvatarURL = *avatarURL
+		validationError.Merge(profile.validateAvatarURL())
+	}
+
+	if dateOfBirth != nil {
+		validationError.Merge(profile.setDateOfBirth(*dateOfBirth))
+	}
+
+	if locale != nil {
+		profile.Locale = *locale
+		validationError.Merge(profile.validateLocale())
+	}
+
+	if timezone != nil {
+		profile.Timezone = *timezone
+		validationError.Merge(profile.validateTimezone())
+	}
+
+	if address != nil {
+		profile.Address = address
+		validationError.Merge(profile.validateAddress())
+	}
+
+	if validationError.HasError() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (profile Profile) validateAvatarURL() error {
+	if profile.AvatarURL == "" {
+		return nil
+	}
+
+	validationError := customerror.NewValidationError()
+	if len(profile.AvatarURL) > avatarURLMaxLen {
+		validationError.AddError("avatarUrl", customerror.CodeMaxLength, customerror.Params{"max": avatarURLMaxLen})
+	}
+
+	parsedURL, err := url.Parse(profile.AvatarURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		validationError.AddError("avatarUrl", customerror.CodeInvalidURL, nil)
+	}
+
+	if validationError.HasError() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (profile *Profile) setDateOfBirth(dateOfBirth string) error {
+	if dateOfBirth == "" {
+		profile.DateOfBirth = nil
+		return nil
+	}
+
+	parsedDate, err := time.Parse(dateOfBirthLayout, dateOfBirth)
+	if err != nil {
+		return customerror.NewValidationErrorWithCode("dateOfBirth", customerror.CodeInvalidDate, nil)
+	}
+
+	now := time.Now()
+	if parsedDate.After(now) || parsedDate.Before(now.AddDate(-maxAge, 0, 0)) {
+		return customerror.NewValidationErrorWithCode("dateOfBirth", customerror.CodeDateOfBirthRange, customerror.Params{"maxAge": maxAge})
+	}
+
+	profile.DateOfBirth = &parsedDate
+	return nil
+}
+
+func (profile Profile) validateLocale() error {
+	if profile.Locale == "" || localeRegex.MatchString(profile.Locale) {
+		return nil
+	}
+	return customerror.NewValidationErrorWithCode("locale", customerror.CodeInvalidLocale, nil)
+}
+
+func (profile Profile) validateTimezone() error {
+	if profile.Timezone == "" {
+		return nil
+	}
+
+	// Local is accepted by LoadLocation but meaningless for the user
+	if _, err := time.LoadLocation(profile.Timezone); err != nil || profile.Timezone == "Local" {
+		return customerror.NewValidationErrorWithCode("timezone", customerror.CodeInvalidTimezone, nil)
+	}
+	return nil
+}
+
+func (profile Profile) validateAddress() error {
+	validationError := customerror.NewValidationError()
+	address := profile.Address
+
+	if len(address.Street) > addressStreetMaxLen {
+		validationError.AddError("address.street", customerror.CodeMaxLength, customerror.Params{"max": addressStreetMaxLen})
+	}
+
+	if len(address.City) > addressCityMaxLen {
+		validationError.AddError("address.city", customerror.CodeMaxLength, customerror.Params{"max": addressCityMaxLen})
+	}
+
+	if len(address.Province) > addressProvinceMaxLen {
+		validationError.AddError("address.province", customerror.CodeMaxLength, customerror.Params{"max": addressProvinceMaxLen})
+	}
+
+	if address.PostalCode != "" && (len(address.PostalCode) > addressPostalCodeMaxLen || !postalCodeRegex.MatchString(address.PostalCode)) {
+		validationError.AddError("address.postalCode", customerror.CodeInvalidPostalCode, customerror.Params{"max": addressPostalCodeMaxLen})
+	}
+
+	if !countryRegex.MatchString(address.Country) {
+		validationError.AddError("address.country", customerror.CodeInvalidCountry, nil)
+	}
+
+	if validationError.HasError() {
+		return validationError
+	}
+
+	return nil
+}
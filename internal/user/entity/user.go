@@ -1,8 +1,6 @@
 package entity
 
 import (
-	"fmt"
-	"regexp"
 	"time"
 	"unicode"
 
@@ -10,21 +8,24 @@ import (
 )
 
 const (
-	phoneNumberMinLen = 10
-	phoneNumberMaxLen = 13
-	fullNameMinLen    = 3
-	fullNameMaxLen    = 60
-	passwordMinLen    = 6
-	passwordMaxLen    = 64
+	fullNameMinLen = 3
+	fullNameMaxLen = 60
+	passwordMinLen = 6
+	passwordMaxLen = 64
 )
 
 type User struct {
-	ID          string
-	FullName    string
-	PhoneNumber string
-	Password    string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID                string
+	FullName          string
+	PhoneNumber       string
+	Password          string
+	Email             string
+	EmailVerified     bool
+	AvatarURL         string
+	Username          string
+	UsernameChangedAt *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 func NewUser(fullName, phoneNumber, password string) (*User, error) {
@@ -35,7 +36,7 @@ func NewUser(fullName, phoneNumber, password string) (*User, error) {
 	}
 
 	validationError := customerror.NewValidationError()
-	if err := user.validatePhoneNumber(); err != nil {
+	if err := user.normalizePhoneNumber(); err != nil {
 		validationError.Merge(err)
 	}
 
@@ -57,8 +58,8 @@ func NewUser(fullName, phoneNumber, password string) (*User, error) {
 func (user *User) UpdateProfile(fullName, phoneNumber *string) error {
 	validationError := customerror.NewValidationError()
 	if fullName == nil && phoneNumber == nil {
-		validationError.AddError("fullName", "at least 1 field must be present")
-		validationError.AddError("phoneNumber", "at least 1 field must be present")
+		validationError.AddError("fullName", customerror.CodeAtLeastOneField, nil)
+		validationError.AddError("phoneNumber", customerror.CodeAtLeastOneField, nil)
 		return validationError
 	}
 
@@ -71,7 +72,7 @@ func (user *User) UpdateProfile(fullName, phoneNumber *string) error {
 
 	if phoneNumber != nil {
 		user.PhoneNumber = *phoneNumber
-		if err := user.validatePhoneNumber(); err != nil {
+		if err := user.normalizePhoneNumber(); err != nil {
 			validationError.Merge(err)
 		}
 	}
@@ -83,30 +84,38 @@ func (user *User) UpdateProfile(fullName, phoneNumber *string) error {
 	return nil
 }
 
-func (user User) validatePhoneNumber() error {
-	validationError := customerror.NewValidationError()
-	if len(user.PhoneNumber) < phoneNumberMinLen || len(user.PhoneNumber) > phoneNumberMaxLen {
-		validationError.AddError("phoneNumber", fmt.Sprintf("must be between %d and %d characters in length", phoneNumberMinLen, phoneNumberMaxLen))
+// ChangeEmail replaces the email with its normalized form, a changed email need to be verified again.
+func (user *User) ChangeEmail(email string) error {
+	normalizedEmail, err := NormalizeEmail(email)
+	if err != nil {
+		return err
 	}
 
-	// check that have prefix +62 and only containt 0-9 after that
-	phoneNumberRegex := regexp.MustCompile(`^\+62[0-9]`)
-	match := phoneNumberRegex.MatchString(user.PhoneNumber)
-	if !match {
-		validationError.AddError("phoneNumber", "must start with '+62' and only containt number")
-	}
+	user.Email = normalizedEmail
+	user.EmailVerified = false
+	return nil
+}
 
-	if validationError.HasError() {
-		return validationError
+func (user *User) ChangePassword(password string) error {
+	user.Password = password
+	return user.validatePassword()
+}
+
+// normalizePhoneNumber validates the phone number and replaces it with the E.164 format
+func (user *User) normalizePhoneNumber() error {
+	phoneNumber, err := ParsePhoneNumber(user.PhoneNumber)
+	if err != nil {
+		return err
 	}
 
+	user.PhoneNumber = phoneNumber.E164()
 	return nil
 }
 
 func (user User) validateFullName() error {
 	validationError := customerror.NewValidationError()
 	if len(user.FullName) < fullNameMinLen || len(user.FullName) > fullNameMaxLen {
-		validationError.AddError("fullName", fmt.Sprintf("must be between %d and %d characters in length", fullNameMinLen, fullNameMaxLen))
+		validationError.AddError("fullName", customerror.CodeLengthBetween, customerror.Params{"min": fullNameMinLen, "max": fullNameMaxLen})
 	}
 
 	if validationError.HasError() {
@@ -119,13 +128,13 @@ func (user User) validateFullName() error {
 func (user User) validatePassword() error {
 	validationError := customerror.NewValidationError()
 	if len(user.Password) < passwordMinLen || len(user.Password) > passwordMaxLen {
-		validationError.AddError("password", fmt.Sprintf("must be between %d and %d characters in length", passwordMinLen, passwordMaxLen))
+		validationError.AddError("password", customerror.CodeLengthBetween, customerror.Params{"min": passwordMinLen, "max": passwordMaxLen})
 	}
 
 	// Check for at least 1 capital letter, 1 number, and 1 special character
 
 	if !strongPassword(user.Password) {
-		validationError.AddError("password", "containing at least 1 capital characters AND 1 number AND 1 special (nonalpha-numeric) characters")
+		validationError.AddError("password", customerror.CodePasswordComplexity, nil)
 	}
 
 	if validationError.HasError() {
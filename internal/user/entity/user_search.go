@@ -0,0 +1,181 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	customerror "userservice/internal/custom_error"
+)
+
+const (
+	UserSearchDefaultLimit = 20
+	UserSearchMaxLimit     = 100
+
+	userSearchMinLength = 2
+	userSearchMaxLength = 64
+)
+
+// UserSearchQuery searches the users whose full name is similar to the text
+// or whose phone number starts with the text, ordered by the score then the id.
+type UserSearchQuery struct {
+	Text string
+	// PhoneNumberPrefix is empty when the text does not look like a phone number
+	PhoneNumberPrefix string
+	After             *UserSearchCursor
+	Limit             int
+}
+
+// UserSearchCursor is the position of the last result of a page, the next page starts after it.
+type UserSearchCursor struct {
+	Score float64 `json:"score"`
+	ID    string  `json:"id"`
+}
+
+// UserSearchResult is a found user with its score between 0 and 1,
+// the highlighted fields are HTML escaped and the matched parts are wrapped with <em>.
+type UserSearchResult struct {
+	User                   *User
+	Score                  float64
+	HighlightedFullName    string
+	HighlightedPhoneNumber string
+}
+
+func NewUserSearchQuery(text string, cursor string, limit int) (*UserSearchQuery, error) {
+	validationError := customerror.NewValidationError()
+	text = strings.Join(strings.Fields(text), " ")
+	if length := utf8.RuneCountInString(text); length < userSearchMinLength || length > userSearchMaxLength {
+		validationError.AddError("q", customerror.CodeLengthBetween, customerror.Params{"min": userSearchMinLength, "max": userSearchMaxLength})
+	}
+
+	if limit == 0 {
+		limit = UserSearchDefaultLimit
+	}
+	if limit < 1 || limit > UserSearchMaxLimit {
+		validationError.AddError("limit", customerror.CodeRangeBetween, customerror.Params{"min": 1, "max": UserSearchMaxLimit})
+	}
+
+	var after *UserSearchCursor
+	if cursor != "" {
+		var err error
+		after, err = DecodeUserSearchCursor(cursor)
+		if err != nil {
+			validationError.AddError("cursor", customerror.CodeInvalid, nil)
+		}
+	}
+
+	if validationError.HasError() {
+		return nil, validationError
+	}
+
+	phoneNumberPrefix, _ := NormalizePhoneNumberPrefix(text)
+	return &UserSearchQuery{
+		Text:              text,
+		PhoneNumberPrefix: phoneNumberPrefix,
+		After:             after,
+		Limit:             limit,
+	}, nil
+}
+
+// Encode returns the opaque cursor given to the client.
+func (c UserSearchCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeUserSearchCursor(cursor string) (*UserSearchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded UserSearchCursor
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	if decoded.ID == "" {
+		return nil, fmt.Errorf("cursor without id")
+	}
+	return &decoded, nil
+}
+
+// NewUserSearchResult highlights the parts of the user matched by the query.
+func NewUserSearchResult(user *User, score float64, query *UserSearchQuery) *UserSearchResult {
+	result := &UserSearchResult{
+		User:                   user,
+		Score:                  score,
+		HighlightedFullName:    highlightWords(user.FullName, strings.Fields(query.Text)),
+		HighlightedPhoneNumber: html.EscapeString(user.PhoneNumber),
+	}
+	if query.PhoneNumberPrefix != "" && strings.HasPrefix(user.PhoneNumber, query.PhoneNumberPrefix) {
+		result.HighlightedPhoneNumber = "<em>" + html.EscapeString(query.PhoneNumberPrefix) + "</em>" +
+			html.EscapeString(strings.TrimPrefix(user.PhoneNumber, query.PhoneNumberPrefix))
+	}
+	return result
+}
+
+func (r UserSearchResult) Cursor() UserSearchCursor {
+	return UserSearchCursor{
+		Score: r.Score,
+		ID:    r.User.ID,
+	}
+}
+
+// highlightWords wraps the words of the text which start with or are a few typos away
+// from any of the query words, so "John" of "John Doe" is highlighted for the query "jon".
+func highlightWords(text string, queryWords []string) string {
+	words := strings.Fields(text)
+	for i, word := range words {
+		highlighted := false
+		for _, queryWord := range queryWords {
+			lowerWord, lowerQueryWord := strings.ToLower(word), strings.ToLower(queryWord)
+			if strings.HasPrefix(lowerWord, lowerQueryWord) || editDistance(lowerWord, lowerQueryWord) <= maxTypos(lowerQueryWord) {
+				highlighted = true
+				break
+			}
+		}
+
+		words[i] = html.EscapeString(word)
+		if highlighted {
+			words[i] = "<em>" + words[i] + "</em>"
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// maxTypos allows no typo for very short words, 1 typo for short words and 2 typos for the longer ones.
+func maxTypos(word string) int {
+	switch length := utf8.RuneCountInString(word); {
+	case length <= 2:
+		return 0
+	case length <= 5:
+		return 1
+	}
+	return 2
+}
+
+// editDistance is the Levenshtein distance between two words.
+func editDistance(a, b string) int {
+	runesA, runesB := []rune(a), []rune(b)
+	previous := make([]int, len(runesB)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(runesA); i++ {
+		current := make([]int, len(runesB)+1)
+		current[0] = i
+		for j := 1; j <= len(runesB); j++ {
+			cost := 1
+			if runesA[i-1] == runesB[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(runesB)]
+}
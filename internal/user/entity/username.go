@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	customerror "userservice/internal/custom_error"
+)
+
+const (
+	usernameMinLen = 3
+	usernameMaxLen = 30
+)
+
+var (
+	usernameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+	// reservedUsernames could be confused with the service or a path of the API
+	reservedUsernames = map[string]bool{
+		"admin":         true,
+		"administrator": true,
+		"api":           true,
+		"help":          true,
+		"me":            true,
+		"moderator":     true,
+		"null":          true,
+		"root":          true,
+		"security":      true,
+		"settings":      true,
+		"support":       true,
+		"system":        true,
+		"undefined":     true,
+		"user":          true,
+		"username":      true,
+		"users":         true,
+	}
+)
+
+// UsernameRedirect keeps the old username resolving to the user until it expires.
+type UsernameRedirect struct {
+	Username  string
+	UserID    string
+	ExpiresAt time.Time
+}
+
+// ValidateUsername checks the characters and the reserved words,
+// the username is compared case-insensitively so "John" and "john" are the same username.
+func ValidateUsername(username string) error {
+	validationError := customerror.NewValidationError()
+	if len(username) < usernameMinLen || len(username) > usernameMaxLen {
+		validationError.AddError("username", customerror.CodeLengthBetween, customerror.Params{"min": usernameMinLen, "max": usernameMaxLen})
+	}
+
+	if !usernameRegex.MatchString(username) {
+		validationError.AddError("username", customerror.CodeInvalidUsername, nil)
+	} else if strings.Contains(username, "__") || strings.HasSuffix(username, "_") {
+		validationError.AddError("username", customerror.CodeInvalidUsernameUnderscore, nil)
+	}
+
+	if reservedUsernames[strings.ToLower(username)] {
+		validationError.AddError("username", customerror.CodeReserved, nil)
+	}
+
+	if validationError.HasError() {
+		return validationError
+	}
+
+	return nil
+}
+
+// ChangeUsername only allows one change for every change interval,
+// changing the letter case of the current username is not limited.
+func (user *User) ChangeUsername(username string, changeInterval time.Duration) error {
+	if err := ValidateUsername(username); err != nil {
+		return err
+	}
+
+	if username == user.Username {
+		return customerror.NewValidationErrorWithCode("username", customerror.CodeSameUsername, nil)
+	}
+
+	now := time.Now()
+	caseOnlyChange := strings.EqualFold(username, user.Username)
+	if !caseOnlyChange && user.UsernameChangedAt != nil && now.Before(user.UsernameChangedAt.Add(changeInterval)) {
+		return customerror.NewValidationErrorWithCode(
+			"username",
+			customerror.CodeUsernameChangeInterval,
+			customerror.Params{
+				"days":         int(changeInterval.Hours() / 24),
+				"nextChangeAt": user.UsernameChangedAt.Add(changeInterval).Format(time.RFC3339),
+			},
+		)
+	}
+
+	user.Username = username
+	if !caseOnlyChange {
+		user.UsernameChangedAt = &now
+	}
+	return nil
+}
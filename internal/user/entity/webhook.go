@@ -0,0 +1,283 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	customerror "userservice/internal/custom_error"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventIDHeader   = "X-Webhook-Event-Id"
+	WebhookEventTypeHeader = "X-Webhook-Event-Type"
+
+	webhookSecretMinLength = 16
+	webhookURLMaxLength    = 2048
+	partnerIDMaxLength     = 64
+	webhookMaxBackoff      = 6 * time.Hour
+)
+
+// WebhookEventTypes are the event types which can be subscribed.
+var WebhookEventTypes = map[string]bool{
+	UserCreatedEventType:        true,
+	UserProfileUpdatedEventType: true,
+	UserLoggedInEventType:       true,
+}
+
+var partnerIDRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// WebhookSubscription receives the events of the subscribed types for the users linked to its partner,
+// it is disabled automatically after too many failed deliveries in a row.
+type WebhookSubscription struct {
+	ID                  string
+	PartnerID           string
+	URL                 string
+	EventTypes          []string
+	Secret              string
+	Enabled             bool
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// WebhookDelivery is an event waiting to be delivered to a subscription.
+type WebhookDelivery struct {
+	ID            string
+	Subscription  *WebhookSubscription
+	EventID       string
+	EventType     string
+	Payload       []byte
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+}
+
+// WebhookDeliveryAttempt is the log of a single request to the subscription,
+// StatusCode is 0 when the request failed before getting a response.
+type WebhookDeliveryAttempt struct {
+	ID             string
+	DeliveryID     string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Attempt        int
+	StatusCode     int
+	Error          string
+	Duration       time.Duration
+	CreatedAt      time.Time
+}
+
+// WebhookRequest is the signed request sent to the subscription.
+type WebhookRequest struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// NewWebhookSubscription generates the secret when it is empty.
+func NewWebhookSubscription(partnerID, rawURL string, eventTypes []string, secret string) (*WebhookSubscription, error) {
+	subscription := &WebhookSubscription{
+		PartnerID:  partnerID,
+		URL:        rawURL,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		Enabled:    true,
+	}
+
+	validationError := customerror.NewValidationError()
+	validationError.Merge(ValidatePartnerID(partnerID))
+	validationError.Merge(validateWebhookURL(rawURL))
+	validationError.Merge(validateWebhookEventTypes(eventTypes))
+	if secret == "" {
+		subscription.Secret = generateWebhookSecret()
+	} else if len(secret) < webhookSecretMinLength {
+		validationError.AddError("secret", customerror.CodeMinLength, customerror.Params{"min": webhookSecretMinLength})
+	}
+
+	if validationError.HasError() {
+		return nil, validationError
+	}
+	return subscription, nil
+}
+
+// Update changes the given fields, enabling the subscription again resets its failures.
+func (ws *WebhookSubscription) Update(rawURL *string, eventTypes *[]string, enabled *bool) error {
+	validationError := customerror.NewValidationError()
+	if rawURL == nil && eventTypes == nil && enabled == nil {
+		validationError.AddError("url", customerror.CodeAtLeastOneField, nil)
+		return validationError
+	}
+
+	if rawURL != nil {
+		validationError.Merge(validateWebhookURL(*rawURL))
+		ws.URL = *rawURL
+	}
+	if eventTypes != nil {
+		validationError.Merge(validateWebhookEventTypes(*eventTypes))
+		ws.EventTypes = *eventTypes
+	}
+	if validationError.HasError() {
+		return validationError
+	}
+
+	if enabled != nil && *enabled != ws.Enabled {
+		ws.Enabled = *enabled
+		ws.ConsecutiveFailures = 0
+		ws.DisabledAt = nil
+		if !ws.Enabled {
+			now := time.Now()
+			ws.DisabledAt = &now
+		}
+	}
+	return nil
+}
+
+// NewRequest signs the payload with HMAC-SHA256 of "{timestamp}.{payload}",
+// the receiver should reject an old timestamp to prevent replay.
+func (wd *WebhookDelivery) NewRequest(now time.Time) *WebhookRequest {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	return &WebhookRequest{
+		URL: wd.Subscription.URL,
+		Headers: map[string]string{
+			"Content-Type":         "application/json",
+			WebhookTimestampHeader: timestamp,
+			WebhookSignatureHeader: "sha256=" + SignWebhookPayload(wd.Subscription.Secret, timestamp, wd.Payload),
+			WebhookEventIDHeader:   wd.EventID,
+			WebhookEventTypeHeader: wd.EventType,
+		},
+		Body: wd.Payload,
+	}
+}
+
+// RecordAttempt updates the delivery and its subscription with the result of a request,
+// a failed delivery is retried with exponential backoff until the max attempts.
+func (wd *WebhookDelivery) RecordAttempt(
+	statusCode int,
+	requestErr error,
+	duration time.Duration,
+	now time.Time,
+	maxAttempts int,
+	backoffBase time.Duration,
+	disableAfterFailures int,
+) *WebhookDeliveryAttempt {
+	wd.Attempts++
+	attempt := &WebhookDeliveryAttempt{
+		DeliveryID:     wd.ID,
+		SubscriptionID: wd.Subscription.ID,
+		EventID:        wd.EventID,
+		EventType:      wd.EventType,
+		Attempt:        wd.Attempts,
+		StatusCode:     statusCode,
+		Duration:       duration,
+		CreatedAt:      now,
+	}
+
+	if requestErr == nil && statusCode >= 200 && statusCode < 300 {
+		wd.Status = WebhookDeliverySucceeded
+		wd.Subscription.ConsecutiveFailures = 0
+		return attempt
+	}
+
+	if requestErr != nil {
+		attempt.Error = requestErr.Error()
+	} else {
+		attempt.Error = fmt.Sprintf("unexpected status code %d", statusCode)
+	}
+
+	if wd.Attempts >= maxAttempts {
+		wd.Status = WebhookDeliveryFailed
+	} else {
+		backoff := backoffBase << (wd.Attempts - 1)
+		if backoff <= 0 || backoff > webhookMaxBackoff {
+			backoff = webhookMaxBackoff
+		}
+		wd.NextAttemptAt = now.Add(backoff)
+	}
+
+	wd.Subscription.ConsecutiveFailures++
+	if wd.Subscription.Enabled && wd.Subscription.ConsecutiveFailures >= disableAfterFailures {
+		wd.Subscription.Enabled = false
+		wd.Subscription.DisabledAt = &now
+	}
+	return attempt
+}
+
+// SignWebhookPayload returns the hex encoded signature, it is exported for the receivers written in Go.
+func SignWebhookPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// carrier-grade NAT is shared by the internal networks of some providers, netip does not report it as private
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// WebhookAddressAllowed tells whether a webhook can be sent to the address, the loopback, private and link-local
+// addresses are never allowed so a subscription cannot reach the internal services.
+func WebhookAddressAllowed(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// validateWebhookURL rejects the internal hosts which are known without resolving them,
+// the resolved addresses are checked by the sender when it connects.
+func validateWebhookURL(rawURL string) error {
+	invalidURL := customerror.NewValidationErrorWithCode("url", customerror.CodeInvalidWebhookURL, customerror.Params{"max": webhookURLMaxLength})
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Hostname() == "" || len(rawURL) > webhookURLMaxLength {
+		return invalidURL
+	}
+
+	if addr, err := netip.ParseAddr(parsed.Hostname()); err == nil && !WebhookAddressAllowed(addr) {
+		return invalidURL
+	}
+	if hostname := strings.ToLower(parsed.Hostname()); hostname == "localhost" || strings.HasSuffix(hostname, ".localhost") {
+		return invalidURL
+	}
+	return nil
+}
+
+// ValidatePartnerID checks the id of the partner given by the admin, the partners are not stored by the service.
+func ValidatePartnerID(partnerID string) error {
+	if len(partnerID) > partnerIDMaxLength || !partnerIDRegex.MatchString(partnerID) {
+		return customerror.NewValidationErrorWithCode("partnerId", customerror.CodeInvalidPartnerID, customerror.Params{"max": partnerIDMaxLength})
+	}
+	return nil
+}
+
+func validateWebhookEventTypes(eventTypes []string) error {
+	if len(eventTypes) == 0 {
+		return customerror.NewValidationErrorWithCode("eventTypes", customerror.CodeAtLeastOneEventType, nil)
+	}
+	for _, eventType := range eventTypes {
+		if !WebhookEventTypes[eventType] {
+			return customerror.NewValidationErrorWithCode("eventTypes", customerror.CodeUnsupportedEventType, customerror.Params{"eventType": eventType})
+		}
+	}
+	return nil
+}
+
+func generateWebhookSecret() string {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(secret)
+}
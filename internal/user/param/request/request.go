@@ -1,5 +1,7 @@
 package request
 
+import "time"
+
 type CreateUser struct {
 	PhoneNumber string
 	FullName    string
@@ -13,5 +15,71 @@ type UpdateProfile struct {
 
 type GenerateUserTokenRequest struct {
 	PhoneNumber string
+	Email       string
 	Password    string
 }
+
+type ChangePassword struct {
+	CurrentPassword string
+	NewPassword     string
+}
+
+type ChangeEmail struct {
+	Email string
+}
+
+type UpdateExtendedProfile struct {
+	AvatarURL   *string
+	DateOfBirth *string
+	Locale      *string
+	Timezone    *string
+	Address     *Address
+}
+
+type Address struct {
+	Street     string
+	City       string
+	Province   string
+	PostalCode string
+	Country    string
+}
+
+type ChangeUsername struct {
+	Username string
+}
+
+type UpdatePrivacySettings struct {
+	ShowFullName    bool
+	ShowAvatar      bool
+	ShowPhoneNumber bool
+}
+
+type BatchGetUsers struct {
+	IDs          []string
+	PhoneNumbers []string
+}
+
+type SearchUsers struct {
+	Query  string
+	Cursor string
+	Limit  int
+}
+
+type CreateWebhookSubscription struct {
+	PartnerID  string
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+type UpdateWebhookSubscription struct {
+	URL        *string
+	EventTypes *[]string
+	Enabled    *bool
+}
+
+type CreateAPIKey struct {
+	Name      string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
@@ -1,7 +1,21 @@
 package response
 
+import "userservice/internal/user/entity"
+
 type Token struct {
 	Token     string
 	ExpiresIn int
 	Type      string
 }
+
+type BatchGetUsers struct {
+	Users               []*entity.User
+	MissingIDs          []string
+	MissingPhoneNumbers []string
+}
+
+type SearchUsers struct {
+	Results []*entity.UserSearchResult
+	// NextCursor is empty on the last page
+	NextCursor string
+}
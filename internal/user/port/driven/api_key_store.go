@@ -0,0 +1,19 @@
+package driven
+
+import (
+	"context"
+	"time"
+
+	"userservice/internal/user/entity"
+)
+
+type APIKeyStore interface {
+	CreateAPIKey(ctx context.Context, apiKey *entity.APIKey) error
+	// GetAPIKeysByUserID returns the keys which are not revoked, the latest first
+	GetAPIKeysByUserID(ctx context.Context, userID string) ([]*entity.APIKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error)
+	// RevokeAPIKey returns ErrNotFound when the key of the user is not found or already revoked
+	RevokeAPIKey(ctx context.Context, userID, id string, revokedAt time.Time) error
+	// TouchAPIKey sets the last used time, it may skip the write when the key was used recently
+	TouchAPIKey(ctx context.Context, id string, usedAt time.Time) error
+}
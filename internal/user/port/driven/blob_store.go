@@ -0,0 +1,9 @@
+package driven
+
+import "context"
+
+type BlobStore interface {
+	// Put stores the data under the key, replacing any existing blob, and
+	// returns the public URL of the blob.
+	Put(ctx context.Context, key, contentType string, data []byte) (url string, err error)
+}
@@ -0,0 +1,7 @@
+package driven
+
+import "context"
+
+type EmailSender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
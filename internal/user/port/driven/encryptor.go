@@ -1,6 +1,8 @@
 package driven
 
+import "context"
+
 type Encyptor interface {
-	Encrypt(data []byte, cost int) ([]byte, error)
-	CompareEncryptedAndData(encrypted, data []byte) error
+	Encrypt(ctx context.Context, data []byte, cost int) ([]byte, error)
+	CompareEncryptedAndData(ctx context.Context, encrypted, data []byte) error
 }
@@ -0,0 +1,7 @@
+package driven
+
+import "errors"
+
+// ErrNotFound is returned by the driven adapters when the resource does not exist,
+// so the usecases do not depend on the errors of the storage, e.g. sql.ErrNoRows.
+var ErrNotFound = errors.New("resource not found")
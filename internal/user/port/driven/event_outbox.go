@@ -0,0 +1,17 @@
+package driven
+
+import (
+	"context"
+	"time"
+
+	"userservice/internal/user/entity"
+)
+
+// EventOutbox is the events stored together with the state changes, waiting to be published.
+type EventOutbox interface {
+	// ClaimUnpublished returns the oldest unpublished events and hides them from the other claims
+	// for the lock duration, so the events are retried when the claimer crashed.
+	ClaimUnpublished(ctx context.Context, limit int, lockDuration time.Duration) ([]*entity.Event, error)
+	MarkPublished(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, id string, reason string) error
+}
@@ -0,0 +1,11 @@
+package driven
+
+import (
+	"context"
+
+	"userservice/internal/user/entity"
+)
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event *entity.Event) error
+}
@@ -0,0 +1,9 @@
+package driven
+
+import "userservice/internal/user/entity"
+
+type ImageProcessor interface {
+	// Process re-encodes the image without its metadata and creates a square
+	// thumbnail for each of the given sizes.
+	Process(image *entity.Image, thumbnailSizes []int) (processed *entity.Image, thumbnails []entity.Image, err error)
+}
@@ -0,0 +1,36 @@
+package driven
+
+import "time"
+
+// the reasons of Metrics.LoginFailed
+const (
+	LoginFailureInvalidIdentifier = "invalid_identifier"
+	LoginFailureUnknownUser       = "unknown_user"
+	LoginFailureEmailNotVerified  = "email_not_verified"
+	LoginFailureWrongPassword     = "wrong_password"
+	LoginFailureError             = "error"
+)
+
+// the profiles of Metrics.ProfileUpdated
+const (
+	ProfileKindProfile         = "profile"
+	ProfileKindExtendedProfile = "extended_profile"
+	ProfileKindPrivacySettings = "privacy_settings"
+)
+
+// the operations of Metrics.PasswordHashed
+const (
+	PasswordHashingHash    = "hash"
+	PasswordHashingCompare = "compare"
+)
+
+// Metrics records the business metrics, the labels are the constants above to keep their cardinality low.
+type Metrics interface {
+	UserSignedUp()
+	LoginSucceeded()
+	LoginFailed(reason string)
+	// TokenValidationFailed records a rejected credential, the reason is e.g. invalid_token
+	TokenValidationFailed(reason string)
+	ProfileUpdated(kind string)
+	PasswordHashed(operation string, duration time.Duration)
+}
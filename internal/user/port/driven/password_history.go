@@ -0,0 +1,8 @@
+package driven
+
+import "context"
+
+// PasswordHistory is the previous passwords of the users, they are stored by UserWriter.UpdatePasswordByID.
+type PasswordHistory interface {
+	GetLatestByUserID(ctx context.Context, userID string, limit int) ([]string, error)
+}
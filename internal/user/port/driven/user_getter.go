@@ -8,5 +8,14 @@ import (
 
 type UserGetter interface {
 	GetByID(ctx context.Context, id string) (*entity.User, error)
+	GetByIDs(ctx context.Context, ids []string) ([]*entity.User, error)
+	GetByPhoneNumbers(ctx context.Context, phoneNumbers []string) ([]*entity.User, error)
 	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*entity.User, error)
+	// GetByEmail returns the user who verified the email when there is one
+	GetByEmail(ctx context.Context, email string) (*entity.User, error)
+	GetProfileByUserID(ctx context.Context, userID string) (*entity.Profile, error)
+	GetPrivacySettingsByUserID(ctx context.Context, userID string) (*entity.PrivacySettings, error)
+	GetByUsername(ctx context.Context, username string) (*entity.User, error)
+	// GetUsernameRedirect only returns the redirect which is not expired yet
+	GetUsernameRedirect(ctx context.Context, username string) (*entity.UsernameRedirect, error)
 }
@@ -0,0 +1,12 @@
+package driven
+
+import (
+	"context"
+
+	"userservice/internal/user/entity"
+)
+
+type UserSearcher interface {
+	// Search returns at most query.Limit users with their score, ordered by the score then the id
+	Search(ctx context.Context, query *entity.UserSearchQuery) ([]*entity.UserSearchResult, error)
+}
@@ -11,4 +11,13 @@ type UserWriter interface {
 	Create(ctx context.Context, user *entity.User) (id string, err error)
 	UpdateProfileByID(ctx context.Context, id string, params *request.UpdateProfile) (*entity.User, error)
 	UpdateUserToken(ctx context.Context, userId string) error
+	// UpdatePasswordByID also stores the previous password in the password history, keeping the latest
+	// historyLimit entries, nothing is stored when historyLimit is 0
+	UpdatePasswordByID(ctx context.Context, id string, password string, previousPassword string, historyLimit int) error
+	UpdateEmailByID(ctx context.Context, id string, email string) error
+	VerifyEmailByID(ctx context.Context, id string, email string) error
+	UpsertProfile(ctx context.Context, profile *entity.Profile) error
+	UpsertPrivacySettings(ctx context.Context, settings *entity.PrivacySettings) error
+	// UpdateUsername also stores the redirect from the old username when it is not nil
+	UpdateUsername(ctx context.Context, user *entity.User, redirect *entity.UsernameRedirect) error
 }
@@ -0,0 +1,6 @@
+package driven
+
+type VerificationTokenProvider interface {
+	Generate(userID, email string) (string, error)
+	Validate(tokenString string) (userID, email string, err error)
+}
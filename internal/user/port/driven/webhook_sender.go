@@ -0,0 +1,12 @@
+package driven
+
+import (
+	"context"
+
+	"userservice/internal/user/entity"
+)
+
+type WebhookSender interface {
+	// Send returns the status code of the response, the error is only for the failure before getting a response
+	Send(ctx context.Context, request *entity.WebhookRequest) (int, error)
+}
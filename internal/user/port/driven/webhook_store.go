@@ -0,0 +1,31 @@
+package driven
+
+import (
+	"context"
+	"time"
+
+	"userservice/internal/user/entity"
+)
+
+type WebhookStore interface {
+	CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) error
+	GetSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	GetSubscriptionByID(ctx context.Context, id string) (*entity.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id string) error
+	// LinkPartnerUser lets the subscriptions of the partner receive the events of the user,
+	// linking them again does nothing
+	LinkPartnerUser(ctx context.Context, partnerID, userID string) error
+	UnlinkPartnerUser(ctx context.Context, partnerID, userID string) error
+	// QueueDeliveries queues the event for the enabled subscriptions of its type whose partner is linked to the user
+	// of the event, queueing the same event again does nothing
+	QueueDeliveries(ctx context.Context, event *entity.Event) error
+	// ClaimDueDeliveries returns the pending deliveries of the enabled subscriptions which are due,
+	// and hides them from the other claims for the lock duration.
+	// The deliveries of the same subscription share the same subscription.
+	ClaimDueDeliveries(ctx context.Context, limit int, lockDuration time.Duration) ([]*entity.WebhookDelivery, error)
+	// SaveAttempt stores the attempt together with the updated delivery and subscription
+	SaveAttempt(ctx context.Context, delivery *entity.WebhookDelivery, attempt *entity.WebhookDeliveryAttempt) error
+	// GetDeliveryAttempts returns the latest attempts first
+	GetDeliveryAttempts(ctx context.Context, subscriptionID string, limit int) ([]*entity.WebhookDeliveryAttempt, error)
+}
@@ -0,0 +1,17 @@
+package driver
+
+import (
+	"context"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+)
+
+type APIKeyUsecase interface {
+	// CreateAPIKey returns the key with its Key, it is not shown anymore afterward
+	CreateAPIKey(ctx context.Context, userID string, params *request.CreateAPIKey) (*entity.APIKey, error)
+	GetAPIKeysByUserID(ctx context.Context, userID string) ([]*entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, id string) error
+	// AuthenticateAPIKey returns entity.ErrInvalidAPIKey when the key cannot be used
+	AuthenticateAPIKey(ctx context.Context, key string) (*entity.APIKey, error)
+}
@@ -0,0 +1,13 @@
+package driver
+
+import (
+	"context"
+
+	"userservice/internal/user/entity"
+)
+
+type AvatarUsecase interface {
+	UploadAvatarByID(ctx context.Context, id string, data []byte) (*entity.Avatar, error)
+	// AvatarMaxSize is the max size of the avatar in bytes, a larger upload is rejected before it is read
+	AvatarMaxSize() int
+}
@@ -0,0 +1,13 @@
+package driver
+
+import (
+	"context"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+)
+
+type EmailUsecase interface {
+	ChangeEmailByID(ctx context.Context, id string, params *request.ChangeEmail) error
+	VerifyEmail(ctx context.Context, token string) (*entity.User, error)
+}
@@ -0,0 +1,12 @@
+package driver
+
+import (
+	"context"
+
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/param/response"
+)
+
+type InternalUserUsecase interface {
+	BatchGetUsers(ctx context.Context, params *request.BatchGetUsers) (*response.BatchGetUsers, error)
+}
@@ -0,0 +1,8 @@
+package driver
+
+import "context"
+
+type OutboxRelayUsecase interface {
+	// RelayEvents publishes a batch of unpublished events and returns the number of published events
+	RelayEvents(ctx context.Context) (int, error)
+}
@@ -0,0 +1,11 @@
+package driver
+
+import (
+	"context"
+
+	"userservice/internal/user/param/request"
+)
+
+type PasswordUsecase interface {
+	ChangePasswordByID(ctx context.Context, id string, params *request.ChangePassword) error
+}
@@ -8,4 +8,7 @@ import (
 
 type UserGetterUsecase interface {
 	GetUserByID(ctx context.Context, id string) (*entity.User, error)
+	GetProfileByUserID(ctx context.Context, userID string) (*entity.Profile, error)
+	GetPublicProfileByID(ctx context.Context, id string) (*entity.PublicProfile, error)
+	GetPrivacySettingsByUserID(ctx context.Context, userID string) (*entity.PrivacySettings, error)
 }
@@ -0,0 +1,12 @@
+package driver
+
+import (
+	"context"
+
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/param/response"
+)
+
+type UserSearchUsecase interface {
+	SearchUsers(ctx context.Context, params *request.SearchUsers) (*response.SearchUsers, error)
+}
@@ -11,5 +11,7 @@ import (
 type UserUsecase interface {
 	CreateUser(ctx context.Context, params *request.CreateUser) (id string, err error)
 	UpdateProfileByID(ctx context.Context, id string, params *request.UpdateProfile) (*entity.User, error)
+	UpdateExtendedProfileByID(ctx context.Context, id string, params *request.UpdateExtendedProfile) (*entity.Profile, error)
+	UpdatePrivacySettingsByID(ctx context.Context, id string, params *request.UpdatePrivacySettings) (*entity.PrivacySettings, error)
 	GenerateUserToken(ctx context.Context, params *request.GenerateUserTokenRequest) (*response.Token, error)
 }
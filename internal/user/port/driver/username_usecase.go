@@ -0,0 +1,14 @@
+package driver
+
+import (
+	"context"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+)
+
+type UsernameUsecase interface {
+	CheckUsernameAvailability(ctx context.Context, username string) error
+	ChangeUsernameByID(ctx context.Context, id string, params *request.ChangeUsername) (*entity.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+}
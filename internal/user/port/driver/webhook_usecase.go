@@ -0,0 +1,23 @@
+package driver
+
+import (
+	"context"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+)
+
+type WebhookUsecase interface {
+	CreateWebhookSubscription(ctx context.Context, params *request.CreateWebhookSubscription) (*entity.WebhookSubscription, error)
+	GetWebhookSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	UpdateWebhookSubscriptionByID(ctx context.Context, id string, params *request.UpdateWebhookSubscription) (*entity.WebhookSubscription, error)
+	DeleteWebhookSubscriptionByID(ctx context.Context, id string) error
+	GetWebhookDeliveryAttempts(ctx context.Context, subscriptionID string, limit int) ([]*entity.WebhookDeliveryAttempt, error)
+	LinkPartnerUser(ctx context.Context, partnerID, userID string) error
+	UnlinkPartnerUser(ctx context.Context, partnerID, userID string) error
+}
+
+type WebhookDispatcherUsecase interface {
+	// DispatchDueDeliveries sends a batch of due deliveries and returns the number of sent deliveries
+	DispatchDueDeliveries(ctx context.Context) (int, error)
+}
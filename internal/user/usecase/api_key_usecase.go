@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"userservice/internal/logging"
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/port/driven"
+)
+
+type APIKeyUsecase struct {
+	apiKeyStore driven.APIKeyStore
+}
+
+func NewAPIKeyUsecase(apiKeyStore driven.APIKeyStore) *APIKeyUsecase {
+	return &APIKeyUsecase{
+		apiKeyStore: apiKeyStore,
+	}
+}
+
+func (aku APIKeyUsecase) CreateAPIKey(ctx context.Context, userID string, params *request.CreateAPIKey) (_ *entity.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUsecase.CreateAPIKey")
+	defer func() { tracing.End(span, err) }()
+
+	apiKey, err := entity.NewAPIKey(userID, params.Name, params.Scopes, params.ExpiresAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := aku.apiKeyStore.CreateAPIKey(ctx, apiKey); err != nil {
+		return nil, err
+	}
+	return apiKey, nil
+}
+
+func (aku APIKeyUsecase) GetAPIKeysByUserID(ctx context.Context, userID string) (_ []*entity.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUsecase.GetAPIKeysByUserID")
+	defer func() { tracing.End(span, err) }()
+
+	return aku.apiKeyStore.GetAPIKeysByUserID(ctx, userID)
+}
+
+func (aku APIKeyUsecase) RevokeAPIKey(ctx context.Context, userID, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUsecase.RevokeAPIKey")
+	defer func() { tracing.End(span, err) }()
+
+	return aku.apiKeyStore.RevokeAPIKey(ctx, userID, id, time.Now())
+}
+
+// AuthenticateAPIKey does not fail when the last used time cannot be saved.
+func (aku APIKeyUsecase) AuthenticateAPIKey(ctx context.Context, key string) (_ *entity.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUsecase.AuthenticateAPIKey")
+	defer func() { tracing.End(span, err) }()
+
+	prefix, ok := entity.ParseAPIKeyPrefix(key)
+	if !ok {
+		return nil, entity.ErrInvalidAPIKey
+	}
+
+	apiKey, err := aku.apiKeyStore.GetAPIKeyByPrefix(ctx, prefix)
+	if errors.Is(err, driven.ErrNotFound) {
+		return nil, entity.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := apiKey.Verify(key, now); err != nil {
+		return nil, err
+	}
+
+	if err := aku.apiKeyStore.TouchAPIKey(ctx, apiKey.ID, now); err != nil {
+		logging.FromContext(ctx).Warn("failed to save the last use of api key", "api_key_id", apiKey.ID, "error", err)
+	} else {
+		apiKey.LastUsedAt = &now
+	}
+	return apiKey, nil
+}
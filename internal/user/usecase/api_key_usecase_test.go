@@ -0,0 +1,154 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyUsecase_CreateAPIKey(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	tests := []struct {
+		name      string
+		params    *request.CreateAPIKey
+		wantCodes []string
+	}{
+		{
+			name: "when fields are invalid, it should return every invalid field",
+			params: &request.CreateAPIKey{
+				Name:      " ",
+				Scopes:    []string{"users:delete"},
+				ExpiresAt: &past,
+			},
+			wantCodes: []string{customerror.CodeLengthBetween, customerror.CodeUnsupportedScope, customerror.CodeFutureTime},
+		},
+		{
+			name: "when scopes are empty, it should return validation error",
+			params: &request.CreateAPIKey{
+				Name: "backup script",
+			},
+			wantCodes: []string{customerror.CodeAtLeastOneScope},
+		},
+		{
+			name: "when fields are valid, it should create the key",
+			params: &request.CreateAPIKey{
+				Name:      "backup script",
+				Scopes:    []string{entity.APIKeyScopeUsersRead},
+				ExpiresAt: &future,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := fake.NewFakeAPIKeyStore()
+			aku := usecase.NewAPIKeyUsecase(store)
+
+			got, err := aku.CreateAPIKey(context.Background(), "user-id", tt.params)
+
+			assert := assert.New(t)
+			if tt.wantCodes != nil {
+				var codes []string
+				for _, fieldError := range err.(*customerror.ValidationError).Errors() {
+					codes = append(codes, fieldError.Code)
+				}
+				assert.Equal(tt.wantCodes, codes)
+				assert.Empty(store.APIKeys)
+				return
+			}
+			assert.NoError(err)
+			assert.True(strings.HasPrefix(got.Key, got.Prefix+"_"))
+			// only the hash is kept
+			assert.NotContains(got.Hash, got.Key)
+			assert.Contains(store.APIKeys, got.ID)
+		})
+	}
+}
+
+func TestAPIKeyUsecase_AuthenticateAPIKey(t *testing.T) {
+	store := fake.NewFakeAPIKeyStore()
+	aku := usecase.NewAPIKeyUsecase(store)
+	ctx := context.Background()
+
+	apiKey, err := aku.CreateAPIKey(ctx, "user-id", &request.CreateAPIKey{Name: "script", Scopes: []string{entity.APIKeyScopeUsersRead}})
+	require.NoError(t, err)
+	revokedKey, err := aku.CreateAPIKey(ctx, "user-id", &request.CreateAPIKey{Name: "old script", Scopes: []string{entity.APIKeyScopeUsersRead}})
+	require.NoError(t, err)
+	require.NoError(t, aku.RevokeAPIKey(ctx, "user-id", revokedKey.ID))
+	expiredKey, err := aku.CreateAPIKey(ctx, "user-id", &request.CreateAPIKey{Name: "expired script", Scopes: []string{entity.APIKeyScopeUsersRead}})
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(-time.Minute)
+	expiredKey.ExpiresAt = &expiresAt
+
+	tests := []struct {
+		name    string
+		key     string
+		wantErr error
+	}{
+		{
+			name:    "when key is not formatted as api key, it should return invalid api key",
+			key:     "token",
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name:    "when secret does not match, it should return invalid api key",
+			key:     apiKey.Prefix + "_" + strings.Repeat("0", 64),
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name:    "when key is revoked, it should return invalid api key",
+			key:     revokedKey.Key,
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name:    "when key is expired, it should return invalid api key",
+			key:     expiredKey.Key,
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name: "when key is valid, it should return the key and save the last use",
+			key:  apiKey.Key,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := aku.AuthenticateAPIKey(ctx, tt.key)
+
+			assert := assert.New(t)
+			if tt.wantErr != nil {
+				assert.ErrorIs(err, tt.wantErr)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal("user-id", got.UserID)
+			assert.NotNil(store.APIKeys[apiKey.ID].LastUsedAt)
+		})
+	}
+}
+
+func TestAPIKeyUsecase_RevokeAPIKey(t *testing.T) {
+	store := fake.NewFakeAPIKeyStore()
+	aku := usecase.NewAPIKeyUsecase(store)
+	ctx := context.Background()
+	apiKey, err := aku.CreateAPIKey(ctx, "user-id", &request.CreateAPIKey{Name: "script", Scopes: []string{entity.APIKeyScopeUsersRead}})
+	require.NoError(t, err)
+
+	assert := assert.New(t)
+	// the key of another user cannot be revoked
+	assert.Error(aku.RevokeAPIKey(ctx, "other-user-id", apiKey.ID))
+	assert.NoError(aku.RevokeAPIKey(ctx, "user-id", apiKey.ID))
+
+	apiKeys, err := aku.GetAPIKeysByUserID(ctx, "user-id")
+	assert.NoError(err)
+	assert.Empty(apiKeys)
+}
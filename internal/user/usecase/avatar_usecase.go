@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"github.com/google/uuid"
+)
+
+var avatarThumbnailSizes = []int{64, 256}
+
+type AvatarUsecase struct {
+	userGetter     driven.UserGetter
+	userWriter     driven.UserWriter
+	imageProcessor driven.ImageProcessor
+	blobStore      driven.BlobStore
+	maxSize        int
+}
+
+func NewAvatarUsecase(
+	userGetter driven.UserGetter,
+	userWriter driven.UserWriter,
+	imageProcessor driven.ImageProcessor,
+	blobStore driven.BlobStore,
+	maxSize int,
+) *AvatarUsecase {
+	return &AvatarUsecase{
+		userGetter:     userGetter,
+		userWriter:     userWriter,
+		imageProcessor: imageProcessor,
+		blobStore:      blobStore,
+		maxSize:        maxSize,
+	}
+}
+
+// AvatarMaxSize implements driver.AvatarUsecase.
+func (au AvatarUsecase) AvatarMaxSize() int {
+	return au.maxSize
+}
+
+// UploadAvatarByID stores the avatar and its thumbnails under a new version,
+// so the previous URL is never served with the new image from a cache.
+func (au AvatarUsecase) UploadAvatarByID(ctx context.Context, id string, data []byte) (_ *entity.Avatar, err error) {
+	ctx, span := tracing.Start(ctx, "AvatarUsecase.UploadAvatarByID")
+	defer func() { tracing.End(span, err) }()
+
+	image, err := entity.NewAvatarImage(data, au.maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	processed, thumbnails, err := au.imageProcessor.Process(image, avatarThumbnailSizes)
+	if err != nil {
+		return nil, customerror.NewValidationErrorWithCode(
+			"avatar",
+			customerror.CodeInvalidImage,
+			customerror.Params{"maxDimension": entity.AvatarMaxDimension},
+		)
+	}
+
+	keyPrefix := fmt.Sprintf("avatars/%s/%s", id, uuid.NewString())
+	avatar := new(entity.Avatar)
+	avatar.URL, err = au.blobStore.Put(ctx, fmt.Sprintf("%s/original.%s", keyPrefix, processed.Extension()), processed.ContentType, processed.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, thumbnail := range thumbnails {
+		url, err := au.blobStore.Put(ctx, fmt.Sprintf("%s/%d.%s", keyPrefix, avatarThumbnailSizes[i], thumbnail.Extension()), thumbnail.ContentType, thumbnail.Data)
+		if err != nil {
+			return nil, err
+		}
+		avatar.Thumbnails = append(avatar.Thumbnails, entity.Thumbnail{
+			Size: avatarThumbnailSizes[i],
+			URL:  url,
+		})
+	}
+
+	profile, err := au.userGetter.GetProfileByUserID(ctx, id)
+	if errors.Is(err, driven.ErrNotFound) {
+		profile = &entity.Profile{
+			UserID: id,
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	profile.AvatarURL = avatar.URL
+	if err := au.userWriter.UpsertProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return avatar, nil
+}
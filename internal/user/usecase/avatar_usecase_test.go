@@ -0,0 +1,148 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	pngHeader  = "\x89PNG\r\n\x1a\n"
+	jpegHeader = "\xff\xd8\xff\xe0"
+)
+
+func TestAvatarUsecase_UploadAvatarByID(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	id, _ := fakeUserDriven.Create(context.Background(), &entity.User{
+		PhoneNumber: "+628123123123",
+		FullName:    faker.Name(),
+	})
+
+	type args struct {
+		ctx  context.Context
+		data []byte
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantErr       bool
+		wantErrMsg    string
+		wantExtension string
+	}{
+		{
+			name: "when avatar is empty, it should return error",
+			args: args{
+				context.Background(),
+				nil,
+			},
+			wantErr:    true,
+			wantErrMsg: "avatar: must not be empty",
+		},
+		{
+			name: "when avatar is too large, it should return error",
+			args: args{
+				context.Background(),
+				[]byte(pngHeader + strings.Repeat("a", 1024)),
+			},
+			wantErr:    true,
+			wantErrMsg: "avatar: must be at most 1024 bytes in size",
+		},
+		{
+			name: "when avatar is not an image, it should return error",
+			args: args{
+				context.Background(),
+				[]byte("GIF89a"),
+			},
+			wantErr:    true,
+			wantErrMsg: "avatar: must be a JPEG, PNG or WebP image",
+		},
+		{
+			name: "when avatar cannot be processed, it should return error",
+			args: args{
+				context.Background(),
+				[]byte(pngHeader + "corrupted"),
+			},
+			wantErr:    true,
+			wantErrMsg: "avatar: must be a valid image of at most 4096x4096 pixels",
+		},
+		{
+			name: "when failed to store avatar, it should return error",
+			args: args{
+				context.WithValue(context.Background(), "blob_error", true),
+				[]byte(pngHeader),
+			},
+			wantErr:    true,
+			wantErrMsg: "error",
+		},
+		{
+			name: "when failed to get profile, it should return error",
+			args: args{
+				context.WithValue(context.Background(), "profile_error", true),
+				[]byte(pngHeader),
+			},
+			wantErr:    true,
+			wantErrMsg: "error",
+		},
+		{
+			name: "when avatar is png, it should store avatar and thumbnails",
+			args: args{
+				context.Background(),
+				[]byte(pngHeader),
+			},
+			wantErr:       false,
+			wantExtension: "png",
+		},
+		{
+			name: "when avatar is jpeg, it should store avatar and thumbnails",
+			args: args{
+				context.Background(),
+				[]byte(jpegHeader),
+			},
+			wantErr:       false,
+			wantExtension: "jpg",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeBlobStore := fake.NewFakeBlobStore()
+			au := usecase.NewAvatarUsecase(
+				fakeUserDriven,
+				fakeUserDriven,
+				new(fake.FakeImageProcessor),
+				fakeBlobStore,
+				1024,
+			)
+
+			got, err := au.UploadAvatarByID(tt.args.ctx, id, tt.args.data)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Equal(tt.wantErrMsg, err.Error())
+				assert.Nil(got)
+				return
+			}
+
+			assert.True(strings.HasSuffix(got.URL, "/original."+tt.wantExtension))
+			assert.Contains(got.URL, "/avatars/"+id+"/")
+			assert.Len(got.Thumbnails, 2)
+			assert.Len(fakeBlobStore.Blobs, 3)
+			for _, thumbnail := range got.Thumbnails {
+				assert.True(strings.HasSuffix(thumbnail.URL, "."+tt.wantExtension))
+				key := strings.TrimPrefix(thumbnail.URL, "http://localhost/blobs/")
+				assert.Equal(fmt.Sprint(thumbnail.Size), string(fakeBlobStore.Blobs[key].Data))
+			}
+
+			profile, _ := fakeUserDriven.GetProfileByUserID(context.Background(), id)
+			assert.Equal(got.URL, profile.AvatarURL)
+		})
+	}
+}
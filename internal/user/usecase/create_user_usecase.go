@@ -2,52 +2,156 @@ package usecase
 
 import (
 	"context"
+	"errors"
 
 	customerror "userservice/internal/custom_error"
+	"userservice/internal/logging"
+	"userservice/internal/tracing"
 	"userservice/internal/user/entity"
 	"userservice/internal/user/param/request"
 	"userservice/internal/user/param/response"
+	"userservice/internal/user/port/driven"
 )
 
 const (
 	costEncryption = 10
 )
 
+var errEmailNotVerified = errors.New("email not verified")
+
 func (uu UserUsecase) CreateUser(ctx context.Context, params *request.CreateUser) (id string, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.CreateUser")
+	defer func() { tracing.End(span, err) }()
+
 	user, err := entity.NewUser(params.FullName, params.PhoneNumber, params.Password)
 	if err != nil {
 		return id, err
 	}
 
-	encryptedPassword, err := uu.encryptor.Encrypt([]byte(user.Password), costEncryption)
+	encryptedPassword, err := uu.encryptor.Encrypt(ctx, []byte(user.Password), costEncryption)
 	if err != nil {
 		return id, err
 	}
 	user.Password = string(encryptedPassword)
-	return uu.userWriter.Create(ctx, user)
+	id, err = uu.userWriter.Create(ctx, user)
+	if err != nil {
+		return id, err
+	}
+
+	uu.metrics.UserSignedUp()
+	logging.FromContext(ctx).Info("user created", "user_id", id, "phone_number", user.PhoneNumber)
+	return id, nil
 }
 
-func (uu UserUsecase) UpdateProfileByID(ctx context.Context, id string, params *request.UpdateProfile) (*entity.User, error) {
+func (uu UserUsecase) UpdateProfileByID(ctx context.Context, id string, params *request.UpdateProfile) (_ *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.UpdateProfileByID")
+	defer func() { tracing.End(span, err) }()
+
 	user := &entity.User{
 		ID: id,
 	}
-	err := user.UpdateProfile(params.FullName, params.PhoneNumber)
+	err = user.UpdateProfile(params.FullName, params.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.PhoneNumber != nil {
+		params = &request.UpdateProfile{
+			FullName:    params.FullName,
+			PhoneNumber: &user.PhoneNumber,
+		}
+	}
+	updated, err := uu.userWriter.UpdateProfileByID(ctx, id, params)
+	if err != nil {
+		return nil, err
+	}
+
+	uu.metrics.ProfileUpdated(driven.ProfileKindProfile)
+	return updated, nil
+}
+
+func (uu UserUsecase) UpdateExtendedProfileByID(ctx context.Context, id string, params *request.UpdateExtendedProfile) (_ *entity.Profile, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.UpdateExtendedProfileByID")
+	defer func() { tracing.End(span, err) }()
+
+	profile, err := uu.userGetter.GetProfileByUserID(ctx, id)
+	if errors.Is(err, driven.ErrNotFound) {
+		profile = &entity.Profile{
+			UserID: id,
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	var address *entity.Address
+	if params.Address != nil {
+		address = &entity.Address{
+			Street:     params.Address.Street,
+			City:       params.Address.City,
+			Province:   params.Address.Province,
+			PostalCode: params.Address.PostalCode,
+			Country:    params.Address.Country,
+		}
+	}
+
+	err = profile.Update(params.AvatarURL, params.DateOfBirth, params.Locale, params.Timezone, address)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uu.userWriter.UpsertProfile(ctx, profile)
 	if err != nil {
 		return nil, err
 	}
 
-	return uu.userWriter.UpdateProfileByID(ctx, id, params)
+	uu.metrics.ProfileUpdated(driven.ProfileKindExtendedProfile)
+	return profile, nil
+}
+
+func (uu UserUsecase) UpdatePrivacySettingsByID(ctx context.Context, id string, params *request.UpdatePrivacySettings) (_ *entity.PrivacySettings, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.UpdatePrivacySettingsByID")
+	defer func() { tracing.End(span, err) }()
+
+	settings := &entity.PrivacySettings{
+		UserID:          id,
+		ShowFullName:    params.ShowFullName,
+		ShowAvatar:      params.ShowAvatar,
+		ShowPhoneNumber: params.ShowPhoneNumber,
+	}
+	if err := uu.userWriter.UpsertPrivacySettings(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	uu.metrics.ProfileUpdated(driven.ProfileKindPrivacySettings)
+	return settings, nil
 }
 
-func (uu UserUsecase) GenerateUserToken(ctx context.Context, params *request.GenerateUserTokenRequest) (*response.Token, error) {
-	user, err := uu.userGetter.GetByPhoneNumber(ctx, params.PhoneNumber)
+func (uu UserUsecase) GenerateUserToken(ctx context.Context, params *request.GenerateUserTokenRequest) (_ *response.Token, err error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.GenerateUserToken")
+	defer func() { tracing.End(span, err) }()
+
+	authenticationError := customerror.NewValidationErrorWithCode("authentication", customerror.CodeWrongPhoneNumberPassword, nil)
+	if params.Email != "" {
+		authenticationError = customerror.NewValidationErrorWithCode("authentication", customerror.CodeWrongEmailPassword, nil)
+	}
+
+	logger := logging.FromContext(ctx)
+	if params.Email == "" {
+		logger = logger.With("phone_number", params.PhoneNumber)
+	}
+
+	user, err := uu.getUserByLoginIdentifier(ctx, params)
 	if err != nil {
-		return nil, customerror.NewValidationErrorWithMessage("authentication", "wrong phone number/password")
+		uu.metrics.LoginFailed(loginFailureReason(err))
+		logger.Warn("login failed", "reason", err)
+		return nil, authenticationError
 	}
 
-	err = uu.encryptor.CompareEncryptedAndData([]byte(user.Password), []byte(params.Password))
+	err = uu.encryptor.CompareEncryptedAndData(ctx, []byte(user.Password), []byte(params.Password))
 	if err != nil {
-		return nil, customerror.NewValidationErrorWithMessage("authentication", "wrong phone number/password")
+		uu.metrics.LoginFailed(driven.LoginFailureWrongPassword)
+		logger.Warn("login failed", "user_id", user.ID, "reason", "wrong password")
+		return nil, authenticationError
 	}
 
 	token, err := uu.tokenProvider.Generate(user)
@@ -58,5 +162,51 @@ func (uu UserUsecase) GenerateUserToken(ctx context.Context, params *request.Gen
 	if err != nil {
 		return nil, err
 	}
+
+	uu.metrics.LoginSucceeded()
+	logger.Info("user logged in", "user_id", user.ID)
 	return token, nil
 }
+
+// loginFailureReason is the reason of the metrics of an error of getUserByLoginIdentifier.
+func loginFailureReason(err error) string {
+	var validationError *customerror.ValidationError
+	switch {
+	case errors.Is(err, driven.ErrNotFound):
+		return driven.LoginFailureUnknownUser
+	case errors.Is(err, errEmailNotVerified):
+		return driven.LoginFailureEmailNotVerified
+	case errors.As(err, &validationError):
+		return driven.LoginFailureInvalidIdentifier
+	default:
+		return driven.LoginFailureError
+	}
+}
+
+// getUserByLoginIdentifier finds the user by email when given, otherwise by phone number.
+func (uu UserUsecase) getUserByLoginIdentifier(ctx context.Context, params *request.GenerateUserTokenRequest) (*entity.User, error) {
+	if params.Email != "" {
+		email, err := entity.NormalizeEmail(params.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := uu.userGetter.GetByEmail(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+
+		// unverified email could be claimed by anyone, so it cannot be used to login
+		if !user.EmailVerified {
+			return nil, errEmailNotVerified
+		}
+		return user, nil
+	}
+
+	phoneNumber, err := entity.ParsePhoneNumber(params.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return uu.userGetter.GetByPhoneNumber(ctx, phoneNumber.E164())
+}
@@ -36,44 +36,54 @@ func TestUserUsecase_CreateUser(t *testing.T) {
 		wantErrMsg string
 	}{
 		{
-			name: "when phoneNumber less than 10 character it should return error",
+			name: "when phoneNumber less than country minimum digits it should return error",
 			args: args{context.Background(), &request.CreateUser{
-				PhoneNumber: "+62123",
+				PhoneNumber: "+62812",
 				FullName:    faker.Name(),
 				Password:    generateRandomPassword(12),
 			}},
 			wantErr:    true,
-			wantErrMsg: "phoneNumber: must be between 10 and 13 characters in length",
+			wantErrMsg: "phoneNumber: must be between 8 and 12 digits after country code +62",
 		},
 		{
-			name: "when phoneNumber more than 13 character it should return error",
+			name: "when phoneNumber more than country maximum digits it should return error",
 			args: args{context.Background(), &request.CreateUser{
-				PhoneNumber: "+62123123123123",
+				PhoneNumber: "+628123123123123",
 				FullName:    faker.Name(),
 				Password:    generateRandomPassword(12),
 			}},
 			wantErr:    true,
-			wantErrMsg: "phoneNumber: must be between 10 and 13 characters in length",
+			wantErrMsg: "phoneNumber: must be between 8 and 12 digits after country code +62",
 		},
 		{
-			name: "when phoneNumber not have prefix +62 it should return error",
+			name: "when phoneNumber not start with valid prefix of the country it should return error",
 			args: args{context.Background(), &request.CreateUser{
-				PhoneNumber: "0812311231231",
+				PhoneNumber: "+62123123123",
 				FullName:    faker.Name(),
 				Password:    generateRandomPassword(12),
 			}},
 			wantErr:    true,
-			wantErrMsg: "phoneNumber: must start with '+62' and only containt number",
+			wantErrMsg: "phoneNumber: must start with a valid prefix for Indonesia",
 		},
 		{
-			name: "when phoneNumber have prefix +62 but containt other than number, it should return error",
+			name: "when phoneNumber country calling code not supported it should return error",
+			args: args{context.Background(), &request.CreateUser{
+				PhoneNumber: "+12025550100",
+				FullName:    faker.Name(),
+				Password:    generateRandomPassword(12),
+			}},
+			wantErr:    true,
+			wantErrMsg: "phoneNumber: country calling code is not supported",
+		},
+		{
+			name: "when phoneNumber containt other than number, it should return error",
 			args: args{context.Background(), &request.CreateUser{
 				PhoneNumber: "+62abc3123123",
 				FullName:    faker.Name(),
 				Password:    generateRandomPassword(12),
 			}},
 			wantErr:    true,
-			wantErrMsg: "phoneNumber: must start with '+62' and only containt number",
+			wantErrMsg: "phoneNumber: must be a valid phone number in international or national format",
 		},
 		{
 			name: "when fullName less than 3 character, it should return error",
@@ -153,7 +163,7 @@ func TestUserUsecase_CreateUser(t *testing.T) {
 				Password:    "",
 			}},
 			wantErr:    true,
-			wantErrMsg: "phoneNumber: must be between 10 and 13 characters in length,must start with '+62' and only containt number;fullName: must be between 3 and 60 characters in length;password: must be between 6 and 64 characters in length,containing at least 1 capital characters AND 1 number AND 1 special (nonalpha-numeric) characters",
+			wantErrMsg: "phoneNumber: must be a valid phone number in international or national format;fullName: must be between 3 and 60 characters in length;password: must be between 6 and 64 characters in length,containing at least 1 capital characters AND 1 number AND 1 special (nonalpha-numeric) characters",
 		},
 		{
 			name: "when all field valid, it should return id and saved",
@@ -167,7 +177,7 @@ func TestUserUsecase_CreateUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			uu := usecase.NewUserUsecase(fakeUserDriven, bcrypt, nil, nil)
+			uu := usecase.NewUserUsecase(fakeUserDriven, bcrypt, nil, nil, fake.NewFakeMetrics())
 			gotID, err := uu.CreateUser(tt.args.ctx, tt.args.param)
 			assert := assert.New(t)
 			if tt.wantErr {
@@ -202,7 +212,7 @@ func assertMessagesEqual(message1, message2 string) bool {
 func TestCreateUser_withPasswordEncrypted(t *testing.T) {
 	fakeUserDriven := fake.NewFakeUserDriven()
 	bcrypt := new(infrastructure.BcyrpEncryption)
-	uu := usecase.NewUserUsecase(fakeUserDriven, bcrypt, nil, nil)
+	uu := usecase.NewUserUsecase(fakeUserDriven, bcrypt, nil, nil, fake.NewFakeMetrics())
 	assert := assert.New(t)
 
 	userParam := &request.CreateUser{
@@ -218,10 +228,62 @@ func TestCreateUser_withPasswordEncrypted(t *testing.T) {
 	assert.Equal(user.ID, gotID)
 	assert.NotEqual(userParam.Password, user.Password)
 
-	err = bcrypt.CompareEncryptedAndData([]byte(user.Password), []byte(userParam.Password))
+	err = bcrypt.CompareEncryptedAndData(context.Background(), []byte(user.Password), []byte(userParam.Password))
 	assert.NoError(err)
 }
 
+func TestCreateUser_withPhoneNumberNormalized(t *testing.T) {
+	tests := []struct {
+		name        string
+		phoneNumber string
+		want        string
+	}{
+		{
+			name:        "when phoneNumber in indonesian national format, it should be saved in E.164",
+			phoneNumber: "0812-3123-1231",
+			want:        "+6281231231231",
+		},
+		{
+			name:        "when phoneNumber in international format with separator, it should be saved in E.164",
+			phoneNumber: "+62 812 3123 1231",
+			want:        "+6281231231231",
+		},
+		{
+			name:        "when phoneNumber use 00 international prefix, it should be saved in E.164",
+			phoneNumber: "006281231231231",
+			want:        "+6281231231231",
+		},
+		{
+			name:        "when phoneNumber from malaysia, it should be saved in E.164",
+			phoneNumber: "+60 12-345 6789",
+			want:        "+60123456789",
+		},
+		{
+			name:        "when phoneNumber from singapore, it should be saved in E.164",
+			phoneNumber: "+65 9123 4567",
+			want:        "+6591234567",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeUserDriven := fake.NewFakeUserDriven()
+			uu := usecase.NewUserUsecase(fakeUserDriven, new(infrastructure.BcyrpEncryption), nil, nil, fake.NewFakeMetrics())
+			assert := assert.New(t)
+
+			gotID, err := uu.CreateUser(context.Background(), &request.CreateUser{
+				PhoneNumber: tt.phoneNumber,
+				FullName:    faker.Name(),
+				Password:    generateRandomPassword(12),
+			})
+			assert.NoError(err)
+
+			user, err := fakeUserDriven.GetByID(context.Background(), gotID)
+			assert.NoError(err)
+			assert.Equal(tt.want, user.PhoneNumber)
+		})
+	}
+}
+
 func generateRandomString(length int, charset string) string {
 	if charset == "" {
 		charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
@@ -239,12 +301,20 @@ func generateRandomString(length int, charset string) string {
 
 func generateRandomPassword(length int) string {
 	charset := "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%^&*()-_=+[]{}|;:'\",.<>/?~"
-	passwordRegex := regexp.MustCompile(`^(.*[A-Z])(.*\d)(.*[^A-Za-z0-9])`)
+	passwordRegexes := []*regexp.Regexp{
+		regexp.MustCompile(`[A-Z]`),
+		regexp.MustCompile(`[a-z]`),
+		regexp.MustCompile(`\d`),
+		regexp.MustCompile(`[^A-Za-z0-9]`),
+	}
 	var password string
 	match := false
 	for !match {
 		password = generateRandomString(length, charset)
-		match = passwordRegex.MatchString(password)
+		match = true
+		for _, passwordRegex := range passwordRegexes {
+			match = match && passwordRegex.MatchString(password)
+		}
 	}
 
 	return password
@@ -336,7 +406,7 @@ func TestUserUsecase_UpdateProfileByID(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			uu := usecase.NewUserUsecase(fakeUserDriven, nil, nil, nil)
+			uu := usecase.NewUserUsecase(fakeUserDriven, nil, nil, nil, fake.NewFakeMetrics())
 			result, err := uu.UpdateProfileByID(tt.args.ctx, tt.args.id, tt.args.params)
 			assert := assert.New(t)
 			if tt.wantErr {
@@ -362,8 +432,25 @@ func TestUserUsecase_GenerateUserToken(t *testing.T) {
 	}
 	fakeUserDriven.Create(context.Background(), user)
 
+	verifiedEmailUser := &entity.User{
+		PhoneNumber:   "+628123123124",
+		FullName:      faker.Name(),
+		Password:      string(encryptedPassword),
+		Email:         "verified@example.com",
+		EmailVerified: true,
+	}
+	fakeUserDriven.Create(context.Background(), verifiedEmailUser)
+
+	unverifiedEmailUser := &entity.User{
+		PhoneNumber: "+628123123125",
+		FullName:    faker.Name(),
+		Password:    string(encryptedPassword),
+		Email:       "unverified@example.com",
+	}
+	fakeUserDriven.Create(context.Background(), unverifiedEmailUser)
+
 	invalidUser := &entity.User{
-		PhoneNumber: "======111",
+		PhoneNumber: "+628111111111",
 		FullName:    faker.Name(),
 		Password:    faker.Password(),
 	}
@@ -427,6 +514,50 @@ func TestUserUsecase_GenerateUserToken(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "when email not verified, it should return error",
+			args: args{
+				context.Background(),
+				&request.GenerateUserTokenRequest{
+					Email:    unverifiedEmailUser.Email,
+					Password: validPassword,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "when email verified, it should return token",
+			args: args{
+				context.Background(),
+				&request.GenerateUserTokenRequest{
+					Email:    "Verified@Example.com",
+					Password: validPassword,
+				},
+			},
+			want: &response.Token{
+				Token:     "1231313213213131",
+				ExpiresIn: 3600,
+				Type:      "Bearer",
+			},
+			wantErr: false,
+		},
+		{
+			name: "when phoneNumber in national format, it should return token",
+			args: args{
+				context.Background(),
+				&request.GenerateUserTokenRequest{
+					PhoneNumber: "08123123123",
+					Password:    validPassword,
+				},
+			},
+			want: &response.Token{
+				Token:     "1231313213213131",
+				ExpiresIn: 3600,
+				Type:      "Bearer",
+			},
+			wantErr: false,
+		},
 		{
 			name: "success, it should return token",
 			args: args{
@@ -446,7 +577,7 @@ func TestUserUsecase_GenerateUserToken(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			uu := usecase.NewUserUsecase(fakeUserDriven, new(infrastructure.BcyrpEncryption), fakeUserDriven, new(fake.FakeTokenProvider))
+			uu := usecase.NewUserUsecase(fakeUserDriven, new(infrastructure.BcyrpEncryption), fakeUserDriven, new(fake.FakeTokenProvider), fake.NewFakeMetrics())
 			result, err := uu.GenerateUserToken(tt.args.ctx, tt.args.params)
 			assert := assert.New(t)
 			assert.Equal(tt.wantErr, err != nil)
@@ -454,3 +585,37 @@ func TestUserUsecase_GenerateUserToken(t *testing.T) {
 		})
 	}
 }
+
+func TestUserUsecase_GenerateUserToken_Metrics(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	password := faker.Password()
+	encryptedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	fakeUserDriven.Create(context.Background(), &entity.User{
+		PhoneNumber: "+628123123123",
+		FullName:    faker.Name(),
+		Password:    string(encryptedPassword),
+		Email:       "unverified@example.com",
+	})
+	fm := fake.NewFakeMetrics()
+	uu := usecase.NewUserUsecase(fakeUserDriven, infrastructure.NewBcryptEncryption(fm), fakeUserDriven, new(fake.FakeTokenProvider), fm)
+
+	for _, params := range []*request.GenerateUserTokenRequest{
+		{PhoneNumber: "+628123123123", Password: password},
+		{PhoneNumber: "+628123123123", Password: "wrong"},
+		{PhoneNumber: "not a phone number", Password: password},
+		{PhoneNumber: "+628123123124", Password: password},
+		{Email: "unverified@example.com", Password: password},
+	} {
+		uu.GenerateUserToken(context.Background(), params)
+	}
+
+	assert := assert.New(t)
+	assert.Equal(1, fm.LoginSuccesses)
+	assert.Equal(map[string]int{
+		"wrong_password":     1,
+		"invalid_identifier": 1,
+		"unknown_user":       1,
+		"email_not_verified": 1,
+	}, fm.LoginFailures)
+	assert.Equal(map[string]int{"compare": 2}, fm.PasswordHashings)
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/port/driven"
+)
+
+const (
+	verificationEmailSubject = "Verify your email address"
+	verificationEmailBody    = "Hi %s,\r\n\r\nPlease verify your email address by opening the link below:\r\n\r\n%s\r\n\r\nIf you did not request this, you can ignore this email.\r\n"
+)
+
+type EmailUsecase struct {
+	userGetter                driven.UserGetter
+	userWriter                driven.UserWriter
+	emailSender               driven.EmailSender
+	verificationTokenProvider driven.VerificationTokenProvider
+	verificationURL           string
+}
+
+func NewEmailUsecase(
+	userGetter driven.UserGetter,
+	userWriter driven.UserWriter,
+	emailSender driven.EmailSender,
+	verificationTokenProvider driven.VerificationTokenProvider,
+	verificationURL string,
+) *EmailUsecase {
+	return &EmailUsecase{
+		userGetter:                userGetter,
+		userWriter:                userWriter,
+		emailSender:               emailSender,
+		verificationTokenProvider: verificationTokenProvider,
+		verificationURL:           verificationURL,
+	}
+}
+
+func (eu EmailUsecase) ChangeEmailByID(ctx context.Context, id string, params *request.ChangeEmail) (err error) {
+	ctx, span := tracing.Start(ctx, "EmailUsecase.ChangeEmailByID")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := eu.userGetter.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	changedUser := &entity.User{
+		ID:       id,
+		FullName: user.FullName,
+	}
+	if err := changedUser.ChangeEmail(params.Email); err != nil {
+		return err
+	}
+
+	err = eu.userWriter.UpdateEmailByID(ctx, id, changedUser.Email)
+	if err != nil {
+		return err
+	}
+
+	return eu.sendVerificationEmail(ctx, changedUser)
+}
+
+func (eu EmailUsecase) VerifyEmail(ctx context.Context, token string) (_ *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "EmailUsecase.VerifyEmail")
+	defer func() { tracing.End(span, err) }()
+
+	userID, email, err := eu.verificationTokenProvider.Validate(token)
+	if err != nil {
+		return nil, customerror.NewValidationErrorWithCode("token", customerror.CodeInvalidVerificationToken, nil)
+	}
+
+	// the email could be verified by another user after the token was sent, only one of them can own it
+	owner, err := eu.userGetter.GetByEmail(ctx, email)
+	if err == nil && owner.EmailVerified && owner.ID != userID {
+		return nil, customerror.NewValidationErrorWithCode("email", customerror.CodeTaken, nil)
+	} else if err != nil && !errors.Is(err, driven.ErrNotFound) {
+		return nil, err
+	}
+
+	// the email could already be changed after the token was sent
+	err = eu.userWriter.VerifyEmailByID(ctx, userID, email)
+	if errors.Is(err, driven.ErrNotFound) {
+		return nil, customerror.NewValidationErrorWithCode("token", customerror.CodeInvalidVerificationToken, nil)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return eu.userGetter.GetByID(ctx, userID)
+}
+
+func (eu EmailUsecase) sendVerificationEmail(ctx context.Context, user *entity.User) error {
+	token, err := eu.verificationTokenProvider.Generate(user.ID, user.Email)
+	if err != nil {
+		return err
+	}
+
+	verificationURL, err := url.Parse(eu.verificationURL)
+	if err != nil {
+		return err
+	}
+	query := verificationURL.Query()
+	query.Set("token", token)
+	verificationURL.RawQuery = query.Encode()
+
+	return eu.emailSender.Send(ctx, user.Email, verificationEmailSubject, fmt.Sprintf(verificationEmailBody, user.FullName, verificationURL.String()))
+}
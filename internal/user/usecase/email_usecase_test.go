@@ -0,0 +1,200 @@
+package usecase_test
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"userservice/config"
+	"userservice/infrastructure"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEmailVerificationTokenProvider() *infrastructure.EmailVerificationTokenProvider {
+	return infrastructure.NewEmailVerificationTokenProvider(&config.Email{
+		VerificationSecret:        "secret",
+		VerificationExpiresSecond: 60,
+	})
+}
+
+func TestEmailUsecase_ChangeEmailByID(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	user := &entity.User{
+		PhoneNumber: "+628123123123",
+		FullName:    faker.Name(),
+	}
+	id, _ := fakeUserDriven.Create(context.Background(), user)
+
+	type args struct {
+		ctx    context.Context
+		id     string
+		params *request.ChangeEmail
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantErr    bool
+		wantErrMsg string
+		wantEmail  string
+	}{
+		{
+			name: "when user not found, it should return error",
+			args: args{
+				context.Background(),
+				faker.UUIDHyphenated(),
+				&request.ChangeEmail{Email: faker.Email()},
+			},
+			wantErr:    true,
+			wantErrMsg: "resource not found",
+		},
+		{
+			name: "when email not valid, it should return error",
+			args: args{
+				context.Background(),
+				id,
+				&request.ChangeEmail{Email: "John Doe <john@example.com>"},
+			},
+			wantErr:    true,
+			wantErrMsg: "email: must be a valid email address",
+		},
+		{
+			name: "when failed to send email, it should return error",
+			args: args{
+				context.WithValue(context.Background(), "email_error", true),
+				id,
+				&request.ChangeEmail{Email: "john@example.com"},
+			},
+			wantErr:    true,
+			wantErrMsg: "error",
+		},
+		{
+			name: "when email valid, it should save normalized email and send verification",
+			args: args{
+				context.Background(),
+				id,
+				&request.ChangeEmail{Email: "  John.Doe@Example.COM "},
+			},
+			wantErr:   false,
+			wantEmail: "john.doe@example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emailSender := new(fake.FakeEmailSender)
+			tokenProvider := newEmailVerificationTokenProvider()
+			eu := usecase.NewEmailUsecase(fakeUserDriven, fakeUserDriven, emailSender, tokenProvider, "http://localhost/verify")
+			err := eu.ChangeEmailByID(tt.args.ctx, tt.args.id, tt.args.params)
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Equal(tt.wantErrMsg, err.Error())
+				return
+			}
+
+			gotUser, _ := fakeUserDriven.GetByID(tt.args.ctx, tt.args.id)
+			assert.Equal(tt.wantEmail, gotUser.Email)
+			assert.False(gotUser.EmailVerified)
+
+			assert.Len(emailSender.Emails, 1)
+			assert.Equal(tt.wantEmail, emailSender.Emails[0].To)
+
+			link := regexp.MustCompile(`http://localhost/verify\?token=\S+`).FindString(emailSender.Emails[0].Body)
+			verificationURL, err := url.Parse(link)
+			assert.NoError(err)
+			gotUserID, gotEmail, err := tokenProvider.Validate(verificationURL.Query().Get("token"))
+			assert.NoError(err)
+			assert.Equal(tt.args.id, gotUserID)
+			assert.Equal(tt.wantEmail, gotEmail)
+		})
+	}
+}
+
+func TestEmailUsecase_VerifyEmail(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	tokenProvider := newEmailVerificationTokenProvider()
+	user := &entity.User{
+		PhoneNumber: "+628123123123",
+		FullName:    faker.Name(),
+		Email:       "john@example.com",
+	}
+	id, _ := fakeUserDriven.Create(context.Background(), user)
+
+	validToken, _ := tokenProvider.Generate(id, "john@example.com")
+	oldEmailToken, _ := tokenProvider.Generate(id, "old@example.com")
+	otherSecretToken, _ := infrastructure.NewEmailVerificationTokenProvider(&config.Email{
+		VerificationSecret:        "other-secret",
+		VerificationExpiresSecond: 60,
+	}).Generate(id, "john@example.com")
+	expiredToken, _ := infrastructure.NewEmailVerificationTokenProvider(&config.Email{
+		VerificationSecret:        "secret",
+		VerificationExpiresSecond: -60,
+	}).Generate(id, "john@example.com")
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:    "when token signed by other secret, it should return error",
+			token:   otherSecretToken,
+			wantErr: true,
+		},
+		{
+			name:    "when token expired, it should return error",
+			token:   expiredToken,
+			wantErr: true,
+		},
+		{
+			name:    "when email changed after token sent, it should return error",
+			token:   oldEmailToken,
+			wantErr: true,
+		},
+		{
+			name:    "when token valid, it should mark email as verified",
+			token:   validToken,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eu := usecase.NewEmailUsecase(fakeUserDriven, fakeUserDriven, new(fake.FakeEmailSender), tokenProvider, "http://localhost/verify")
+			got, err := eu.VerifyEmail(context.Background(), tt.token)
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Equal("token: invalid or expired verification token", err.Error())
+				assert.Nil(got)
+			} else {
+				assert.Equal(id, got.ID)
+				assert.True(got.EmailVerified)
+			}
+		})
+	}
+}
+
+func TestEmailUsecase_VerifyEmail_Taken(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	tokenProvider := newEmailVerificationTokenProvider()
+	owner := &entity.User{PhoneNumber: "+628123123123", FullName: faker.Name(), Email: "john@example.com"}
+	ownerID, _ := fakeUserDriven.Create(context.Background(), owner)
+	fakeUserDriven.VerifyEmailByID(context.Background(), ownerID, "john@example.com")
+	user := &entity.User{PhoneNumber: "+628123123124", FullName: faker.Name(), Email: "john@example.com"}
+	id, _ := fakeUserDriven.Create(context.Background(), user)
+	token, _ := tokenProvider.Generate(id, "john@example.com")
+	eu := usecase.NewEmailUsecase(fakeUserDriven, fakeUserDriven, new(fake.FakeEmailSender), tokenProvider, "http://localhost/verify")
+
+	got, err := eu.VerifyEmail(context.Background(), token)
+
+	assert := assert.New(t)
+	assert.Nil(got)
+	assert.EqualError(err, "email: is already taken")
+	assert.False(user.EmailVerified)
+}
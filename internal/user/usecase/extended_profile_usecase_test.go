@@ -0,0 +1,233 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserUsecase_UpdateExtendedProfileByID(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+
+	type args struct {
+		ctx    context.Context
+		params *request.UpdateExtendedProfile
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "when all params empty it should return error",
+			args: args{
+				context.Background(),
+				&request.UpdateExtendedProfile{},
+			},
+			wantErr:    true,
+			wantErrMsg: "avatarUrl: at least 1 field must be present;dateOfBirth: at least 1 field must be present;locale: at least 1 field must be present;timezone: at least 1 field must be present;address: at least 1 field must be present",
+		},
+		{
+			name: "when avatarUrl not http url it should return error",
+			args: args{
+				context.Background(),
+				&request.UpdateExtendedProfile{AvatarURL: ptr("ftp://example.com/avatar.png")},
+			},
+			wantErr:    true,
+			wantErrMsg: "avatarUrl: must be an absolute http or https url",
+		},
+		{
+			name: "when avatarUrl too long it should return error",
+			args: args{
+				context.Background(),
+				&request.UpdateExtendedProfile{AvatarURL: ptr("https://example.com/" + strings.Repeat("a", 2048))},
+			},
+			wantErr:    true,
+			wantErrMsg: "avatarUrl: must be at most 2048 characters in length",
+		},
+		{
+			name: "when dateOfBirth not valid date it should return error",
+			args: args{
+				context.Background(),
+				&request.UpdateExtendedProfile{DateOfBirth: ptr("1990-02-30")},
+			},
+			wantErr:    true,
+			wantErrMsg: "dateOfBirth: must be a valid date in YYYY-MM-DD format",
+		},
+		{
+			name: "when dateOfBirth in the future it should return error",
+			args: args{
+				context.Background(),
+				&request.UpdateExtendedProfile{DateOfBirth: ptr(tomorrow)},
+			},
+			wantErr:    true,
+			wantErrMsg: "dateOfBirth: must be in the past and within the last 150 years",
+		},
+		{
+			name: "when locale not language tag it should return error",
+			args: args{
+				context.Background(),
+				&request.UpdateExtendedProfile{Locale: ptr("indonesia")},
+			},
+			wantErr:    true,
+			wantErrMsg: "locale: must be a language tag like 'id' or 'en-US'",
+		},
+		{
+			name: "when timezone unknown it should return error",
+			args: args{
+				context.Background(),
+				&request.UpdateExtendedProfile{Timezone: ptr("Asia/Atlantis")},
+			},
+			wantErr:    true,
+			wantErrMsg: "timezone: must be a valid IANA time zone like 'Asia/Jakarta'",
+		},
+		{
+			name: "when address not valid it should return all address error",
+			args: args{
+				context.Background(),
+				&request.UpdateExtendedProfile{Address: &request.Address{
+					Street:     strings.Repeat("a", 256),
+					PostalCode: "12345!",
+					Country:    "Indonesia",
+				}},
+			},
+			wantErr:    true,
+			wantErrMsg: "address.street: must be at most 255 characters in length;address.postalCode: must be at most 10 letters or numbers;address.country: must be an ISO 3166-1 alpha-2 country code like 'ID'",
+		},
+		{
+			name: "when got error from getter it should return error",
+			args: args{
+				context.WithValue(context.Background(), "profile_error", true),
+				&request.UpdateExtendedProfile{Locale: ptr("id-ID")},
+			},
+			wantErr:    true,
+			wantErrMsg: "error",
+		},
+		{
+			name: "when all valid it should save profile",
+			args: args{
+				context.Background(),
+				&request.UpdateExtendedProfile{
+					AvatarURL:   ptr("https://example.com/avatar.png"),
+					DateOfBirth: ptr("1990-12-31"),
+					Locale:      ptr("id-ID"),
+					Timezone:    ptr("Asia/Jakarta"),
+					Address: &request.Address{
+						Street:     "Jl. Sudirman No. 1",
+						City:       "Jakarta",
+						Province:   "DKI Jakarta",
+						PostalCode: "10220",
+						Country:    "ID",
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeUserDriven := fake.NewFakeUserDriven()
+			id := faker.UUIDHyphenated()
+			uu := usecase.NewUserUsecase(fakeUserDriven, nil, fakeUserDriven, nil, fake.NewFakeMetrics())
+			got, err := uu.UpdateExtendedProfileByID(tt.args.ctx, id, tt.args.params)
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.True(assertMessagesEqual(tt.wantErrMsg, err.Error()))
+				return
+			}
+
+			saved, err := fakeUserDriven.GetProfileByUserID(tt.args.ctx, id)
+			assert.NoError(err)
+			assert.Equal(got, saved)
+			assert.Equal("1990-12-31", saved.DateOfBirth.Format("2006-01-02"))
+		})
+	}
+}
+
+func TestUserUsecase_UpdateExtendedProfileByID_onlyChangeGivenFields(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	uu := usecase.NewUserUsecase(fakeUserDriven, nil, fakeUserDriven, nil, fake.NewFakeMetrics())
+	assert := assert.New(t)
+	id := faker.UUIDHyphenated()
+
+	locale := "id-ID"
+	avatarURL := "https://example.com/avatar.png"
+	_, err := uu.UpdateExtendedProfileByID(context.Background(), id, &request.UpdateExtendedProfile{
+		Locale:    &locale,
+		AvatarURL: &avatarURL,
+	})
+	assert.NoError(err)
+
+	timezone := "Asia/Jakarta"
+	emptyAvatarURL := ""
+	got, err := uu.UpdateExtendedProfileByID(context.Background(), id, &request.UpdateExtendedProfile{
+		Timezone:  &timezone,
+		AvatarURL: &emptyAvatarURL,
+	})
+	assert.NoError(err)
+	assert.Equal(locale, got.Locale)
+	assert.Equal(timezone, got.Timezone)
+	assert.Empty(got.AvatarURL)
+}
+
+func TestUserGetterUsecase_GetProfileByUserID(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	id := faker.UUIDHyphenated()
+	fakeUserDriven.UpsertProfile(context.Background(), &entity.Profile{
+		UserID: id,
+		Locale: "id-ID",
+	})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		userID  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "when got error from getter it should return error",
+			ctx:     context.WithValue(context.Background(), "profile_error", true),
+			userID:  id,
+			wantErr: true,
+		},
+		{
+			name:    "when profile never filled it should return empty profile",
+			ctx:     context.Background(),
+			userID:  faker.UUIDHyphenated(),
+			want:    "",
+			wantErr: false,
+		},
+		{
+			name:    "when profile found it should return profile",
+			ctx:     context.Background(),
+			userID:  id,
+			want:    "id-ID",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ug := usecase.NewUserGetterUsecase(fakeUserDriven)
+			got, err := ug.GetProfileByUserID(tt.ctx, tt.userID)
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(tt.userID, got.UserID)
+				assert.Equal(tt.want, got.Locale)
+			}
+		})
+	}
+}
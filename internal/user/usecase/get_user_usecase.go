@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
 
+	"userservice/internal/tracing"
 	"userservice/internal/user/entity"
 	"userservice/internal/user/port/driven"
 )
@@ -17,6 +19,53 @@ func NewUserGetterUsecase(userGetter driven.UserGetter) *UserGetterUsecase {
 	}
 }
 
-func (ug UserGetterUsecase) GetUserByID(ctx context.Context, id string) (*entity.User, error) {
+func (ug UserGetterUsecase) GetUserByID(ctx context.Context, id string) (_ *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserGetterUsecase.GetUserByID")
+	defer func() { tracing.End(span, err) }()
+
 	return ug.userGetter.GetByID(ctx, id)
 }
+
+// GetProfileByUserID returns an empty profile when the user never fill it.
+func (ug UserGetterUsecase) GetProfileByUserID(ctx context.Context, userID string) (_ *entity.Profile, err error) {
+	ctx, span := tracing.Start(ctx, "UserGetterUsecase.GetProfileByUserID")
+	defer func() { tracing.End(span, err) }()
+
+	profile, err := ug.userGetter.GetProfileByUserID(ctx, userID)
+	if errors.Is(err, driven.ErrNotFound) {
+		return &entity.Profile{
+			UserID: userID,
+		}, nil
+	}
+	return profile, err
+}
+
+// GetPublicProfileByID returns the user fields allowed by the privacy settings of the user.
+func (ug UserGetterUsecase) GetPublicProfileByID(ctx context.Context, id string) (_ *entity.PublicProfile, err error) {
+	ctx, span := tracing.Start(ctx, "UserGetterUsecase.GetPublicProfileByID")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := ug.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := ug.GetPrivacySettingsByUserID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewPublicProfile(user, settings), nil
+}
+
+// GetPrivacySettingsByUserID returns the default settings when the user never change it.
+func (ug UserGetterUsecase) GetPrivacySettingsByUserID(ctx context.Context, userID string) (_ *entity.PrivacySettings, err error) {
+	ctx, span := tracing.Start(ctx, "UserGetterUsecase.GetPrivacySettingsByUserID")
+	defer func() { tracing.End(span, err) }()
+
+	settings, err := ug.userGetter.GetPrivacySettingsByUserID(ctx, userID)
+	if errors.Is(err, driven.ErrNotFound) {
+		return entity.DefaultPrivacySettings(userID), nil
+	}
+	return settings, err
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/param/response"
+	"userservice/internal/user/port/driven"
+)
+
+type InternalUserUsecase struct {
+	userGetter      driven.UserGetter
+	batchGetMaxSize int
+}
+
+func NewInternalUserUsecase(userGetter driven.UserGetter, batchGetMaxSize int) *InternalUserUsecase {
+	return &InternalUserUsecase{
+		userGetter:      userGetter,
+		batchGetMaxSize: batchGetMaxSize,
+	}
+}
+
+// BatchGetUsers finds the users by ids and phone numbers with at most 1 query each,
+// a user found by both id and phone number is only returned once.
+// The invalid phone numbers are reported as missing instead of failing the whole batch.
+func (iu InternalUserUsecase) BatchGetUsers(ctx context.Context, params *request.BatchGetUsers) (_ *response.BatchGetUsers, err error) {
+	ctx, span := tracing.Start(ctx, "InternalUserUsecase.BatchGetUsers")
+	defer func() { tracing.End(span, err) }()
+
+	total := len(params.IDs) + len(params.PhoneNumbers)
+	if total == 0 {
+		return nil, customerror.NewValidationErrorWithCode("ids", customerror.CodeAtLeastOneIDOrPhoneNumber, nil)
+	}
+	if total > iu.batchGetMaxSize {
+		return nil, customerror.NewValidationErrorWithCode(
+			"ids",
+			customerror.CodeBatchMaxSize,
+			customerror.Params{"max": iu.batchGetMaxSize},
+		)
+	}
+
+	result := &response.BatchGetUsers{
+		Users:               []*entity.User{},
+		MissingIDs:          []string{},
+		MissingPhoneNumbers: []string{},
+	}
+	foundIDs := make(map[string]bool)
+
+	if ids := unique(params.IDs); len(ids) > 0 {
+		users, err := iu.userGetter.GetByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			foundIDs[user.ID] = true
+			result.Users = append(result.Users, user)
+		}
+		for _, id := range ids {
+			if !foundIDs[id] {
+				result.MissingIDs = append(result.MissingIDs, id)
+			}
+		}
+	}
+
+	rawPhoneNumbers := unique(params.PhoneNumbers)
+	normalized := make(map[string]string, len(rawPhoneNumbers))
+	phoneNumbers := []string{}
+	for _, raw := range rawPhoneNumbers {
+		phoneNumber, err := entity.ParsePhoneNumber(raw)
+		if err != nil {
+			continue
+		}
+		normalized[raw] = phoneNumber.E164()
+		phoneNumbers = append(phoneNumbers, phoneNumber.E164())
+	}
+
+	foundPhoneNumbers := make(map[string]bool)
+	if len(phoneNumbers) > 0 {
+		users, err := iu.userGetter.GetByPhoneNumbers(ctx, unique(phoneNumbers))
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			foundPhoneNumbers[user.PhoneNumber] = true
+			if !foundIDs[user.ID] {
+				foundIDs[user.ID] = true
+				result.Users = append(result.Users, user)
+			}
+		}
+	}
+	for _, raw := range rawPhoneNumbers {
+		if !foundPhoneNumbers[normalized[raw]] {
+			result.MissingPhoneNumbers = append(result.MissingPhoneNumbers, raw)
+		}
+	}
+
+	return result, nil
+}
+
+// unique removes the duplicated values and keeps the order of the first occurrences.
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
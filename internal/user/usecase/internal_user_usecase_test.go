@@ -0,0 +1,116 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInternalUserUsecase_BatchGetUsers(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	firstID, _ := fakeUserDriven.Create(context.Background(), &entity.User{
+		FullName:    faker.Name(),
+		PhoneNumber: "+628123456789",
+	})
+	secondID, _ := fakeUserDriven.Create(context.Background(), &entity.User{
+		FullName:    faker.Name(),
+		PhoneNumber: "+628987654321",
+	})
+	missingID := faker.UUIDHyphenated()
+
+	tests := []struct {
+		name                    string
+		ctx                     context.Context
+		params                  *request.BatchGetUsers
+		wantUserIDs             []string
+		wantMissingIDs          []string
+		wantMissingPhoneNumbers []string
+		wantErrMsg              string
+	}{
+		{
+			name:       "when nothing requested, it should return error",
+			ctx:        context.Background(),
+			params:     &request.BatchGetUsers{},
+			wantErrMsg: "ids: at least 1 id or phone number must be present",
+		},
+		{
+			name: "when too many requested, it should return error",
+			ctx:  context.Background(),
+			params: &request.BatchGetUsers{
+				IDs:          []string{firstID, secondID},
+				PhoneNumbers: []string{"+628123456789", "+628987654321"},
+			},
+			wantErrMsg: "ids: must be at most 3 ids and phone numbers in total",
+		},
+		{
+			name: "when failed to get users, it should return error",
+			ctx:  context.WithValue(context.Background(), "batch_error", true),
+			params: &request.BatchGetUsers{
+				IDs: []string{firstID},
+			},
+			wantErrMsg: "error",
+		},
+		{
+			name: "when some ids not found, it should return found users and missing ids",
+			ctx:  context.Background(),
+			params: &request.BatchGetUsers{
+				IDs: []string{firstID, missingID, firstID},
+			},
+			wantUserIDs:             []string{firstID},
+			wantMissingIDs:          []string{missingID},
+			wantMissingPhoneNumbers: []string{},
+		},
+		{
+			name: "when phone numbers in national format or invalid, it should normalize and report invalid as missing",
+			ctx:  context.Background(),
+			params: &request.BatchGetUsers{
+				PhoneNumbers: []string{"0812-3456-789", "+628111111111", "not a phone"},
+			},
+			wantUserIDs:             []string{firstID},
+			wantMissingIDs:          []string{},
+			wantMissingPhoneNumbers: []string{"+628111111111", "not a phone"},
+		},
+		{
+			name: "when user found by id and phone number, it should only return the user once",
+			ctx:  context.Background(),
+			params: &request.BatchGetUsers{
+				IDs:          []string{firstID},
+				PhoneNumbers: []string{"+628123456789", "+628987654321"},
+			},
+			wantUserIDs:             []string{firstID, secondID},
+			wantMissingIDs:          []string{},
+			wantMissingPhoneNumbers: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iu := usecase.NewInternalUserUsecase(fakeUserDriven, 3)
+
+			got, err := iu.BatchGetUsers(tt.ctx, tt.params)
+
+			assert := assert.New(t)
+			if tt.wantErrMsg != "" {
+				assert.Error(err)
+				assert.Equal(tt.wantErrMsg, err.Error())
+				assert.Nil(got)
+				return
+			}
+
+			assert.NoError(err)
+			gotUserIDs := []string{}
+			for _, user := range got.Users {
+				gotUserIDs = append(gotUserIDs, user.ID)
+			}
+			assert.Equal(tt.wantUserIDs, gotUserIDs)
+			assert.Equal(tt.wantMissingIDs, got.MissingIDs)
+			assert.Equal(tt.wantMissingPhoneNumbers, got.MissingPhoneNumbers)
+		})
+	}
+}
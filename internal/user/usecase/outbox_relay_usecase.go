@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"userservice/internal/user/port/driven"
+)
+
+// defaultOutboxLockDuration is used when the lock is not set, a zero lock would publish a claimed event twice
+const defaultOutboxLockDuration = 30 * time.Second
+
+type OutboxRelayUsecase struct {
+	outbox       driven.EventOutbox
+	publisher    driven.EventPublisher
+	loop         pollLoop
+	lockDuration time.Duration
+}
+
+func NewOutboxRelayUsecase(
+	outbox driven.EventOutbox,
+	publisher driven.EventPublisher,
+	pollIntervalMillisecond int,
+	batchSize int,
+	lockSecond int,
+) *OutboxRelayUsecase {
+	lockDuration := time.Duration(lockSecond) * time.Second
+	if lockDuration <= 0 {
+		lockDuration = defaultOutboxLockDuration
+	}
+	return &OutboxRelayUsecase{
+		outbox:       outbox,
+		publisher:    publisher,
+		loop:         newPollLoop("outbox_relay", pollIntervalMillisecond, batchSize),
+		lockDuration: lockDuration,
+	}
+}
+
+// RelayEvents publishes the claimed events in the order they occurred.
+// It stops at the first failed event so the later events of the same user are not published before it,
+// the rest of the batch is retried after the lock duration.
+func (or OutboxRelayUsecase) RelayEvents(ctx context.Context) (int, error) {
+	events, err := or.outbox.ClaimUnpublished(ctx, or.loop.batchSize, or.lockDuration)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, event := range events {
+		if err := or.publisher.Publish(ctx, event); err != nil {
+			if markErr := or.outbox.MarkFailed(ctx, event.ID, err.Error()); markErr != nil {
+				return i, markErr
+			}
+			return i, err
+		}
+		// when it fails the event is published again after the lock duration, that is the at-least-once
+		if err := or.outbox.MarkPublished(ctx, event.ID); err != nil {
+			return i, err
+		}
+	}
+	return len(events), nil
+}
+
+// Run relays the events on every poll interval until the context is done.
+func (or OutboxRelayUsecase) Run(ctx context.Context) {
+	or.loop.run(ctx, or.RelayEvents)
+}
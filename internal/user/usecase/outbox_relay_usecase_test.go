@@ -0,0 +1,120 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxRelayUsecase_RelayEvents(t *testing.T) {
+	newEvents := func(aggregateIDs ...string) []*entity.Event {
+		events := []*entity.Event{}
+		for i, aggregateID := range aggregateIDs {
+			event := entity.NewUserLoggedInEvent(aggregateID)
+			event.OccurredAt = time.Now().Add(time.Duration(i) * time.Second)
+			events = append(events, event)
+		}
+		return events
+	}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		events        []*entity.Event
+		wantPublished int
+		wantFailed    int
+		wantErr       bool
+	}{
+		{
+			name:    "when failed to claim events, it should return error",
+			ctx:     context.WithValue(context.Background(), "outbox_error", true),
+			wantErr: true,
+		},
+		{
+			name:          "when there is no event, it should publish nothing",
+			ctx:           context.Background(),
+			wantPublished: 0,
+		},
+		{
+			name:          "when all events published, it should mark them published up to the batch size",
+			ctx:           context.Background(),
+			events:        newEvents("1", "2", "3", "4"),
+			wantPublished: 3,
+		},
+		{
+			name:          "when an event failed, it should mark it failed and stop the batch",
+			ctx:           context.Background(),
+			events:        newEvents("1", "broken", "3"),
+			wantPublished: 1,
+			wantFailed:    1,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := fake.NewFakeEventOutbox(tt.events...)
+			publisher := new(fake.FakeEventPublisher)
+			or := usecase.NewOutboxRelayUsecase(outbox, publisher, 1000, 3, 30)
+
+			got, err := or.RelayEvents(tt.ctx)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.Equal(tt.wantPublished, got)
+			assert.Len(publisher.Published, tt.wantPublished)
+			assert.Len(outbox.Published, tt.wantPublished)
+			assert.Len(outbox.Failed, tt.wantFailed)
+			for i, event := range publisher.Published {
+				assert.Equal(tt.events[i].ID, event.ID, "events should be published in order")
+			}
+		})
+	}
+}
+
+func TestOutboxRelayUsecase_Run(t *testing.T) {
+	events := []*entity.Event{}
+	for i := 0; i < 5; i++ {
+		events = append(events, entity.NewUserCreatedEvent(&entity.User{ID: "1"}))
+	}
+	outbox := fake.NewFakeEventOutbox(events...)
+	publisher := new(fake.FakeEventPublisher)
+	or := usecase.NewOutboxRelayUsecase(outbox, publisher, 3600000, 2, 30)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	or.Run(ctx)
+
+	// the full batches are relayed without waiting for the interval
+	assert.Len(t, publisher.Published, 5)
+}
+
+func TestOutboxRelayUsecase_Run_withoutIntervalAndBatchSize(t *testing.T) {
+	outbox := fake.NewFakeEventOutbox(entity.NewUserCreatedEvent(&entity.User{ID: "1"}))
+	publisher := new(fake.FakeEventPublisher)
+	// the defaults are used instead of a zero ticker and a busy loop on empty batches
+	or := usecase.NewOutboxRelayUsecase(outbox, publisher, 0, 0, 30)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.NotPanics(t, func() { or.Run(ctx) })
+
+	assert.Len(t, publisher.Published, 1)
+}
+
+func TestOutboxRelayUsecase_RelayEvents_withoutLock(t *testing.T) {
+	outbox := fake.NewFakeEventOutbox(entity.NewUserCreatedEvent(&entity.User{ID: "1"}))
+	or := usecase.NewOutboxRelayUsecase(outbox, new(fake.FakeEventPublisher), 1000, 10, 0)
+
+	_, err := or.RelayEvents(context.Background())
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	// the default is used instead of a lock which expires as soon as the events are claimed
+	assert.Equal(30*time.Second, outbox.LockDuration)
+}
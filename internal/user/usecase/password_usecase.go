@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/logging"
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/port/driven"
+)
+
+type PasswordUsecase struct {
+	userGetter           driven.UserGetter
+	userWriter           driven.UserWriter
+	encryptor            driven.Encyptor
+	passwordHistory      driven.PasswordHistory
+	passwordHistoryLimit int
+}
+
+func NewPasswordUsecase(
+	userGetter driven.UserGetter,
+	userWriter driven.UserWriter,
+	encryptor driven.Encyptor,
+	passwordHistory driven.PasswordHistory,
+	passwordHistoryLimit int,
+) *PasswordUsecase {
+	return &PasswordUsecase{
+		userGetter:           userGetter,
+		userWriter:           userWriter,
+		encryptor:            encryptor,
+		passwordHistory:      passwordHistory,
+		passwordHistoryLimit: passwordHistoryLimit,
+	}
+}
+
+func (pu PasswordUsecase) ChangePasswordByID(ctx context.Context, id string, params *request.ChangePassword) (err error) {
+	ctx, span := tracing.Start(ctx, "PasswordUsecase.ChangePasswordByID")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := pu.userGetter.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = pu.encryptor.CompareEncryptedAndData(ctx, []byte(user.Password), []byte(params.CurrentPassword))
+	if err != nil {
+		return customerror.NewValidationErrorWithCode("currentPassword", customerror.CodeWrongPassword, nil)
+	}
+
+	currentPassword := user.Password
+	usedPasswords := []string{currentPassword}
+	if pu.passwordHistoryLimit > 0 {
+		history, err := pu.passwordHistory.GetLatestByUserID(ctx, id, pu.passwordHistoryLimit)
+		if err != nil {
+			return err
+		}
+		usedPasswords = append(usedPasswords, history...)
+	}
+
+	changedUser := &entity.User{
+		ID: id,
+	}
+	if err := changedUser.ChangePassword(params.NewPassword); err != nil {
+		return err
+	}
+
+	// compare against every stored hash since bcrypt hashes are salted
+	for _, usedPassword := range usedPasswords {
+		if pu.encryptor.CompareEncryptedAndData(ctx, []byte(usedPassword), []byte(changedUser.Password)) == nil {
+			return customerror.NewValidationErrorWithCode("password", customerror.CodeRecentlyUsedPassword, nil)
+		}
+	}
+
+	encryptedPassword, err := pu.encryptor.Encrypt(ctx, []byte(changedUser.Password), costEncryption)
+	if err != nil {
+		return err
+	}
+
+	err = pu.userWriter.UpdatePasswordByID(ctx, id, string(encryptedPassword), currentPassword, pu.passwordHistoryLimit)
+	if err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("password changed", "user_id", id)
+	return nil
+}
@@ -0,0 +1,185 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"userservice/infrastructure"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordUsecase_ChangePasswordByID(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+
+	previousPassword := generateRandomPassword(12)
+	encryptedPreviousPassword, _ := bcrypt.GenerateFromPassword([]byte(previousPassword), bcrypt.MinCost)
+	user := &entity.User{
+		PhoneNumber: "+628123123123",
+		FullName:    faker.Name(),
+		Password:    string(encryptedPreviousPassword),
+	}
+	id, _ := fakeUserDriven.Create(context.Background(), user)
+
+	currentPassword := generateRandomPassword(12)
+	encryptedCurrentPassword, _ := bcrypt.GenerateFromPassword([]byte(currentPassword), bcrypt.MinCost)
+	fakeUserDriven.UpdatePasswordByID(context.Background(), id, string(encryptedCurrentPassword), string(encryptedPreviousPassword), 5)
+
+	newPassword := generateRandomPassword(12)
+
+	type args struct {
+		ctx    context.Context
+		id     string
+		params *request.ChangePassword
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "when user not found, it should return error",
+			args: args{
+				context.Background(),
+				faker.UUIDHyphenated(),
+				&request.ChangePassword{
+					CurrentPassword: currentPassword,
+					NewPassword:     newPassword,
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "resource not found",
+		},
+		{
+			name: "when current password is wrong, it should return error",
+			args: args{
+				context.Background(),
+				id,
+				&request.ChangePassword{
+					CurrentPassword: faker.Password(),
+					NewPassword:     newPassword,
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "currentPassword: wrong password",
+		},
+		{
+			name: "when new password not match with criteria, it should return error",
+			args: args{
+				context.Background(),
+				id,
+				&request.ChangePassword{
+					CurrentPassword: currentPassword,
+					NewPassword:     "abc123.asd",
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "password: containing at least 1 capital characters AND 1 number AND 1 special (nonalpha-numeric) characters",
+		},
+		{
+			name: "when new password same as current password, it should return error",
+			args: args{
+				context.Background(),
+				id,
+				&request.ChangePassword{
+					CurrentPassword: currentPassword,
+					NewPassword:     currentPassword,
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "password: must be different from recently used passwords",
+		},
+		{
+			name: "when new password found in password history, it should return error",
+			args: args{
+				context.Background(),
+				id,
+				&request.ChangePassword{
+					CurrentPassword: currentPassword,
+					NewPassword:     previousPassword,
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "password: must be different from recently used passwords",
+		},
+		{
+			name: "when got error from password history, it should return error",
+			args: args{
+				context.WithValue(context.Background(), "password_history_error", true),
+				id,
+				&request.ChangePassword{
+					CurrentPassword: currentPassword,
+					NewPassword:     newPassword,
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "error",
+		},
+		{
+			name: "when all valid, it should update password",
+			args: args{
+				context.Background(),
+				id,
+				&request.ChangePassword{
+					CurrentPassword: currentPassword,
+					NewPassword:     newPassword,
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pu := usecase.NewPasswordUsecase(fakeUserDriven, fakeUserDriven, new(infrastructure.BcyrpEncryption), fakeUserDriven, 5)
+			err := pu.ChangePasswordByID(tt.args.ctx, tt.args.id, tt.args.params)
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Equal(tt.wantErrMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestPasswordUsecase_ChangePasswordByID_withPasswordHistoryRecorded(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	encryptor := new(infrastructure.BcyrpEncryption)
+	assert := assert.New(t)
+
+	currentPassword := generateRandomPassword(12)
+	encryptedCurrentPassword, _ := bcrypt.GenerateFromPassword([]byte(currentPassword), bcrypt.MinCost)
+	user := &entity.User{
+		PhoneNumber: "+628123123123",
+		FullName:    faker.Name(),
+		Password:    string(encryptedCurrentPassword),
+	}
+	id, _ := fakeUserDriven.Create(context.Background(), user)
+
+	pu := usecase.NewPasswordUsecase(fakeUserDriven, fakeUserDriven, encryptor, fakeUserDriven, 1)
+	newPassword := generateRandomPassword(12)
+	err := pu.ChangePasswordByID(context.Background(), id, &request.ChangePassword{
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	})
+	assert.NoError(err)
+
+	gotUser, _ := fakeUserDriven.GetByID(context.Background(), id)
+	assert.NoError(encryptor.CompareEncryptedAndData(context.Background(), []byte(gotUser.Password), []byte(newPassword)))
+
+	history, _ := fakeUserDriven.GetLatestByUserID(context.Background(), id, 1)
+	assert.Equal([]string{string(encryptedCurrentPassword)}, history)
+
+	// the original password is still within the limit, so it cannot be reused
+	err = pu.ChangePasswordByID(context.Background(), id, &request.ChangePassword{
+		CurrentPassword: newPassword,
+		NewPassword:     currentPassword,
+	})
+	assert.Error(err)
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"userservice/internal/logging"
+)
+
+const (
+	defaultPollInterval  = time.Second
+	defaultPollBatchSize = 100
+)
+
+// pollLoop runs a background worker which handles the pending records in batches.
+type pollLoop struct {
+	name      string
+	interval  time.Duration
+	batchSize int
+}
+
+// newPollLoop falls back to the defaults when the interval or the batch size is not set,
+// time.NewTicker panics on a zero interval and an empty batch would never wait for the interval.
+func newPollLoop(name string, intervalMillisecond int, batchSize int) pollLoop {
+	interval := time.Duration(intervalMillisecond) * time.Millisecond
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultPollBatchSize
+	}
+	return pollLoop{
+		name:      name,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// run polls the batches until the context is done,
+// a full batch is followed immediately by the next one instead of waiting for the interval.
+func (pl pollLoop) run(ctx context.Context, poll func(ctx context.Context) (int, error)) {
+	ticker := time.NewTicker(pl.interval)
+	defer ticker.Stop()
+	for {
+		handled, err := poll(ctx)
+		// the batch interrupted by the shutdown is retried once its lock expires
+		if err != nil && ctx.Err() == nil {
+			logging.FromContext(ctx).Error("failed to poll batch", "worker", pl.name, "error", err)
+		}
+		if err == nil && pl.batchSize > 0 && handled == pl.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserUsecase_GetPublicProfileByID(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	user := &entity.User{
+		FullName:    faker.Name(),
+		PhoneNumber: "+628123456789",
+		Username:    "john_doe",
+		AvatarURL:   "http://localhost/blobs/avatars/original.png",
+	}
+	id, _ := fakeUserDriven.Create(context.Background(), user)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		id       string
+		settings *entity.PrivacySettings
+		want     *entity.PublicProfile
+		wantErr  bool
+	}{
+		{
+			name:    "when user not found, it should return error",
+			ctx:     context.Background(),
+			id:      faker.UUIDHyphenated(),
+			wantErr: true,
+		},
+		{
+			name:    "when failed to get privacy settings, it should return error",
+			ctx:     context.WithValue(context.Background(), "privacy_error", true),
+			id:      id,
+			wantErr: true,
+		},
+		{
+			name: "when settings never changed, it should hide phone number",
+			ctx:  context.Background(),
+			id:   id,
+			want: &entity.PublicProfile{
+				ID:        id,
+				Username:  "john_doe",
+				FullName:  user.FullName,
+				AvatarURL: user.AvatarURL,
+			},
+		},
+		{
+			name: "when all fields hidden, it should only return id and username",
+			ctx:  context.Background(),
+			id:   id,
+			settings: &entity.PrivacySettings{
+				UserID: id,
+			},
+			want: &entity.PublicProfile{
+				ID:       id,
+				Username: "john_doe",
+			},
+		},
+		{
+			name: "when phone number shown, it should return masked phone number",
+			ctx:  context.Background(),
+			id:   id,
+			settings: &entity.PrivacySettings{
+				UserID:          id,
+				ShowPhoneNumber: true,
+			},
+			want: &entity.PublicProfile{
+				ID:          id,
+				Username:    "john_doe",
+				PhoneNumber: "+6281*****789",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.settings != nil {
+				fakeUserDriven.UpsertPrivacySettings(context.Background(), tt.settings)
+			}
+			ug := usecase.NewUserGetterUsecase(fakeUserDriven)
+
+			got, err := ug.GetPublicProfileByID(tt.ctx, tt.id)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestUserUsecase_UpdatePrivacySettingsByID(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	id := faker.UUIDHyphenated()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		params  *request.UpdatePrivacySettings
+		wantErr bool
+	}{
+		{
+			name:    "when failed to save settings, it should return error",
+			ctx:     context.WithValue(context.Background(), "privacy_error", true),
+			params:  &request.UpdatePrivacySettings{},
+			wantErr: true,
+		},
+		{
+			name: "when success, it should save the settings",
+			ctx:  context.Background(),
+			params: &request.UpdatePrivacySettings{
+				ShowFullName:    false,
+				ShowAvatar:      true,
+				ShowPhoneNumber: true,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uu := usecase.NewUserUsecase(fakeUserDriven, nil, fakeUserDriven, nil, fake.NewFakeMetrics())
+
+			got, err := uu.UpdatePrivacySettingsByID(tt.ctx, id, tt.params)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Nil(got)
+				return
+			}
+
+			saved, _ := usecase.NewUserGetterUsecase(fakeUserDriven).GetPrivacySettingsByUserID(context.Background(), id)
+			assert.Equal(got, saved)
+			assert.Equal(tt.params.ShowFullName, saved.ShowFullName)
+			assert.Equal(tt.params.ShowAvatar, saved.ShowAvatar)
+			assert.Equal(tt.params.ShowPhoneNumber, saved.ShowPhoneNumber)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/param/response"
+	"userservice/internal/user/port/driven"
+)
+
+type UserSearchUsecase struct {
+	userSearcher driven.UserSearcher
+}
+
+func NewUserSearchUsecase(userSearcher driven.UserSearcher) *UserSearchUsecase {
+	return &UserSearchUsecase{
+		userSearcher: userSearcher,
+	}
+}
+
+// SearchUsers finds the users by similar full name or phone number prefix,
+// 1 more user than the limit is fetched to know whether there is a next page.
+func (us UserSearchUsecase) SearchUsers(ctx context.Context, params *request.SearchUsers) (_ *response.SearchUsers, err error) {
+	ctx, span := tracing.Start(ctx, "UserSearchUsecase.SearchUsers")
+	defer func() { tracing.End(span, err) }()
+
+	query, err := entity.NewUserSearchQuery(params.Query, params.Cursor, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	query.Limit++
+	found, err := us.userSearcher.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.SearchUsers{
+		Results: make([]*entity.UserSearchResult, 0, min(len(found), limit)),
+	}
+	for i, searchResult := range found {
+		if i == limit {
+			result.NextCursor = result.Results[limit-1].Cursor().Encode()
+			break
+		}
+		result.Results = append(result.Results, entity.NewUserSearchResult(searchResult.User, searchResult.Score, query))
+	}
+	return result, nil
+}
@@ -0,0 +1,131 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserSearchUsecase_SearchUsers(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	for _, user := range []*entity.User{
+		{FullName: "John Doe", PhoneNumber: "+628123456789"},
+		{FullName: "Johnny <Bravo>", PhoneNumber: "+628987654321"},
+		{FullName: "Jane Roe", PhoneNumber: "+628111111111"},
+	} {
+		fakeUserDriven.Create(context.Background(), user)
+	}
+
+	tests := []struct {
+		name                 string
+		ctx                  context.Context
+		params               *request.SearchUsers
+		wantFullNames        []string
+		wantHighlightedPhone string
+		wantErrMsg           string
+	}{
+		{
+			name:       "when query too short, it should return error",
+			ctx:        context.Background(),
+			params:     &request.SearchUsers{Query: " j "},
+			wantErrMsg: "q: must be between 2 and 64 characters in length",
+		},
+		{
+			name:       "when limit too big, it should return error",
+			ctx:        context.Background(),
+			params:     &request.SearchUsers{Query: "john", Limit: 101},
+			wantErrMsg: "limit: must be between 1 and 100",
+		},
+		{
+			name:       "when cursor invalid, it should return error",
+			ctx:        context.Background(),
+			params:     &request.SearchUsers{Query: "john", Cursor: "not a cursor"},
+			wantErrMsg: "cursor: is not valid",
+		},
+		{
+			name:       "when failed to search, it should return error",
+			ctx:        context.WithValue(context.Background(), "search_error", true),
+			params:     &request.SearchUsers{Query: "john"},
+			wantErrMsg: "error",
+		},
+		{
+			name:          "when found by name, it should highlight and escape the full name",
+			ctx:           context.Background(),
+			params:        &request.SearchUsers{Query: "joh"},
+			wantFullNames: []string{"<em>John</em> Doe", "<em>Johnny</em> &lt;Bravo&gt;"},
+		},
+		{
+			name:                 "when found by national phone number prefix, it should highlight the phone number",
+			ctx:                  context.Background(),
+			params:               &request.SearchUsers{Query: "0812-345"},
+			wantFullNames:        []string{"John Doe"},
+			wantHighlightedPhone: "<em>+62812345</em>6789",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			us := usecase.NewUserSearchUsecase(fakeUserDriven)
+
+			got, err := us.SearchUsers(tt.ctx, tt.params)
+
+			assert := assert.New(t)
+			if tt.wantErrMsg != "" {
+				assert.Error(err)
+				assert.Equal(tt.wantErrMsg, err.Error())
+				assert.Nil(got)
+				return
+			}
+
+			assert.NoError(err)
+			gotFullNames := []string{}
+			for _, result := range got.Results {
+				gotFullNames = append(gotFullNames, result.HighlightedFullName)
+			}
+			assert.ElementsMatch(tt.wantFullNames, gotFullNames)
+			assert.Empty(got.NextCursor)
+			if tt.wantHighlightedPhone != "" {
+				assert.Equal(tt.wantHighlightedPhone, got.Results[0].HighlightedPhoneNumber)
+			}
+		})
+	}
+}
+
+func TestUserSearchUsecase_SearchUsers_Pagination(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	for _, user := range []*entity.User{
+		{FullName: "John Doe", PhoneNumber: "+628123456789"},
+		{FullName: "Johnny Bravo", PhoneNumber: "+628987654321"},
+		{FullName: "Johan Roe", PhoneNumber: "+628111111111"},
+	} {
+		fakeUserDriven.Create(context.Background(), user)
+	}
+	us := usecase.NewUserSearchUsecase(fakeUserDriven)
+
+	assert := assert.New(t)
+	seen := map[string]bool{}
+	cursor := ""
+	for page := 1; page <= 2; page++ {
+		got, err := us.SearchUsers(context.Background(), &request.SearchUsers{Query: "jo", Cursor: cursor, Limit: 2})
+		assert.NoError(err)
+		for _, result := range got.Results {
+			assert.False(seen[result.User.ID], "user returned twice")
+			seen[result.User.ID] = true
+		}
+
+		if page == 1 {
+			assert.Len(got.Results, 2)
+			assert.NotEmpty(got.NextCursor)
+		} else {
+			assert.Len(got.Results, 1)
+			assert.Empty(got.NextCursor)
+		}
+		cursor = got.NextCursor
+	}
+	assert.Len(seen, 3)
+}
@@ -10,6 +10,7 @@ type UserUsecase struct {
 	encryptor     driven.Encyptor
 	userGetter    driven.UserGetter
 	tokenProvider driven.TokenProvider[*entity.User]
+	metrics       driven.Metrics
 }
 
 func NewUserUsecase(
@@ -17,11 +18,13 @@ func NewUserUsecase(
 	encryptor driven.Encyptor,
 	userGetter driven.UserGetter,
 	tokenProvider driven.TokenProvider[*entity.User],
+	metrics driven.Metrics,
 ) *UserUsecase {
 	return &UserUsecase{
 		userWriter:    userWriter,
 		encryptor:     encryptor,
 		userGetter:    userGetter,
 		tokenProvider: tokenProvider,
+		metrics:       metrics,
 	}
 }
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/port/driven"
+)
+
+type UsernameUsecase struct {
+	userGetter          driven.UserGetter
+	userWriter          driven.UserWriter
+	changeInterval      time.Duration
+	redirectGracePeriod time.Duration
+}
+
+func NewUsernameUsecase(
+	userGetter driven.UserGetter,
+	userWriter driven.UserWriter,
+	changeIntervalDay int,
+	redirectGraceDay int,
+) *UsernameUsecase {
+	return &UsernameUsecase{
+		userGetter:          userGetter,
+		userWriter:          userWriter,
+		changeInterval:      time.Duration(changeIntervalDay) * 24 * time.Hour,
+		redirectGracePeriod: time.Duration(redirectGraceDay) * 24 * time.Hour,
+	}
+}
+
+// CheckUsernameAvailability returns validation error when the username cannot be used.
+func (uu UsernameUsecase) CheckUsernameAvailability(ctx context.Context, username string) (err error) {
+	ctx, span := tracing.Start(ctx, "UsernameUsecase.CheckUsernameAvailability")
+	defer func() { tracing.End(span, err) }()
+
+	if err := entity.ValidateUsername(username); err != nil {
+		return err
+	}
+	return uu.ensureUsernameNotTaken(ctx, username, "")
+}
+
+func (uu UsernameUsecase) ChangeUsernameByID(ctx context.Context, id string, params *request.ChangeUsername) (_ *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UsernameUsecase.ChangeUsernameByID")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := uu.userGetter.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changedUser := &entity.User{
+		ID:                id,
+		Username:          user.Username,
+		UsernameChangedAt: user.UsernameChangedAt,
+	}
+	if err := changedUser.ChangeUsername(params.Username, uu.changeInterval); err != nil {
+		return nil, err
+	}
+
+	if err := uu.ensureUsernameNotTaken(ctx, changedUser.Username, id); err != nil {
+		return nil, err
+	}
+
+	var redirect *entity.UsernameRedirect
+	if user.Username != "" && !strings.EqualFold(user.Username, changedUser.Username) && uu.redirectGracePeriod > 0 {
+		redirect = &entity.UsernameRedirect{
+			Username:  strings.ToLower(user.Username),
+			UserID:    id,
+			ExpiresAt: time.Now().Add(uu.redirectGracePeriod),
+		}
+	}
+
+	if err := uu.userWriter.UpdateUsername(ctx, changedUser, redirect); err != nil {
+		return nil, err
+	}
+
+	return uu.userGetter.GetByID(ctx, id)
+}
+
+// GetUserByUsername also resolves the old username of the user during the redirect grace period.
+func (uu UsernameUsecase) GetUserByUsername(ctx context.Context, username string) (_ *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UsernameUsecase.GetUserByUsername")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := uu.userGetter.GetByUsername(ctx, username)
+	if !errors.Is(err, driven.ErrNotFound) {
+		return user, err
+	}
+
+	redirect, err := uu.userGetter.GetUsernameRedirect(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return uu.userGetter.GetByID(ctx, redirect.UserID)
+}
+
+// ensureUsernameNotTaken ignores the username and redirect owned by the user itself,
+// so the user can change the letter case or go back to the old username.
+func (uu UsernameUsecase) ensureUsernameNotTaken(ctx context.Context, username, userID string) error {
+	user, err := uu.userGetter.GetByUsername(ctx, username)
+	if err == nil && user.ID != userID {
+		return customerror.NewValidationErrorWithCode("username", customerror.CodeTaken, nil)
+	} else if err != nil && !errors.Is(err, driven.ErrNotFound) {
+		return err
+	}
+
+	redirect, err := uu.userGetter.GetUsernameRedirect(ctx, username)
+	if err == nil && redirect.UserID != userID {
+		return customerror.NewValidationErrorWithCode("username", customerror.CodeTaken, nil)
+	} else if err != nil && !errors.Is(err, driven.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/port/driven"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsernameUsecase_CheckUsernameAvailability(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	id, _ := fakeUserDriven.Create(context.Background(), &entity.User{
+		PhoneNumber: "+628123123123",
+		FullName:    faker.Name(),
+	})
+	fakeUserDriven.UpdateUsername(context.Background(), &entity.User{ID: id, Username: "John_Doe"}, &entity.UsernameRedirect{
+		Username:  "old_john",
+		UserID:    id,
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	fakeUserDriven.UpdateUsername(context.Background(), &entity.User{ID: id, Username: "John_Doe"}, &entity.UsernameRedirect{
+		Username:  "expired_john",
+		UserID:    id,
+		ExpiresAt: time.Now().Add(-time.Hour),
+	})
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		username   string
+		wantErrMsg string
+	}{
+		{
+			name:       "when username too short, it should return error",
+			ctx:        context.Background(),
+			username:   "jo",
+			wantErrMsg: "username: must be between 3 and 30 characters in length",
+		},
+		{
+			name:       "when username contains invalid characters, it should return error",
+			ctx:        context.Background(),
+			username:   "john.doe",
+			wantErrMsg: "username: must start with a letter and only contain letters, numbers and underscores",
+		},
+		{
+			name:       "when username contains consecutive underscores, it should return error",
+			ctx:        context.Background(),
+			username:   "john__doe",
+			wantErrMsg: "username: must not end with or contain consecutive underscores",
+		},
+		{
+			name:       "when username is reserved, it should return error",
+			ctx:        context.Background(),
+			username:   "Admin",
+			wantErrMsg: "username: is reserved",
+		},
+		{
+			name:       "when username is used in different case, it should return error",
+			ctx:        context.Background(),
+			username:   "john_DOE",
+			wantErrMsg: "username: is already taken",
+		},
+		{
+			name:       "when username is redirected to other user, it should return error",
+			ctx:        context.Background(),
+			username:   "Old_John",
+			wantErrMsg: "username: is already taken",
+		},
+		{
+			name:       "when failed to get user, it should return error",
+			ctx:        context.WithValue(context.Background(), "username_error", true),
+			username:   "jane_doe",
+			wantErrMsg: "error",
+		},
+		{
+			name:     "when username redirect is expired, it should be available",
+			ctx:      context.Background(),
+			username: "expired_john",
+		},
+		{
+			name:     "when username is not used, it should be available",
+			ctx:      context.Background(),
+			username: "jane_doe",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uu := usecase.NewUsernameUsecase(fakeUserDriven, fakeUserDriven, 30, 14)
+
+			err := uu.CheckUsernameAvailability(tt.ctx, tt.username)
+
+			assert := assert.New(t)
+			if tt.wantErrMsg == "" {
+				assert.NoError(err)
+			} else {
+				assert.Error(err)
+				assert.Equal(tt.wantErrMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestUsernameUsecase_ChangeUsernameByID(t *testing.T) {
+	recentlyChangedAt := time.Now().Add(-24 * time.Hour)
+	longAgoChangedAt := time.Now().Add(-31 * 24 * time.Hour)
+	type user struct {
+		username          string
+		usernameChangedAt *time.Time
+	}
+	tests := []struct {
+		name         string
+		user         *user
+		username     string
+		wantErrMsg   string
+		wantRedirect string
+	}{
+		{
+			name:       "when user not found, it should return error",
+			username:   "john_doe",
+			wantErrMsg: "resource not found",
+		},
+		{
+			name:       "when username not valid, it should return error",
+			user:       &user{},
+			username:   "_john",
+			wantErrMsg: "username: must start with a letter and only contain letters, numbers and underscores",
+		},
+		{
+			name: "when username is the same, it should return error",
+			user: &user{
+				username:          "john_doe",
+				usernameChangedAt: &longAgoChangedAt,
+			},
+			username:   "john_doe",
+			wantErrMsg: "username: must be different from the current username",
+		},
+		{
+			name: "when username changed recently, it should return error",
+			user: &user{
+				username:          "john_doe",
+				usernameChangedAt: &recentlyChangedAt,
+			},
+			username:   "jane_doe",
+			wantErrMsg: "username: can only be changed once every 30 days",
+		},
+		{
+			name:       "when username is taken, it should return error",
+			user:       &user{},
+			username:   "Taken_Name",
+			wantErrMsg: "username: is already taken",
+		},
+		{
+			name:     "when username never set, it should set username without redirect",
+			user:     &user{},
+			username: "john_doe",
+		},
+		{
+			name: "when only letter case changed recently, it should change username without redirect",
+			user: &user{
+				username:          "john_doe",
+				usernameChangedAt: &recentlyChangedAt,
+			},
+			username: "John_Doe",
+		},
+		{
+			name: "when username changed long ago, it should change username and redirect the old one",
+			user: &user{
+				username:          "John_Doe",
+				usernameChangedAt: &longAgoChangedAt,
+			},
+			username:     "jane_doe",
+			wantRedirect: "john_doe",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeUserDriven := fake.NewFakeUserDriven()
+			otherID, _ := fakeUserDriven.Create(context.Background(), &entity.User{PhoneNumber: "+628111111112"})
+			fakeUserDriven.UpdateUsername(context.Background(), &entity.User{ID: otherID, Username: "taken_name"}, nil)
+
+			id := faker.UUIDHyphenated()
+			if tt.user != nil {
+				id, _ = fakeUserDriven.Create(context.Background(), &entity.User{
+					PhoneNumber:       "+628123123123",
+					Username:          tt.user.username,
+					UsernameChangedAt: tt.user.usernameChangedAt,
+				})
+			}
+			uu := usecase.NewUsernameUsecase(fakeUserDriven, fakeUserDriven, 30, 14)
+
+			got, err := uu.ChangeUsernameByID(context.Background(), id, &request.ChangeUsername{Username: tt.username})
+
+			assert := assert.New(t)
+			if tt.wantErrMsg != "" {
+				assert.Error(err)
+				assert.True(strings.HasPrefix(err.Error(), tt.wantErrMsg), err.Error())
+				assert.Nil(got)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tt.username, got.Username)
+
+			redirect, err := fakeUserDriven.GetUsernameRedirect(context.Background(), tt.wantRedirect)
+			if tt.wantRedirect == "" {
+				assert.ErrorIs(err, driven.ErrNotFound)
+			} else {
+				assert.NoError(err)
+				assert.Equal(id, redirect.UserID)
+				assert.WithinDuration(time.Now().Add(14*24*time.Hour), redirect.ExpiresAt, time.Minute)
+
+				// the old username should resolve to the same user
+				resolved, err := uu.GetUserByUsername(context.Background(), tt.wantRedirect)
+				assert.NoError(err)
+				assert.Equal(id, resolved.ID)
+			}
+		})
+	}
+}
+
+func TestUsernameUsecase_GetUserByUsername(t *testing.T) {
+	fakeUserDriven := fake.NewFakeUserDriven()
+	id, _ := fakeUserDriven.Create(context.Background(), &entity.User{PhoneNumber: "+628123123123"})
+	fakeUserDriven.UpdateUsername(context.Background(), &entity.User{ID: id, Username: "John_Doe"}, &entity.UsernameRedirect{
+		Username:  "old_john",
+		UserID:    id,
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+
+	tests := []struct {
+		name     string
+		username string
+		wantErr  bool
+	}{
+		{
+			name:     "when username not found, it should return error",
+			username: "jane_doe",
+			wantErr:  true,
+		},
+		{
+			name:     "when username is current username, it should return user",
+			username: "john_doe",
+			wantErr:  false,
+		},
+		{
+			name:     "when username is old username, it should return user",
+			username: "Old_John",
+			wantErr:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uu := usecase.NewUsernameUsecase(fakeUserDriven, fakeUserDriven, 30, 14)
+
+			got, err := uu.GetUserByUsername(context.Background(), tt.username)
+
+			assert := assert.New(t)
+			assert.Equal(tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(id, got.ID)
+				assert.Equal("John_Doe", got.Username)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"userservice/internal/user/port/driven"
+)
+
+const (
+	defaultWebhookLockDuration         = time.Minute
+	defaultWebhookMaxAttempts          = 8
+	defaultWebhookBackoffBase          = 10 * time.Second
+	defaultWebhookDisableAfterFailures = 20
+)
+
+type WebhookDispatcherUsecase struct {
+	webhookStore         driven.WebhookStore
+	webhookSender        driven.WebhookSender
+	loop                 pollLoop
+	lockDuration         time.Duration
+	maxAttempts          int
+	backoffBase          time.Duration
+	disableAfterFailures int
+}
+
+func NewWebhookDispatcherUsecase(
+	webhookStore driven.WebhookStore,
+	webhookSender driven.WebhookSender,
+	pollIntervalMillisecond int,
+	batchSize int,
+	lockSecond int,
+	maxAttempts int,
+	backoffBaseSecond int,
+	disableAfterFailures int,
+) *WebhookDispatcherUsecase {
+	// the defaults are used when the settings are not set, a zero lock would send a claimed delivery twice,
+	// a zero backoff would always wait for the maximum backoff and the subscription would be disabled on its first failure
+	lockDuration := time.Duration(lockSecond) * time.Second
+	if lockDuration <= 0 {
+		lockDuration = defaultWebhookLockDuration
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = defaultWebhookMaxAttempts
+	}
+	backoffBase := time.Duration(backoffBaseSecond) * time.Second
+	if backoffBase <= 0 {
+		backoffBase = defaultWebhookBackoffBase
+	}
+	if disableAfterFailures <= 0 {
+		disableAfterFailures = defaultWebhookDisableAfterFailures
+	}
+	return &WebhookDispatcherUsecase{
+		webhookStore:         webhookStore,
+		webhookSender:        webhookSender,
+		loop:                 newPollLoop("webhook_dispatcher", pollIntervalMillisecond, batchSize),
+		lockDuration:         lockDuration,
+		maxAttempts:          maxAttempts,
+		backoffBase:          backoffBase,
+		disableAfterFailures: disableAfterFailures,
+	}
+}
+
+// DispatchDueDeliveries signs and sends the due deliveries, every attempt is recorded.
+// A delivery of a subscription disabled in the same batch is skipped, it stays pending until the subscription is enabled again.
+func (wd WebhookDispatcherUsecase) DispatchDueDeliveries(ctx context.Context) (int, error) {
+	deliveries, err := wd.webhookStore.ClaimDueDeliveries(ctx, wd.loop.batchSize, wd.lockDuration)
+	if err != nil {
+		return 0, err
+	}
+
+	disabled := make(map[string]bool)
+	sent := 0
+	for _, delivery := range deliveries {
+		if disabled[delivery.Subscription.ID] {
+			continue
+		}
+
+		now := time.Now()
+		statusCode, sendErr := wd.webhookSender.Send(ctx, delivery.NewRequest(now))
+		attempt := delivery.RecordAttempt(statusCode, sendErr, time.Since(now), now, wd.maxAttempts, wd.backoffBase, wd.disableAfterFailures)
+		if err := wd.webhookStore.SaveAttempt(ctx, delivery, attempt); err != nil {
+			return sent, err
+		}
+		if !delivery.Subscription.Enabled {
+			disabled[delivery.Subscription.ID] = true
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// Run dispatches the deliveries on every poll interval until the context is done.
+func (wd WebhookDispatcherUsecase) Run(ctx context.Context) {
+	wd.loop.run(ctx, wd.DispatchDueDeliveries)
+}
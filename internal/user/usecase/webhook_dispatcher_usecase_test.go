@@ -0,0 +1,173 @@
+package usecase_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"userservice/config"
+	"userservice/infrastructure"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// webhookReceiver verifies the signature like a partner does and answers with the next status code.
+type webhookReceiver struct {
+	mu          sync.Mutex
+	secret      string
+	statusCodes []int
+	received    int
+	invalid     int
+}
+
+func (wr *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	signature := "sha256=" + entity.SignWebhookPayload(wr.secret, r.Header.Get(entity.WebhookTimestampHeader), body)
+	if r.Header.Get(entity.WebhookSignatureHeader) != signature {
+		wr.invalid++
+	}
+
+	statusCode := http.StatusOK
+	if wr.received < len(wr.statusCodes) {
+		statusCode = wr.statusCodes[wr.received]
+	}
+	wr.received++
+	w.WriteHeader(statusCode)
+}
+
+func newWebhookDispatcherFixture(t *testing.T, statusCodes ...int) (*fake.FakeWebhookStore, *entity.WebhookSubscription, *webhookReceiver) {
+	receiver := &webhookReceiver{
+		secret:      "0123456789abcdef",
+		statusCodes: statusCodes,
+	}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	store := fake.NewFakeWebhookStore()
+	subscription, _ := entity.NewWebhookSubscription("acme", "https://partner.example.com", []string{entity.UserCreatedEventType}, receiver.secret)
+	// the test receiver is plain http on the loopback, which only the sender of the tests allows
+	subscription.URL = server.URL
+	store.CreateSubscription(context.Background(), subscription)
+	store.LinkPartnerUser(context.Background(), "acme", "1")
+	return store, subscription, receiver
+}
+
+func TestWebhookDispatcherUsecase_DispatchDueDeliveries_Success(t *testing.T) {
+	store, subscription, receiver := newWebhookDispatcherFixture(t)
+	store.QueueDeliveries(context.Background(), entity.NewUserCreatedEvent(&entity.User{ID: "1"}))
+	store.QueueDeliveries(context.Background(), entity.NewUserLoggedInEvent("1"))
+	wd := usecase.NewWebhookDispatcherUsecase(store, infrastructure.NewHTTPWebhookSender(&config.Webhook{TimeoutSecond: 1, AllowPrivateNetworks: true}), 1000, 10, 60, 3, 10, 5)
+
+	sent, err := wd.DispatchDueDeliveries(context.Background())
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	// the subscription does not subscribe UserLoggedIn
+	assert.Equal(1, sent)
+	assert.Equal(1, receiver.received)
+	assert.Equal(0, receiver.invalid)
+	assert.Equal(entity.WebhookDeliverySucceeded, store.Deliveries[0].Status)
+	assert.Equal(subscription.ID, store.Attempts[0].SubscriptionID)
+	assert.Equal(http.StatusOK, store.Attempts[0].StatusCode)
+	assert.Empty(store.Attempts[0].Error)
+}
+
+func TestWebhookDispatcherUsecase_DispatchDueDeliveries_Retry(t *testing.T) {
+	store, _, receiver := newWebhookDispatcherFixture(t, http.StatusInternalServerError, http.StatusBadGateway, http.StatusInternalServerError)
+	store.QueueDeliveries(context.Background(), entity.NewUserCreatedEvent(&entity.User{ID: "1"}))
+	delivery := store.Deliveries[0]
+	wd := usecase.NewWebhookDispatcherUsecase(store, infrastructure.NewHTTPWebhookSender(&config.Webhook{TimeoutSecond: 1, AllowPrivateNetworks: true}), 1000, 10, 60, 3, 10, 5)
+
+	assert := assert.New(t)
+	wantBackoffs := []time.Duration{10 * time.Second, 20 * time.Second}
+	for _, wantBackoff := range wantBackoffs {
+		before := time.Now()
+		sent, err := wd.DispatchDueDeliveries(context.Background())
+		assert.NoError(err)
+		assert.Equal(1, sent)
+		assert.Equal(entity.WebhookDeliveryPending, delivery.Status)
+		assert.WithinDuration(before.Add(wantBackoff), delivery.NextAttemptAt, time.Second)
+
+		// the delivery is not due until the backoff has passed
+		sent, _ = wd.DispatchDueDeliveries(context.Background())
+		assert.Equal(0, sent)
+		delivery.NextAttemptAt = time.Now()
+	}
+
+	sent, err := wd.DispatchDueDeliveries(context.Background())
+	assert.NoError(err)
+	assert.Equal(1, sent)
+	assert.Equal(entity.WebhookDeliveryFailed, delivery.Status)
+	assert.Equal(3, receiver.received)
+	assert.Len(store.Attempts, 3)
+	assert.Equal(http.StatusBadGateway, store.Attempts[1].StatusCode)
+	assert.Equal("unexpected status code 502", store.Attempts[1].Error)
+}
+
+func TestWebhookDispatcherUsecase_DispatchDueDeliveries_DisableSubscription(t *testing.T) {
+	store, subscription, receiver := newWebhookDispatcherFixture(t,
+		http.StatusInternalServerError,
+		http.StatusInternalServerError,
+		http.StatusInternalServerError,
+	)
+	for i := 0; i < 4; i++ {
+		store.QueueDeliveries(context.Background(), entity.NewUserCreatedEvent(&entity.User{ID: "1"}))
+	}
+	wd := usecase.NewWebhookDispatcherUsecase(store, infrastructure.NewHTTPWebhookSender(&config.Webhook{TimeoutSecond: 1, AllowPrivateNetworks: true}), 1000, 10, 60, 8, 10, 2)
+
+	sent, err := wd.DispatchDueDeliveries(context.Background())
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	// the rest of the batch is skipped once the subscription is disabled
+	assert.Equal(2, sent)
+	assert.Equal(2, receiver.received)
+	assert.False(subscription.Enabled)
+	assert.NotNil(subscription.DisabledAt)
+	for _, delivery := range store.Deliveries {
+		assert.Equal(entity.WebhookDeliveryPending, delivery.Status)
+	}
+
+	// the deliveries of a disabled subscription are not claimed anymore
+	sent, err = wd.DispatchDueDeliveries(context.Background())
+	assert.NoError(err)
+	assert.Equal(0, sent)
+}
+
+func TestWebhookDispatcherUsecase_DispatchDueDeliveries_withoutSettings(t *testing.T) {
+	store, subscription, receiver := newWebhookDispatcherFixture(t, http.StatusInternalServerError)
+	store.QueueDeliveries(context.Background(), entity.NewUserCreatedEvent(&entity.User{ID: "1"}))
+	delivery := store.Deliveries[0]
+	wd := usecase.NewWebhookDispatcherUsecase(store, infrastructure.NewHTTPWebhookSender(&config.Webhook{AllowPrivateNetworks: true}), 0, 0, 0, 0, 0, 0)
+
+	before := time.Now()
+	sent, err := wd.DispatchDueDeliveries(context.Background())
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	assert.Equal(1, sent)
+	assert.Equal(1, receiver.received)
+	// the defaults are used instead of the maximum backoff and disabling the subscription on its first failure
+	assert.Equal(entity.WebhookDeliveryPending, delivery.Status)
+	assert.WithinDuration(before.Add(10*time.Second), delivery.NextAttemptAt, time.Second)
+	assert.True(subscription.Enabled)
+}
+
+func TestWebhookDispatcherUsecase_DispatchDueDeliveries_ClaimError(t *testing.T) {
+	store, _, _ := newWebhookDispatcherFixture(t)
+	wd := usecase.NewWebhookDispatcherUsecase(store, infrastructure.NewHTTPWebhookSender(&config.Webhook{TimeoutSecond: 1, AllowPrivateNetworks: true}), 1000, 10, 60, 3, 10, 5)
+
+	_, err := wd.DispatchDueDeliveries(context.WithValue(context.Background(), "webhook_error", true))
+
+	assert.Error(t, err)
+}
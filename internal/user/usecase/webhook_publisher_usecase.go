@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+)
+
+var _ driven.EventPublisher = new(WebhookPublisherUsecase)
+
+// WebhookPublisherUsecase is the publisher of the outbox relay which queues the events for the webhooks,
+// the partners only receive the public profile of the users linked to them.
+type WebhookPublisherUsecase struct {
+	userGetter   UserGetterUsecase
+	webhookStore driven.WebhookStore
+}
+
+func NewWebhookPublisherUsecase(userGetter driven.UserGetter, webhookStore driven.WebhookStore) *WebhookPublisherUsecase {
+	return &WebhookPublisherUsecase{
+		userGetter:   UserGetterUsecase{userGetter: userGetter},
+		webhookStore: webhookStore,
+	}
+}
+
+// Publish implements driven.EventPublisher.
+// The privacy settings are the ones when the event is published, not when it occurred.
+func (wp WebhookPublisherUsecase) Publish(ctx context.Context, event *entity.Event) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookPublisherUsecase.Publish")
+	defer func() { tracing.End(span, err) }()
+
+	settings, err := wp.userGetter.GetPrivacySettingsByUserID(ctx, event.AggregateID)
+	if err != nil {
+		return err
+	}
+
+	publicEvent, err := entity.NewPublicUserEvent(event, settings)
+	if err != nil {
+		return err
+	}
+	return wp.webhookStore.QueueDeliveries(ctx, publicEvent)
+}
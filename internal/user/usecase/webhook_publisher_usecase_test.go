@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookPublisherUsecase_Publish(t *testing.T) {
+	tests := []struct {
+		name        string
+		settings    *entity.PrivacySettings
+		linked      bool
+		wantPayload string
+	}{
+		{
+			name:   "when user is not linked to the partner, it should not queue the event",
+			linked: false,
+		},
+		{
+			name:        "when user never change the settings, it should hide the phone number",
+			linked:      true,
+			wantPayload: `{"id":"{id}","fullName":"John Doe"}`,
+		},
+		{
+			name:        "when user shows the phone number, it should mask the phone number",
+			settings:    &entity.PrivacySettings{ShowPhoneNumber: true},
+			linked:      true,
+			wantPayload: `{"id":"{id}","phoneNumber":"+6281*****789"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userDriven := fake.NewFakeUserDriven()
+			user := &entity.User{FullName: "John Doe", PhoneNumber: "+628123456789"}
+			userDriven.Create(context.Background(), user)
+			if tt.settings != nil {
+				tt.settings.UserID = user.ID
+				userDriven.UpsertPrivacySettings(context.Background(), tt.settings)
+			}
+			store := fake.NewFakeWebhookStore()
+			subscription, _ := entity.NewWebhookSubscription("acme", "https://partner.example.com", []string{entity.UserCreatedEventType}, "")
+			store.CreateSubscription(context.Background(), subscription)
+			if tt.linked {
+				store.LinkPartnerUser(context.Background(), "acme", user.ID)
+			}
+			// the subscription of another partner never receives the event
+			other, _ := entity.NewWebhookSubscription("other", "https://other.example.com", []string{entity.UserCreatedEventType}, "")
+			store.CreateSubscription(context.Background(), other)
+			event := entity.NewUserCreatedEvent(user)
+			wp := usecase.NewWebhookPublisherUsecase(userDriven, store)
+
+			err := wp.Publish(context.Background(), event)
+
+			assert := assert.New(t)
+			assert.NoError(err)
+			if tt.wantPayload == "" {
+				assert.Empty(store.Deliveries)
+				return
+			}
+			assert.Len(store.Deliveries, 1)
+			assert.Equal(subscription.ID, store.Deliveries[0].Subscription.ID)
+			assert.Equal(event.ID, store.Deliveries[0].EventID)
+			assert.JSONEq(strings.ReplaceAll(tt.wantPayload, "{id}", user.ID), string(store.Deliveries[0].Payload))
+		})
+	}
+}
+
+func TestWebhookPublisherUsecase_Publish_Error(t *testing.T) {
+	wp := usecase.NewWebhookPublisherUsecase(fake.NewFakeUserDriven(), fake.NewFakeWebhookStore())
+	event := entity.NewUserLoggedInEvent("1")
+
+	err := wp.Publish(context.WithValue(context.Background(), "privacy_error", true), event)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/tracing"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/port/driven"
+)
+
+const webhookDeliveryAttemptsMaxLimit = 100
+
+type WebhookUsecase struct {
+	webhookStore driven.WebhookStore
+	userGetter   driven.UserGetter
+}
+
+func NewWebhookUsecase(webhookStore driven.WebhookStore, userGetter driven.UserGetter) *WebhookUsecase {
+	return &WebhookUsecase{
+		webhookStore: webhookStore,
+		userGetter:   userGetter,
+	}
+}
+
+// CreateWebhookSubscription returns the secret only here, it is not shown anymore afterward.
+func (wu WebhookUsecase) CreateWebhookSubscription(ctx context.Context, params *request.CreateWebhookSubscription) (_ *entity.WebhookSubscription, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.CreateWebhookSubscription")
+	defer func() { tracing.End(span, err) }()
+
+	subscription, err := entity.NewWebhookSubscription(params.PartnerID, params.URL, params.EventTypes, params.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wu.webhookStore.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (wu WebhookUsecase) GetWebhookSubscriptions(ctx context.Context) (_ []*entity.WebhookSubscription, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.GetWebhookSubscriptions")
+	defer func() { tracing.End(span, err) }()
+
+	return wu.webhookStore.GetSubscriptions(ctx)
+}
+
+func (wu WebhookUsecase) UpdateWebhookSubscriptionByID(ctx context.Context, id string, params *request.UpdateWebhookSubscription) (_ *entity.WebhookSubscription, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.UpdateWebhookSubscriptionByID")
+	defer func() { tracing.End(span, err) }()
+
+	subscription, err := wu.webhookStore.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := subscription.Update(params.URL, params.EventTypes, params.Enabled); err != nil {
+		return nil, err
+	}
+
+	if err := wu.webhookStore.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (wu WebhookUsecase) DeleteWebhookSubscriptionByID(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.DeleteWebhookSubscriptionByID")
+	defer func() { tracing.End(span, err) }()
+
+	return wu.webhookStore.DeleteSubscription(ctx, id)
+}
+
+func (wu WebhookUsecase) GetWebhookDeliveryAttempts(ctx context.Context, subscriptionID string, limit int) (_ []*entity.WebhookDeliveryAttempt, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.GetWebhookDeliveryAttempts")
+	defer func() { tracing.End(span, err) }()
+
+	if limit == 0 {
+		limit = webhookDeliveryAttemptsMaxLimit
+	}
+	if limit < 1 || limit > webhookDeliveryAttemptsMaxLimit {
+		return nil, customerror.NewValidationErrorWithCode("limit", customerror.CodeRangeBetween, customerror.Params{"min": 1, "max": webhookDeliveryAttemptsMaxLimit})
+	}
+
+	// make sure not found subscription is reported instead of empty attempts
+	if _, err := wu.webhookStore.GetSubscriptionByID(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return wu.webhookStore.GetDeliveryAttempts(ctx, subscriptionID, limit)
+}
+
+// LinkPartnerUser lets the subscriptions of the partner receive the events of the user from now on.
+func (wu WebhookUsecase) LinkPartnerUser(ctx context.Context, partnerID, userID string) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.LinkPartnerUser")
+	defer func() { tracing.End(span, err) }()
+
+	if err := entity.ValidatePartnerID(partnerID); err != nil {
+		return err
+	}
+
+	// make sure not found user is reported instead of failing the foreign key
+	if _, err := wu.userGetter.GetByID(ctx, userID); err != nil {
+		return err
+	}
+	return wu.webhookStore.LinkPartnerUser(ctx, partnerID, userID)
+}
+
+func (wu WebhookUsecase) UnlinkPartnerUser(ctx context.Context, partnerID, userID string) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.UnlinkPartnerUser")
+	defer func() { tracing.End(span, err) }()
+
+	return wu.webhookStore.UnlinkPartnerUser(ctx, partnerID, userID)
+}
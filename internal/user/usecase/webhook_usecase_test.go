@@ -0,0 +1,312 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/port/driven"
+	"userservice/internal/user/usecase"
+	"userservice/test/fake"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookUsecase_CreateWebhookSubscription(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     *request.CreateWebhookSubscription
+		wantSecret string
+		wantErr    bool
+	}{
+		{
+			name: "when partner id is not valid, it should return validation error",
+			params: &request.CreateWebhookSubscription{
+				PartnerID:  "Acme Corp",
+				URL:        "https://partner.example.com",
+				EventTypes: []string{entity.UserCreatedEventType},
+			},
+			wantErr: true,
+		},
+		{
+			name: "when url is not https, it should return validation error",
+			params: &request.CreateWebhookSubscription{
+				PartnerID:  "acme",
+				URL:        "http://partner.example.com",
+				EventTypes: []string{entity.UserCreatedEventType},
+			},
+			wantErr: true,
+		},
+		{
+			name: "when url is a private address, it should return validation error",
+			params: &request.CreateWebhookSubscription{
+				PartnerID:  "acme",
+				URL:        "https://10.0.0.1/webhooks",
+				EventTypes: []string{entity.UserCreatedEventType},
+			},
+			wantErr: true,
+		},
+		{
+			name: "when url is a link-local address, it should return validation error",
+			params: &request.CreateWebhookSubscription{
+				PartnerID:  "acme",
+				URL:        "https://169.254.169.254/latest/meta-data",
+				EventTypes: []string{entity.UserCreatedEventType},
+			},
+			wantErr: true,
+		},
+		{
+			name: "when url is localhost, it should return validation error",
+			params: &request.CreateWebhookSubscription{
+				PartnerID:  "acme",
+				URL:        "https://localhost:8080",
+				EventTypes: []string{entity.UserCreatedEventType},
+			},
+			wantErr: true,
+		},
+		{
+			name: "when event type is not supported, it should return validation error",
+			params: &request.CreateWebhookSubscription{
+				PartnerID:  "acme",
+				URL:        "https://partner.example.com",
+				EventTypes: []string{"UserDeleted"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "when secret is too short, it should return validation error",
+			params: &request.CreateWebhookSubscription{
+				PartnerID:  "acme",
+				URL:        "https://partner.example.com",
+				EventTypes: []string{entity.UserCreatedEventType},
+				Secret:     "short",
+			},
+			wantErr: true,
+		},
+		{
+			name: "when secret is given, it should keep the secret",
+			params: &request.CreateWebhookSubscription{
+				PartnerID:  "acme",
+				URL:        "https://partner.example.com",
+				EventTypes: []string{entity.UserCreatedEventType},
+				Secret:     "0123456789abcdef",
+			},
+			wantSecret: "0123456789abcdef",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := fake.NewFakeWebhookStore()
+			wu := usecase.NewWebhookUsecase(store, fake.NewFakeUserDriven())
+
+			got, err := wu.CreateWebhookSubscription(context.Background(), tt.params)
+
+			assert := assert.New(t)
+			if tt.wantErr {
+				assert.IsType(new(customerror.ValidationError), err)
+				assert.Empty(store.Subscriptions)
+				return
+			}
+			assert.NoError(err)
+			assert.True(got.Enabled)
+			assert.Equal("acme", got.PartnerID)
+			assert.Equal(tt.wantSecret, got.Secret)
+			assert.Contains(store.Subscriptions, got.ID)
+		})
+	}
+}
+
+func TestWebhookUsecase_CreateWebhookSubscription_GeneratesSecret(t *testing.T) {
+	wu := usecase.NewWebhookUsecase(fake.NewFakeWebhookStore(), fake.NewFakeUserDriven())
+
+	first, err := wu.CreateWebhookSubscription(context.Background(), &request.CreateWebhookSubscription{
+		PartnerID:  "acme",
+		URL:        "https://partner.example.com",
+		EventTypes: []string{entity.UserCreatedEventType},
+	})
+	assert.NoError(t, err)
+	second, err := wu.CreateWebhookSubscription(context.Background(), &request.CreateWebhookSubscription{
+		PartnerID:  "acme",
+		URL:        "https://partner.example.com",
+		EventTypes: []string{entity.UserCreatedEventType},
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, first.Secret, 64)
+	assert.NotEqual(t, first.Secret, second.Secret)
+}
+
+func TestWebhookUsecase_UpdateWebhookSubscriptionByID(t *testing.T) {
+	enabled := true
+	disabled := false
+	url := "https://other.example.com"
+	tests := []struct {
+		name        string
+		id          string
+		params      *request.UpdateWebhookSubscription
+		wantEnabled bool
+		wantErr     error
+	}{
+		{
+			name:    "when subscription is not found, it should return no rows error",
+			id:      "not-found",
+			params:  &request.UpdateWebhookSubscription{Enabled: &enabled},
+			wantErr: driven.ErrNotFound,
+		},
+		{
+			name:    "when nothing is updated, it should return validation error",
+			params:  &request.UpdateWebhookSubscription{},
+			wantErr: new(customerror.ValidationError),
+		},
+		{
+			name:        "when subscription is enabled again, it should reset the failures",
+			params:      &request.UpdateWebhookSubscription{Enabled: &enabled, URL: &url},
+			wantEnabled: true,
+		},
+		{
+			name:        "when subscription is disabled, it should keep it disabled",
+			params:      &request.UpdateWebhookSubscription{Enabled: &disabled},
+			wantEnabled: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := fake.NewFakeWebhookStore()
+			subscription, _ := entity.NewWebhookSubscription("acme", "https://partner.example.com", []string{entity.UserCreatedEventType}, "")
+			store.CreateSubscription(context.Background(), subscription)
+			subscription.Enabled = false
+			subscription.ConsecutiveFailures = 20
+			if tt.id == "" {
+				tt.id = subscription.ID
+			}
+			wu := usecase.NewWebhookUsecase(store, fake.NewFakeUserDriven())
+
+			got, err := wu.UpdateWebhookSubscriptionByID(context.Background(), tt.id, tt.params)
+
+			assert := assert.New(t)
+			if tt.wantErr != nil {
+				assert.IsType(tt.wantErr, err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.wantEnabled, got.Enabled)
+			if tt.wantEnabled {
+				assert.Equal(0, got.ConsecutiveFailures)
+				assert.Nil(got.DisabledAt)
+				assert.Equal(url, got.URL)
+			}
+		})
+	}
+}
+
+func TestWebhookUsecase_GetWebhookDeliveryAttempts(t *testing.T) {
+	store := fake.NewFakeWebhookStore()
+	subscription, _ := entity.NewWebhookSubscription("acme", "https://partner.example.com", []string{entity.UserCreatedEventType}, "")
+	store.CreateSubscription(context.Background(), subscription)
+	for i := 1; i <= 3; i++ {
+		store.SaveAttempt(context.Background(), &entity.WebhookDelivery{}, &entity.WebhookDeliveryAttempt{
+			SubscriptionID: subscription.ID,
+			Attempt:        i,
+		})
+	}
+	wu := usecase.NewWebhookUsecase(store, fake.NewFakeUserDriven())
+
+	tests := []struct {
+		name         string
+		id           string
+		limit        int
+		wantAttempts []int
+		wantErr      error
+	}{
+		{
+			name:    "when limit is over the max, it should return validation error",
+			id:      subscription.ID,
+			limit:   101,
+			wantErr: new(customerror.ValidationError),
+		},
+		{
+			name:    "when subscription is not found, it should return no rows error",
+			id:      "not-found",
+			wantErr: driven.ErrNotFound,
+		},
+		{
+			name:         "when limit is empty, it should return the latest attempts first",
+			id:           subscription.ID,
+			wantAttempts: []int{3, 2, 1},
+		},
+		{
+			name:         "when limit is given, it should return at most the limit",
+			id:           subscription.ID,
+			limit:        2,
+			wantAttempts: []int{3, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wu.GetWebhookDeliveryAttempts(context.Background(), tt.id, tt.limit)
+
+			assert := assert.New(t)
+			if tt.wantErr != nil {
+				assert.IsType(tt.wantErr, err)
+				return
+			}
+			assert.NoError(err)
+			attempts := []int{}
+			for _, attempt := range got {
+				attempts = append(attempts, attempt.Attempt)
+			}
+			assert.Equal(tt.wantAttempts, attempts)
+		})
+	}
+}
+
+func TestWebhookUsecase_LinkPartnerUser(t *testing.T) {
+	userDriven := fake.NewFakeUserDriven()
+	userID, _ := userDriven.Create(context.Background(), &entity.User{FullName: "John Doe"})
+
+	tests := []struct {
+		name      string
+		partnerID string
+		userID    string
+		wantErr   error
+	}{
+		{
+			name:      "when partner id is not valid, it should return validation error",
+			partnerID: "-acme",
+			userID:    userID,
+			wantErr:   new(customerror.ValidationError),
+		},
+		{
+			name:      "when user is not found, it should return not found error",
+			partnerID: "acme",
+			userID:    "not-found",
+			wantErr:   driven.ErrNotFound,
+		},
+		{
+			name:      "when user is found, it should link the user",
+			partnerID: "acme",
+			userID:    userID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := fake.NewFakeWebhookStore()
+			wu := usecase.NewWebhookUsecase(store, userDriven)
+
+			err := wu.LinkPartnerUser(context.Background(), tt.partnerID, tt.userID)
+
+			assert := assert.New(t)
+			if tt.wantErr != nil {
+				assert.IsType(tt.wantErr, err)
+				return
+			}
+			assert.NoError(err)
+			// linking again does nothing
+			assert.NoError(wu.LinkPartnerUser(context.Background(), tt.partnerID, tt.userID))
+			assert.NoError(wu.UnlinkPartnerUser(context.Background(), tt.partnerID, tt.userID))
+			assert.ErrorIs(wu.UnlinkPartnerUser(context.Background(), tt.partnerID, tt.userID), driven.ErrNotFound)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"userservice/generated/userpb"
+	"userservice/internal/auth"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// the same operations as the public operations of api.yml
+var unprotectedMethod = map[string]bool{
+	userpb.UserService_CreateUser_FullMethodName:        true,
+	userpb.UserService_GenerateUserToken_FullMethodName: true,
+	userpb.UserService_GetUserByID_FullMethodName:       true,
+	grpc_health_v1.Health_Check_FullMethodName:          true,
+}
+
+// WithGRPCJwtAuth is the gRPC version of the bearerAuth of Security, only the user tokens are accepted.
+// The streaming health watch and reflection are not intercepted, they are always public.
+func WithGRPCJwtAuth(tokenProvider driven.TokenProvider[*entity.User]) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if unprotectedMethod[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		authorization := md.Get("authorization")
+		if len(authorization) == 0 || authorization[0] == "" {
+			return nil, status.Error(codes.Unauthenticated, "Unauthorized")
+		}
+
+		token := strings.TrimPrefix(authorization[0], "Bearer ")
+		claims, err := tokenProvider.ValidateJWT(token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "Invalid token")
+		}
+
+		authClaims := auth.NewClaims(claims)
+		if authClaims.ClientType != "" {
+			return nil, status.Error(codes.PermissionDenied, "Forbidden")
+		}
+
+		return handler(withClaims(ctx, authClaims), req)
+	}
+}
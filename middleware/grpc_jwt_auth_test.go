@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"userservice/generated/userpb"
+	"userservice/internal/auth"
+	"userservice/test/fake"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestWithGRPCJwtAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		token      string
+		wantCode   codes.Code
+		wantUserID string
+	}{
+		{
+			name:     "when method is unprotected, it should not need token",
+			method:   userpb.UserService_CreateUser_FullMethodName,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "when health is checked, it should not need token",
+			method:   grpc_health_v1.Health_Check_FullMethodName,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "when token is missing, it should return unauthenticated",
+			method:   userpb.UserService_GetCurrentUser_FullMethodName,
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "when token is invalid, it should return unauthenticated",
+			method:   userpb.UserService_GetCurrentUser_FullMethodName,
+			token:    "invalid",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "when admin token used, it should return permission denied",
+			method:   userpb.UserService_GetCurrentUser_FullMethodName,
+			token:    "admin-token",
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:       "when user token used, it should pass the user id",
+			method:     userpb.UserService_GetCurrentUser_FullMethodName,
+			token:      "user-id",
+			wantCode:   codes.OK,
+			wantUserID: "user-id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tt.token))
+			}
+
+			var gotUserID string
+			interceptor := WithGRPCJwtAuth(new(fake.FakeTokenProvider))
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req any) (any, error) {
+				gotUserID = auth.UserID(ctx)
+				return nil, nil
+			})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantUserID != "" {
+				assert.Equal(t, tt.wantUserID, gotUserID)
+			}
+		})
+	}
+}
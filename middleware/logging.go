@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"time"
+
+	"userservice/internal/auth"
+	"userservice/internal/logging"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const grpcRequestIDKey = "x-request-id"
+
+// requestIDPattern limits the request IDs accepted from the clients, they cannot forge the log lines.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// WithRequestID keeps the X-Request-ID of the request or generates one, it is returned in the response
+// and every log of the request has it as request_id.
+func WithRequestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			requestID := requestIDOrNew(c.Request().Header.Get(echo.HeaderXRequestID))
+			c.Request().Header.Set(echo.HeaderXRequestID, requestID)
+			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+			ctx := logging.With(c.Request().Context(), "request_id", requestID)
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// WithAccessLog logs every request once it is answered with the operation ID of api.yml and the user,
+// the logs of the handlers and usecases have the operation_id too. It is used after WithRequestID.
+func WithAccessLog(swagger *openapi3.T) echo.MiddlewareFunc {
+	operationIDs := operationIDsByRoute(swagger)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			operationID := operationIDs[routeKey(c.Request().Method, c.Path())]
+			if operationID != "" {
+				c.SetRequest(c.Request().WithContext(logging.With(c.Request().Context(), "operation_id", operationID)))
+			}
+
+			// the error is answered here to log its status, as the Logger of echo does
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			req := c.Request()
+			status := c.Response().Status
+			level := slog.LevelInfo
+			switch {
+			case status >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case status >= http.StatusBadRequest:
+				level = slog.LevelWarn
+			}
+			// the logger of the request has the user once it is authenticated
+			logging.FromContext(req.Context()).LogAttrs(req.Context(), level, "request completed",
+				slog.String("method", req.Method),
+				slog.String("route", c.Path()),
+				slog.String("path", req.URL.Path),
+				slog.Int("status", status),
+				slog.Int64("bytes_out", c.Response().Size),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+				slog.String("remote_ip", c.RealIP()),
+				slog.String("user_agent", req.UserAgent()),
+			)
+			return nil
+		}
+	}
+}
+
+// WithGRPCAccessLog is the gRPC version of WithRequestID and WithAccessLog, the request ID is read
+// from the x-request-id metadata and returned in the header. It is the first interceptor of the chain.
+func WithGRPCAccessLog() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		var requestID string
+		if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(grpcRequestIDKey)) > 0 {
+			requestID = md.Get(grpcRequestIDKey)[0]
+		}
+		requestID = requestIDOrNew(requestID)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(grpcRequestIDKey, requestID))
+		ctx = logging.With(ctx, "request_id", requestID, "operation_id", info.FullMethod)
+		// the span is started by the stats handler of otelgrpc
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+			ctx = logging.With(ctx, "trace_id", spanContext.TraceID().String())
+		}
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		level := slog.LevelWarn
+		switch code {
+		case codes.OK:
+			level = slog.LevelInfo
+		case codes.Unknown, codes.Internal, codes.DataLoss:
+			level = slog.LevelError
+		}
+		attrs := []slog.Attr{
+			slog.String("code", code.String()),
+			slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.Any("error", err))
+		}
+		logging.FromContext(ctx).LogAttrs(ctx, level, "request completed", attrs...)
+		return resp, err
+	}
+}
+
+func requestIDOrNew(requestID string) string {
+	if requestIDPattern.MatchString(requestID) {
+		return requestID
+	}
+	return uuid.NewString()
+}
+
+// withClaims puts the claims into the context and adds the user, or the client, to the logger of the request.
+func withClaims(ctx context.Context, claims *auth.Claims) context.Context {
+	ctx = auth.WithClaims(ctx, claims)
+	if claims.ClientType != "" {
+		return logging.With(ctx, "client_id", claims.Subject, "client_type", claims.ClientType)
+	}
+	return logging.With(ctx, "user_id", claims.Subject)
+}
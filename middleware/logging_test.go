@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/logging"
+	"userservice/test/fake"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		wantSame  bool
+	}{
+		{
+			name:      "when request id is valid, it should keep the request id",
+			requestID: "3f2b9c1e-request.1",
+			wantSame:  true,
+		},
+		{
+			name: "when request id is missing, it should generate the request id",
+		},
+		{
+			name:      "when request id can forge the logs, it should generate the request id",
+			requestID: "id\nlevel=ERROR",
+		},
+		{
+			name:      "when request id is too long, it should generate the request id",
+			requestID: strings.Repeat("a", 129),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderXRequestID, tt.requestID)
+			req = req.WithContext(logging.WithLogger(req.Context(), logging.New(&buf, &logging.Options{})))
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			handler := WithRequestID()(func(c echo.Context) error {
+				logging.FromContext(c.Request().Context()).Info("handled")
+				return c.NoContent(http.StatusOK)
+			})
+
+			assert := assert.New(t)
+			assert.NoError(handler(ctx))
+			requestID := rec.Header().Get(echo.HeaderXRequestID)
+			assert.NotEmpty(requestID)
+			assert.Equal(tt.wantSame, requestID == tt.requestID)
+			assert.Equal(requestID, ctx.Request().Header.Get(echo.HeaderXRequestID))
+
+			var got map[string]any
+			assert.NoError(json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal(requestID, got["request_id"])
+		})
+	}
+}
+
+func TestWithAccessLog(t *testing.T) {
+	swagger, err := generated.GetSwagger()
+	require.NoError(t, err)
+	security := newTestSecurity(t, fake.NewFakeUserUsecase())
+
+	tests := []struct {
+		name       string
+		token      string
+		handlerErr error
+		wantStatus float64
+		wantLevel  string
+		wantUserID any
+	}{
+		{
+			name:       "when request is authenticated, it should log the user",
+			token:      "user-id",
+			wantStatus: http.StatusOK,
+			wantLevel:  "INFO",
+			wantUserID: "user-id",
+		},
+		{
+			name:       "when request is not authenticated, it should log warning without the user",
+			wantStatus: http.StatusUnauthorized,
+			wantLevel:  "WARN",
+		},
+		{
+			name:       "when handler returns error, it should log the status of the error",
+			token:      "user-id",
+			handlerErr: echo.ErrInternalServerError,
+			wantStatus: http.StatusInternalServerError,
+			wantLevel:  "ERROR",
+			wantUserID: "user-id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me?fields=id", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			req = req.WithContext(logging.WithLogger(req.Context(), logging.New(&buf, &logging.Options{})))
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetPath("/api/v1/users/me")
+
+			handler := WithAccessLog(swagger)(security.Middleware()(func(c echo.Context) error {
+				if tt.handlerErr != nil {
+					return tt.handlerErr
+				}
+				return c.NoContent(http.StatusOK)
+			}))
+
+			assert := assert.New(t)
+			assert.NoError(handler(ctx))
+			var got map[string]any
+			assert.NoError(json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal("request completed", got["msg"])
+			assert.Equal(tt.wantLevel, got["level"])
+			assert.Equal(tt.wantStatus, got["status"])
+			assert.Equal("GetCurrentUser", got["operation_id"])
+			assert.Equal("/api/v1/users/me", got["path"])
+			assert.Equal(tt.wantUserID, got["user_id"])
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// RequestMetrics records the duration of the HTTP requests, e.g. infrastructure.PrometheusMetrics.
+type RequestMetrics interface {
+	RequestServed(operation, method string, status int, duration time.Duration)
+}
+
+// unknownOperation is the operation of the requests without a route, their paths are not recorded
+// so a client cannot create a series per path.
+const unknownOperation = "unknown"
+
+// WithMetrics records the duration of every request by the operation ID of api.yml and the status,
+// the routes outside api.yml are recorded by their route, e.g. /blobs*.
+func WithMetrics(swagger *openapi3.T, metrics RequestMetrics) echo.MiddlewareFunc {
+	operationIDs := operationIDsByRoute(swagger)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			// the error is answered here to record its status
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			operation := operationIDs[routeKey(c.Request().Method, c.Path())]
+			if operation == "" {
+				operation = c.Path()
+			}
+			if operation == "" {
+				operation = unknownOperation
+			}
+			metrics.RequestServed(operation, c.Request().Method, c.Response().Status, time.Since(start))
+			return nil
+		}
+	}
+}
+
+// operationIDsByRoute maps the routes registered by generated.RegisterHandlers to their operation ID.
+func operationIDsByRoute(swagger *openapi3.T) map[string]string {
+	operationIDs := make(map[string]string)
+	for path, pathItem := range swagger.Paths {
+		// e.g. /api/v1/users/:id
+		echoPath := pathParamPattern.ReplaceAllString(path, ":$1")
+		for method, operation := range pathItem.Operations() {
+			operationIDs[routeKey(method, echoPath)] = operation.OperationID
+		}
+	}
+	return operationIDs
+}
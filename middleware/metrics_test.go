@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"userservice/generated"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type servedRequest struct {
+	operation string
+	method    string
+	status    int
+}
+
+type fakeRequestMetrics struct {
+	requests []servedRequest
+}
+
+func (frm *fakeRequestMetrics) RequestServed(operation, method string, status int, duration time.Duration) {
+	frm.requests = append(frm.requests, servedRequest{operation: operation, method: method, status: status})
+}
+
+func TestWithMetrics(t *testing.T) {
+	swagger, err := generated.GetSwagger()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		path       string
+		handlerErr error
+		want       servedRequest
+	}{
+		{
+			name: "when route is an operation, it should record the operation ID",
+			path: "/api/v1/users/:id",
+			want: servedRequest{operation: "GetUserByID", method: http.MethodGet, status: http.StatusOK},
+		},
+		{
+			name:       "when handler returns error, it should record its status",
+			path:       "/api/v1/users/:id",
+			handlerErr: echo.ErrNotFound,
+			want:       servedRequest{operation: "GetUserByID", method: http.MethodGet, status: http.StatusNotFound},
+		},
+		{
+			name: "when route is outside api.yml, it should record the route",
+			path: "/blobs*",
+			want: servedRequest{operation: "/blobs*", method: http.MethodGet, status: http.StatusOK},
+		},
+		{
+			name: "when request has no route, it should not record the path",
+			want: servedRequest{operation: "unknown", method: http.MethodGet, status: http.StatusOK},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := new(fakeRequestMetrics)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+			ctx := e.NewContext(req, httptest.NewRecorder())
+			ctx.SetPath(tt.path)
+
+			handler := WithMetrics(swagger, metrics)(func(c echo.Context) error {
+				if tt.handlerErr != nil {
+					return tt.handlerErr
+				}
+				return c.NoContent(http.StatusOK)
+			})
+
+			assert.NoError(t, handler(ctx))
+			assert.Equal(t, []servedRequest{tt.want}, metrics.requests)
+		})
+	}
+}
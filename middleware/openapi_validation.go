@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	customerror "userservice/internal/custom_error"
+	"userservice/internal/problem"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/labstack/echo/v4"
+)
+
+type OpenAPIValidationOptions struct {
+	// ResponseErrorHandler makes the responses validated as well, it is meant for the tests
+	// to catch the handlers drifting from api.yml. The response is already sent when it is called.
+	ResponseErrorHandler func(c echo.Context, err error)
+}
+
+// WithOpenAPIValidation rejects the requests which do not match api.yml before the handlers run,
+// the paths which are not in api.yml (e.g. the blobs) are not validated.
+// The credentials are validated by Security, so the security of api.yml is not checked here.
+func WithOpenAPIValidation(swagger *openapi3.T, opt *OpenAPIValidationOptions) echo.MiddlewareFunc {
+	// the servers of api.yml are for the local development, any host is accepted
+	swagger.Servers = nil
+	router, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		panic(err)
+	}
+	options := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route, pathParams, err := router.FindRoute(c.Request())
+			if err != nil {
+				return next(c)
+			}
+
+			requestInput := &openapi3filter.RequestValidationInput{
+				Request:    c.Request(),
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(c.Request().Context(), requestInput); err != nil {
+				validationError := customerror.NewValidationError()
+				parseOpenAPIError(validationError, err)
+				return problem.Write(c, problem.Validation(problem.ValidationItems(c, validationError)))
+			}
+
+			if opt == nil || opt.ResponseErrorHandler == nil {
+				return next(c)
+			}
+			return validateResponse(c, next, requestInput, opt.ResponseErrorHandler)
+		}
+	}
+}
+
+func validateResponse(c echo.Context, next echo.HandlerFunc, requestInput *openapi3filter.RequestValidationInput, onError func(echo.Context, error)) error {
+	body := new(bytes.Buffer)
+	writer := c.Response().Writer
+	c.Response().Writer = &teeResponseWriter{ResponseWriter: writer, body: body}
+	defer func() {
+		c.Response().Writer = writer
+	}()
+
+	if err := next(c); err != nil {
+		return err
+	}
+
+	err := openapi3filter.ValidateResponse(c.Request().Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: requestInput,
+		Status:                 c.Response().Status,
+		Header:                 c.Response().Header(),
+		Body:                   io.NopCloser(body),
+		Options: &openapi3filter.Options{
+			MultiError:            true,
+			IncludeResponseStatus: true,
+		},
+	})
+	if err != nil {
+		onError(c, err)
+	}
+	return nil
+}
+
+// teeResponseWriter keeps a copy of the response body to be validated.
+type teeResponseWriter struct {
+	http.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (trw *teeResponseWriter) Write(b []byte) (int, error) {
+	trw.body.Write(b)
+	return trw.ResponseWriter.Write(b)
+}
+
+// parseOpenAPIError adds a field error for every invalid parameter or request body field,
+// the body fields are named by their path, e.g. "messages.0.name".
+func parseOpenAPIError(validationError *customerror.ValidationError, err error) {
+	// errors.As would find the MultiError wrapped by a RequestError as well
+	if multiError, ok := err.(openapi3.MultiError); ok {
+		for _, err := range multiError {
+			parseOpenAPIError(validationError, err)
+		}
+		return
+	}
+
+	var requestError *openapi3filter.RequestError
+	if !errors.As(err, &requestError) {
+		validationError.AddError("request", customerror.CodeInvalid, nil)
+		return
+	}
+
+	name := "request body"
+	if requestError.Parameter != nil {
+		name = requestError.Parameter.Name
+	}
+	if requestError.Err == nil {
+		validationError.AddError(name, customerror.CodeInvalid, nil)
+		return
+	}
+
+	for _, err := range flattenMultiError(requestError.Err) {
+		var schemaError *openapi3.SchemaError
+		if !errors.As(err, &schemaError) {
+			code := customerror.CodeInvalid
+			if errors.Is(err, openapi3filter.ErrInvalidRequired) {
+				code = customerror.CodeRequired
+			}
+			validationError.AddError(name, code, nil)
+			continue
+		}
+
+		itemName := name
+		if path := schemaError.JSONPointer(); len(path) > 0 {
+			itemName = strings.Join(path, ".")
+		}
+		code, params := parseSchemaError(schemaError)
+		validationError.AddError(itemName, code, params)
+	}
+}
+
+// parseSchemaError returns the code of the failed keyword of the schema,
+// the reason of kin-openapi is not used as it is only in English.
+func parseSchemaError(err *openapi3.SchemaError) (string, customerror.Params) {
+	schema := err.Schema
+	switch err.SchemaField {
+	case "required":
+		return customerror.CodeRequired, nil
+	case "minLength":
+		return customerror.CodeMinLength, customerror.Params{"min": schema.MinLength}
+	case "maxLength":
+		if schema.MaxLength != nil {
+			return customerror.CodeMaxLength, customerror.Params{"max": *schema.MaxLength}
+		}
+	case "minimum", "maximum":
+		if schema.Min != nil && schema.Max != nil {
+			return customerror.CodeRangeBetween, customerror.Params{"min": *schema.Min, "max": *schema.Max}
+		}
+	case "enum":
+		values := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			values = append(values, fmt.Sprint(value))
+		}
+		return customerror.CodeOneOf, customerror.Params{"values": strings.Join(values, ", ")}
+	case "format":
+		if code, ok := formatCodes[schema.Format]; ok {
+			return code, nil
+		}
+	}
+	return customerror.CodeInvalid, nil
+}
+
+// formatCodes are the codes of the formats used in api.yml
+var formatCodes = map[string]string{
+	"uuid":      customerror.CodeInvalidUUID,
+	"email":     customerror.CodeInvalidEmail,
+	"date":      customerror.CodeInvalidDate,
+	"date-time": customerror.CodeInvalidDateTime,
+}
+
+// flattenMultiError returns the errors of the nested objects along with the others.
+func flattenMultiError(err error) []error {
+	multiError, ok := err.(openapi3.MultiError)
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, err := range multiError {
+		errs = append(errs, flattenMultiError(err)...)
+	}
+	return errs
+}
@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	customerror "userservice/internal/custom_error"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithOpenAPIValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		body      string
+		wantCode  int
+		wantNames []string
+	}{
+		{
+			name:     "when request matches the spec, it should call the handler",
+			method:   http.MethodPost,
+			path:     "/api/v1/users",
+			body:     `{"phoneNumber":"+628123456789","fullName":"John Doe","password":"Password1!"}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "when body fields are invalid, it should return every invalid field",
+			method:    http.MethodPost,
+			path:      "/api/v1/users",
+			body:      `{"phoneNumber":"phone","fullName":"Jo"}`,
+			wantCode:  http.StatusBadRequest,
+			wantNames: []string{"phoneNumber", "phoneNumber", "fullName", "password"},
+		},
+		{
+			name:      "when body is not json, it should return request body error",
+			method:    http.MethodPost,
+			path:      "/api/v1/users",
+			body:      `{`,
+			wantCode:  http.StatusBadRequest,
+			wantNames: []string{"request body"},
+		},
+		{
+			name:      "when query parameter is invalid, it should return the parameter",
+			method:    http.MethodGet,
+			path:      "/api/v1/admin/users/search?q=john&limit=1000",
+			wantCode:  http.StatusBadRequest,
+			wantNames: []string{"limit"},
+		},
+		{
+			name:     "when path is not in the spec, it should not validate",
+			method:   http.MethodGet,
+			path:     "/blobs/avatar.png",
+			wantCode: http.StatusOK,
+		},
+	}
+	swagger, err := generated.GetSwagger()
+	require.NoError(t, err)
+	middleware := WithOpenAPIValidation(swagger, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			handler := middleware(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+			err := handler(ctx)
+
+			assert := assert.New(t)
+			assert.NoError(err)
+			assert.Equal(tt.wantCode, rec.Code)
+			if tt.wantNames == nil {
+				return
+			}
+			var response generated.ErrorResponse
+			assert.NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal("ValidationError", response.Type)
+			var names []string
+			for _, message := range response.Messages {
+				names = append(names, message.Name)
+			}
+			assert.ElementsMatch(tt.wantNames, names)
+		})
+	}
+}
+
+func TestWithOpenAPIValidation_Messages(t *testing.T) {
+	type wantItem struct {
+		name   string
+		code   string
+		reason string
+	}
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		acceptLanguage string
+		want           []wantItem
+	}{
+		{
+			name: "when body fields are invalid, it should return the code of every field",
+			path: "/api/v1/users",
+			body: `{"phoneNumber":"+62812345678901234567890","fullName":"Jo"}`,
+			want: []wantItem{
+				{"phoneNumber", customerror.CodeMaxLength, "must be at most 20 characters in length"},
+				{"fullName", customerror.CodeMinLength, "must be at least 3 characters in length"},
+				{"password", customerror.CodeRequired, "is required"},
+			},
+		},
+		{
+			name:           "when language is accepted, it should return the messages in the language",
+			path:           "/api/v1/users",
+			body:           `{"phoneNumber":"+628123456789","fullName":"Jo"}`,
+			acceptLanguage: "id-ID,id;q=0.9,en;q=0.8",
+			want: []wantItem{
+				{"fullName", customerror.CodeMinLength, "harus terdiri dari minimal 3 karakter"},
+				{"password", customerror.CodeRequired, "wajib diisi"},
+			},
+		},
+		{
+			name: "when body fields have an invalid enum or format, it should return the code of the keyword",
+			path: "/api/v1/users/me/api-keys",
+			body: `{"name":"ci","scopes":["users:delete"],"expiresAt":"tomorrow"}`,
+			want: []wantItem{
+				{"scopes.0", customerror.CodeOneOf, "must be one of users:read, users:write"},
+				{"expiresAt", customerror.CodeInvalidDateTime, "must be a date and time in RFC 3339 format, e.g. 2024-01-31T13:00:00Z"},
+			},
+		},
+		{
+			name: "when query parameter is out of range, it should return the range",
+			path: "/api/v1/admin/users/search?q=john&limit=1000",
+			want: []wantItem{
+				{"limit", customerror.CodeRangeBetween, "must be between 1 and 100"},
+			},
+		},
+	}
+	swagger, err := generated.GetSwagger()
+	require.NoError(t, err)
+	middleware := WithOpenAPIValidation(swagger, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := http.MethodGet
+			if tt.body != "" {
+				method = http.MethodPost
+			}
+			e := echo.New()
+			req := httptest.NewRequest(method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+
+			handler := middleware(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+
+			assert := assert.New(t)
+			assert.NoError(handler(ctx))
+			assert.Equal(http.StatusBadRequest, rec.Code)
+			var response generated.ErrorResponse
+			assert.NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+			var got []wantItem
+			for _, message := range response.Messages {
+				got = append(got, wantItem{message.Name, *message.Code, message.Reason})
+			}
+			assert.ElementsMatch(tt.want, got)
+		})
+	}
+}
+
+func TestWithOpenAPIValidation_Response(t *testing.T) {
+	swagger, err := generated.GetSwagger()
+	require.NoError(t, err)
+
+	var responseErr error
+	middleware := WithOpenAPIValidation(swagger, &OpenAPIValidationOptions{
+		ResponseErrorHandler: func(c echo.Context, err error) {
+			responseErr = err
+		},
+	})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	handler := middleware(func(c echo.Context) error {
+		// fullName is required by the spec
+		return c.JSON(http.StatusOK, map[string]string{"phoneNumber": "+628123456789"})
+	})
+	err = handler(ctx)
+
+	assert := assert.New(t)
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Error(responseErr)
+}
@@ -0,0 +1,419 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"userservice/internal/auth"
+	"userservice/internal/logging"
+	"userservice/internal/problem"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+	"userservice/internal/user/port/driver"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// MutualTLSExtension marks the security schemes of api.yml which are satisfied by a client certificate,
+// OpenAPI 3.0 has no mutualTLS type so these schemes are declared with another type which is ignored.
+const MutualTLSExtension = "x-mutual-tls"
+
+// ErrMissingCredentials is returned by SecurityScheme when the request has no credentials of the scheme,
+// the other schemes of the operation are tried then.
+var ErrMissingCredentials = errors.New("missing credentials")
+
+// SecurityScheme authenticates the requests of a security scheme declared in api.yml.
+type SecurityScheme interface {
+	// Authenticate returns ErrMissingCredentials when the request has no credentials of the scheme
+	// and a *SchemeError when the credentials are not valid or not allowed.
+	Authenticate(c echo.Context) (*auth.Claims, error)
+	// Challenge is the WWW-Authenticate challenge of the scheme, empty when it has none.
+	Challenge() string
+}
+
+// SchemeError is answered with 401, or 403 when Forbidden is set.
+type SchemeError struct {
+	Forbidden bool
+	// Code is the error code of the challenge, e.g. invalid_token
+	Code   string
+	Detail string
+}
+
+func (se *SchemeError) Error() string {
+	return se.Detail
+}
+
+type SecurityOptions struct {
+	// Schemes authenticate the schemes of api.yml by their name, every scheme used by an operation must be set
+	Schemes map[string]SecurityScheme
+	// PublicPaths are the routes outside api.yml which need no credentials by method, e.g. the blobs
+	PublicPaths map[string][]string
+	// Metrics counts the rejected credentials by the error code of the challenge, optional
+	Metrics driven.Metrics
+}
+
+// Security authenticates every operation with the security requirements of api.yml,
+// an operation is public only when it declares `security: []`.
+type Security struct {
+	schemes      map[string]SecurityScheme
+	requirements map[string]openapi3.SecurityRequirements
+	metrics      driven.Metrics
+}
+
+var pathParamPattern = regexp.MustCompile(`{([^}]+)}`)
+
+// NewSecurity fails when an operation of api.yml has no security declared
+// or uses a scheme which is not declared or has no SecurityScheme in the options.
+// A scheme with MutualTLSExtension must be a ClientCertificate and the other schemes must not be.
+func NewSecurity(swagger *openapi3.T, opt *SecurityOptions) (*Security, error) {
+	var declaredSchemes openapi3.SecuritySchemes
+	if swagger.Components != nil {
+		declaredSchemes = swagger.Components.SecuritySchemes
+	}
+	security := &Security{
+		schemes:      opt.Schemes,
+		requirements: make(map[string]openapi3.SecurityRequirements),
+		metrics:      opt.Metrics,
+	}
+
+	for path, pathItem := range swagger.Paths {
+		// the same path as generated.RegisterHandlers, e.g. /api/v1/users/:id
+		echoPath := pathParamPattern.ReplaceAllString(path, ":$1")
+		for method, operation := range pathItem.Operations() {
+			requirements := operation.Security
+			if requirements == nil && swagger.Security != nil {
+				requirements = &swagger.Security
+			}
+			if requirements == nil {
+				return nil, fmt.Errorf("%s %s has no security, use `security: []` for a public operation", method, path)
+			}
+
+			for _, requirement := range *requirements {
+				for name := range requirement {
+					if declaredSchemes[name] == nil {
+						return nil, fmt.Errorf("%s %s uses the undeclared security scheme %s", method, path, name)
+					}
+					if opt.Schemes[name] == nil {
+						return nil, fmt.Errorf("%s %s uses the security scheme %s which is not supported", method, path, name)
+					}
+					_, isClientCertificate := opt.Schemes[name].(*clientCertificate)
+					if isMutualTLS(declaredSchemes[name]) != isClientCertificate {
+						return nil, fmt.Errorf("%s %s uses the security scheme %s whose %s does not match its SecurityScheme", method, path, name, MutualTLSExtension)
+					}
+				}
+			}
+			security.requirements[routeKey(method, echoPath)] = *requirements
+		}
+	}
+
+	for method, paths := range opt.PublicPaths {
+		for _, path := range paths {
+			security.requirements[routeKey(method, path)] = openapi3.SecurityRequirements{}
+		}
+	}
+	return security, nil
+}
+
+// CheckRoutes fails when a route has no security, it is called once all the routes are registered.
+func (s *Security) CheckRoutes(routes []*echo.Route) error {
+	for _, route := range routes {
+		if _, ok := s.requirements[routeKey(route.Method, route.Path)]; !ok {
+			return fmt.Errorf("%s %s has no security, it must be declared in api.yml or be a public path", route.Method, route.Path)
+		}
+	}
+	return nil
+}
+
+// Middleware answers 401 when no scheme of the operation is satisfied and 403 when the credentials
+// are valid but cannot access the operation, the claims are put into the context with withClaims.
+// The requests of the routes without security (e.g. the unknown paths) are answered with 404.
+func (s *Security) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			requirements, ok := s.requirements[routeKey(c.Request().Method, c.Path())]
+			if !ok {
+				return problem.Write(c, problem.New(problem.CodeNotFound, "The resource is not found."))
+			}
+			if len(requirements) == 0 {
+				return next(c)
+			}
+
+			claims, err := s.authenticate(c, requirements)
+			if err != nil && errorRank(err) == unexpectedErrorRank {
+				logging.FromContext(c.Request().Context()).Error("failed to authenticate", "error", err)
+				return problem.Write(c, problem.New(problem.CodeInternalError, "The request cannot be authenticated now."))
+			}
+			if err != nil {
+				return s.writeError(c, requirements, err)
+			}
+			if claims != nil {
+				c.SetRequest(c.Request().WithContext(withClaims(c.Request().Context(), claims)))
+			}
+			return next(c)
+		}
+	}
+}
+
+// authenticate returns the claims of the first satisfied requirement, a requirement is satisfied
+// when all of its schemes are. The most relevant error is returned when none is satisfied:
+// a forbidden one over an invalid one over the missing credentials.
+func (s *Security) authenticate(c echo.Context, requirements openapi3.SecurityRequirements) (*auth.Claims, error) {
+	var lastErr error = ErrMissingCredentials
+	for _, requirement := range requirements {
+		claims, err := s.authenticateRequirement(c, requirement)
+		if err == nil {
+			return claims, nil
+		}
+		if errorRank(err) > errorRank(lastErr) {
+			lastErr = err
+		}
+	}
+	return nil, lastErr
+}
+
+func (s *Security) authenticateRequirement(c echo.Context, requirement openapi3.SecurityRequirement) (*auth.Claims, error) {
+	var claims *auth.Claims
+	// sorted so the claims of the same scheme are kept on every request
+	for _, name := range sortedSchemeNames(requirement) {
+		schemeClaims, err := s.schemes[name].Authenticate(c)
+		if err != nil {
+			return nil, err
+		}
+		if !schemeClaims.HasScopes(requirement[name]) {
+			return nil, &SchemeError{
+				Forbidden: true,
+				Code:      "insufficient_scope",
+				Detail:    "The credentials are not granted the scopes of the resource.",
+			}
+		}
+		if claims == nil {
+			claims = schemeClaims
+		}
+	}
+	return claims, nil
+}
+
+func (s *Security) writeError(c echo.Context, requirements openapi3.SecurityRequirements, err error) error {
+	var schemeError *SchemeError
+	if !errors.As(err, &schemeError) && c.Request().Header.Get(echo.HeaderAuthorization) != "" {
+		schemeError = &SchemeError{Code: "invalid_request", Detail: "The authorization scheme is not supported."}
+	} else if schemeError == nil {
+		// no error code when the request has no credentials, see RFC 6750 section 3.1
+		s.tokenValidationFailed(missingCredentialsReason)
+		for _, challenge := range s.challenges(requirements) {
+			c.Response().Header().Add(echo.HeaderWWWAuthenticate, challenge)
+		}
+		return problem.Write(c, problem.New(problem.CodeUnauthorized, "The credentials are missing."))
+	}
+
+	s.tokenValidationFailed(schemeError.Code)
+	for _, challenge := range s.challenges(requirements) {
+		if schemeError.Code != "" {
+			challenge += fmt.Sprintf(`, error="%s", error_description="%s"`, schemeError.Code, quotedStringEscaper.Replace(schemeError.Detail))
+		}
+		c.Response().Header().Add(echo.HeaderWWWAuthenticate, challenge)
+	}
+	if schemeError.Forbidden {
+		return problem.Write(c, problem.New(problem.CodeForbidden, schemeError.Detail))
+	}
+	return problem.Write(c, problem.New(problem.CodeUnauthorized, schemeError.Detail))
+}
+
+// quotedStringEscaper escapes the values of the challenge parameters, see RFC 7230 section 3.2.6.
+var quotedStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// missingCredentialsReason is the reason of the rejected requests without credentials,
+// the other reasons are the error codes of the challenges, e.g. invalid_token.
+const missingCredentialsReason = "missing_credentials"
+
+func (s *Security) tokenValidationFailed(reason string) {
+	if s.metrics != nil {
+		s.metrics.TokenValidationFailed(reason)
+	}
+}
+
+// challenges returns the distinct challenges of the schemes, e.g. the bearer schemes share a single one.
+func (s *Security) challenges(requirements openapi3.SecurityRequirements) []string {
+	var challenges []string
+	for _, requirement := range requirements {
+		for _, name := range sortedSchemeNames(requirement) {
+			challenge := s.schemes[name].Challenge()
+			if challenge != "" && !slices.Contains(challenges, challenge) {
+				challenges = append(challenges, challenge)
+			}
+		}
+	}
+	return challenges
+}
+
+// unexpectedErrorRank is the rank of the errors which are not about the credentials, e.g. the database is down.
+const unexpectedErrorRank = 3
+
+func errorRank(err error) int {
+	var schemeError *SchemeError
+	switch {
+	case errors.Is(err, ErrMissingCredentials):
+		return 0
+	case !errors.As(err, &schemeError):
+		return unexpectedErrorRank
+	case schemeError.Forbidden:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func isMutualTLS(scheme *openapi3.SecuritySchemeRef) bool {
+	if scheme.Value == nil {
+		return false
+	}
+	mutualTLS, _ := scheme.Value.Extensions[MutualTLSExtension].(bool)
+	return mutualTLS
+}
+
+func sortedSchemeNames(requirement openapi3.SecurityRequirement) []string {
+	names := make([]string, 0, len(requirement))
+	for name := range requirement {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// bearerJWT is the bearer token issued by driven.TokenProvider to the users or to the clients.
+type bearerJWT struct {
+	tokenProvider driven.TokenProvider[*entity.User]
+	clientType    string
+}
+
+// UserJWT accepts the tokens of the users.
+func UserJWT(tokenProvider driven.TokenProvider[*entity.User]) SecurityScheme {
+	return &bearerJWT{tokenProvider: tokenProvider}
+}
+
+// ClientJWT accepts the client credential tokens of the client type, e.g. entity.ServiceClientType,
+// they are created with cmd/clienttoken.
+func ClientJWT(tokenProvider driven.TokenProvider[*entity.User], clientType string) SecurityScheme {
+	return &bearerJWT{tokenProvider: tokenProvider, clientType: clientType}
+}
+
+func (bj *bearerJWT) Authenticate(c echo.Context) (*auth.Claims, error) {
+	token, ok := authorizationCredentials(c, "Bearer")
+	if !ok {
+		return nil, ErrMissingCredentials
+	}
+	tokenClaims, err := bj.tokenProvider.ValidateJWT(token)
+	if err != nil {
+		return nil, &SchemeError{Code: "invalid_token", Detail: "The access token is malformed, expired or revoked."}
+	}
+
+	claims := auth.NewClaims(tokenClaims)
+	if claims.ClientType != bj.clientType {
+		return nil, &SchemeError{
+			Forbidden: true,
+			Code:      "insufficient_scope",
+			Detail:    "The access token is not allowed to access the resource.",
+		}
+	}
+	return claims, nil
+}
+
+func (bj *bearerJWT) Challenge() string {
+	return `Bearer realm="userservice"`
+}
+
+// clientCertificate is the client certificate verified against the client CA during the TLS handshake.
+type clientCertificate struct {
+	clientType string
+}
+
+// ClientCertificate accepts the client certificates of the client type, e.g. entity.ServiceClientType,
+// the common name of the subject is the client like the subject of ClientJWT.
+// The certificates are verified by the TLS server, see tlsconfig.Options.ClientCAPath.
+// The scheme in api.yml must have MutualTLSExtension set to true.
+func ClientCertificate(clientType string) SecurityScheme {
+	return &clientCertificate{clientType: clientType}
+}
+
+func (cc *clientCertificate) Authenticate(c echo.Context) (*auth.Claims, error) {
+	// a certificate without verified chain is not trusted, e.g. when no client CA is configured
+	state := c.Request().TLS
+	if state == nil || len(state.VerifiedChains) == 0 {
+		return nil, ErrMissingCredentials
+	}
+
+	subject := state.VerifiedChains[0][0].Subject.CommonName
+	if subject == "" {
+		return nil, &SchemeError{Code: "invalid_certificate", Detail: "The client certificate has no common name."}
+	}
+	return &auth.Claims{
+		Subject:    subject,
+		ClientType: cc.clientType,
+	}, nil
+}
+
+// Challenge is empty, the certificate is requested by the TLS handshake.
+func (cc *clientCertificate) Challenge() string {
+	return ""
+}
+
+// APIKeyValidator returns the claims of the key, or a *SchemeError when the key is not valid.
+type APIKeyValidator func(c echo.Context, key string) (*auth.Claims, error)
+
+type apiKey struct {
+	validate APIKeyValidator
+}
+
+// APIKey accepts the keys sent as `Authorization: ApiKey <key>`, the scheme in api.yml must be
+// `type: apiKey` with `in: header` and `name: Authorization`.
+func APIKey(validate APIKeyValidator) SecurityScheme {
+	return &apiKey{validate: validate}
+}
+
+// UserAPIKey accepts the API keys of the users, the key acts as its user with the scopes of the key.
+func UserAPIKey(apiKeyUsecase driver.APIKeyUsecase) SecurityScheme {
+	return APIKey(func(c echo.Context, key string) (*auth.Claims, error) {
+		userAPIKey, err := apiKeyUsecase.AuthenticateAPIKey(c.Request().Context(), key)
+		if errors.Is(err, entity.ErrInvalidAPIKey) {
+			return nil, &SchemeError{Code: "invalid_token", Detail: "The API key is malformed, expired or revoked."}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &auth.Claims{
+			Subject: userAPIKey.UserID,
+			Scopes:  userAPIKey.Scopes,
+		}, nil
+	})
+}
+
+func (ak *apiKey) Authenticate(c echo.Context) (*auth.Claims, error) {
+	key, ok := authorizationCredentials(c, entity.APIKeyAuthorizationScheme)
+	if !ok {
+		return nil, ErrMissingCredentials
+	}
+	return ak.validate(c, key)
+}
+
+func (ak *apiKey) Challenge() string {
+	return entity.APIKeyAuthorizationScheme + ` realm="userservice"`
+}
+
+// authorizationCredentials returns the credentials of the Authorization header when it has the scheme,
+// the scheme is case insensitive, see RFC 7235 section 2.1.
+func authorizationCredentials(c echo.Context, scheme string) (string, bool) {
+	authScheme, credentials, ok := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(authScheme, scheme) {
+		return "", false
+	}
+	return strings.TrimSpace(credentials), true
+}
@@ -0,0 +1,651 @@
+package middleware
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"userservice/generated"
+	"userservice/internal/auth"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/param/request"
+	"userservice/internal/user/port/driver"
+	"userservice/test/fake"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSecurity(t *testing.T, apiKeyUsecase driver.APIKeyUsecase) *Security {
+	swagger, err := generated.GetSwagger()
+	require.NoError(t, err)
+	tokenProvider := new(fake.FakeTokenProvider)
+	security, err := NewSecurity(swagger, &SecurityOptions{
+		Schemes: map[string]SecurityScheme{
+			"bearerAuth":         UserJWT(tokenProvider),
+			"serviceAuth":        ClientJWT(tokenProvider, entity.ServiceClientType),
+			"serviceCertificate": ClientCertificate(entity.ServiceClientType),
+			"adminAuth":          ClientJWT(tokenProvider, entity.AdminClientType),
+			"monitoringAuth":     ClientJWT(tokenProvider, entity.MonitoringClientType),
+			"apiKeyAuth":         UserAPIKey(apiKeyUsecase),
+		},
+		PublicPaths: map[string][]string{
+			http.MethodGet: {"/blobs*"},
+		},
+	})
+	require.NoError(t, err)
+	return security
+}
+
+func TestSecurity_Middleware(t *testing.T) {
+	fu := fake.NewFakeUserUsecase()
+	readKey, err := fu.CreateAPIKey(context.Background(), "user-id", &request.CreateAPIKey{
+		Name:   "script",
+		Scopes: []string{entity.APIKeyScopeUsersRead},
+	})
+	require.NoError(t, err)
+	revokedKey, err := fu.CreateAPIKey(context.Background(), "user-id", &request.CreateAPIKey{
+		Name:   "old script",
+		Scopes: []string{entity.APIKeyScopeUsersRead},
+	})
+	require.NoError(t, err)
+	require.NoError(t, fu.RevokeAPIKey(context.Background(), "user-id", revokedKey.ID))
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		token         string
+		// clientCert is the common name of a verified client certificate
+		clientCert    string
+		wantCode      int
+		wantChallenge string
+	}{
+		{
+			name:     "when operation is public, it should not need token",
+			method:   http.MethodPost,
+			path:     "/api/v1/users",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "when operation with path parameter is public, it should not need token",
+			method:   http.MethodGet,
+			path:     "/api/v1/users/:id",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "when path is public, it should not need token",
+			method:   http.MethodGet,
+			path:     "/blobs*",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "when route has no security, it should return not found",
+			method:   http.MethodDelete,
+			path:     "/api/v1/users/me",
+			token:    "user-id",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:          "when token is missing, it should return unauthorized",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/me",
+			wantCode:      http.StatusUnauthorized,
+			wantChallenge: `Bearer realm="userservice"`,
+		},
+		{
+			name:          "when token is invalid, it should return unauthorized",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/me",
+			token:         "invalid",
+			wantCode:      http.StatusUnauthorized,
+			wantChallenge: `error="invalid_token", error_description="The access token is malformed, expired or revoked."`,
+		},
+		{
+			name:          "when scheme is not bearer, it should return unauthorized",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/me",
+			authorization: "Basic dXNlcjpwYXNz",
+			wantCode:      http.StatusUnauthorized,
+			wantChallenge: `error="invalid_request", error_description="The authorization scheme is not supported."`,
+		},
+		{
+			name:     "when user token used, it should access user path",
+			method:   http.MethodGet,
+			path:     "/api/v1/users/me",
+			token:    "user-id",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:          "when service token used, it should not access user path",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/me",
+			token:         "service-token",
+			wantCode:      http.StatusForbidden,
+			wantChallenge: `error="insufficient_scope", error_description="The access token is not allowed to access the resource."`,
+		},
+		{
+			name:     "when user token used, it should not access internal path",
+			method:   http.MethodPost,
+			path:     "/api/v1/internal/users:batchGet",
+			token:    "user-id",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "when service token used, it should access internal path",
+			method:   http.MethodPost,
+			path:     "/api/v1/internal/users:batchGet",
+			token:    "service-token",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:          "when service token used, it should not access admin path",
+			method:        http.MethodGet,
+			path:          "/api/v1/admin/users/search",
+			token:         "service-token",
+			wantCode:      http.StatusForbidden,
+			wantChallenge: `error="insufficient_scope", error_description="The access token is not allowed to access the resource."`,
+		},
+		{
+			name:     "when admin token used, it should access admin path",
+			method:   http.MethodGet,
+			path:     "/api/v1/admin/users/search",
+			token:    "admin-token",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "when admin token used, it should not access user path",
+			method:   http.MethodGet,
+			path:     "/api/v1/users/me",
+			token:    "admin-token",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:       "when client certificate verified, it should access internal path",
+			method:     http.MethodPost,
+			path:       "/api/v1/internal/users:batchGet",
+			clientCert: "order-service",
+			wantCode:   http.StatusOK,
+		},
+		{
+			name:          "when client certificate verified, it should not access user path",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/me",
+			clientCert:    "order-service",
+			wantCode:      http.StatusUnauthorized,
+			wantChallenge: `Bearer realm="userservice"`,
+		},
+		{
+			name:     "when monitoring token used, it should access metrics",
+			method:   http.MethodGet,
+			path:     "/metrics",
+			token:    "monitoring-token",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:          "when user token used, it should not access metrics",
+			method:        http.MethodGet,
+			path:          "/metrics",
+			token:         "user-id",
+			wantCode:      http.StatusForbidden,
+			wantChallenge: `error="insufficient_scope", error_description="The access token is not allowed to access the resource."`,
+		},
+		{
+			name:     "when monitoring token used, it should not access user path",
+			method:   http.MethodGet,
+			path:     "/api/v1/users/me",
+			token:    "monitoring-token",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:          "when api key has the scope, it should access user path",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/me",
+			authorization: "ApiKey " + readKey.Key,
+			wantCode:      http.StatusOK,
+		},
+		{
+			name:          "when api key does not have the scope, it should return forbidden",
+			method:        http.MethodPatch,
+			path:          "/api/v1/users/me",
+			authorization: "ApiKey " + readKey.Key,
+			wantCode:      http.StatusForbidden,
+			wantChallenge: `error="insufficient_scope", error_description="The credentials are not granted the scopes of the resource."`,
+		},
+		{
+			name:          "when api key is revoked, it should return unauthorized",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/me",
+			authorization: "ApiKey " + revokedKey.Key,
+			wantCode:      http.StatusUnauthorized,
+			wantChallenge: `error="invalid_token", error_description="The API key is malformed, expired or revoked."`,
+		},
+		{
+			name:          "when api key is used on operation without api key, it should return unauthorized",
+			method:        http.MethodPut,
+			path:          "/api/v1/users/me/password",
+			authorization: "ApiKey " + readKey.Key,
+			wantCode:      http.StatusUnauthorized,
+			wantChallenge: `error="invalid_request", error_description="The authorization scheme is not supported."`,
+		},
+	}
+	middleware := newTestSecurity(t, fu).Middleware()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, "/", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.clientCert != "" {
+				req.TLS = &tls.ConnectionState{
+					VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: tt.clientCert}}}},
+				}
+			}
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetPath(tt.path)
+
+			handler := middleware(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+			err := handler(ctx)
+
+			assert := assert.New(t)
+			assert.NoError(err)
+			assert.Equal(tt.wantCode, rec.Code)
+			assert.Contains(rec.Header().Get(echo.HeaderWWWAuthenticate), tt.wantChallenge)
+			if tt.wantCode != http.StatusOK && tt.wantCode != http.StatusNotFound {
+				var response generated.ErrorResponse
+				assert.NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(http.StatusText(tt.wantCode), response.Type)
+			}
+		})
+	}
+}
+
+const testSecuritySpec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Test
+paths:
+  /keys:
+    get:
+      security:
+        - apiKeyAuth: [users:read]
+        - bearerAuth: []
+      responses:
+        "200":
+          description: OK
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+    apiKeyAuth:
+      type: apiKey
+      in: header
+      name: Authorization
+`
+
+func TestSecurity_Middleware_APIKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		apiKey        string
+		token         string
+		wantCode      int
+		wantSubject   string
+		wantChallenge string
+	}{
+		{
+			name:        "when api key has the scope, it should access the operation",
+			apiKey:      "reader",
+			wantCode:    http.StatusOK,
+			wantSubject: "key-owner",
+		},
+		{
+			name:     "when api key does not have the scope, it should return forbidden",
+			apiKey:   "writer",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:          "when api key is not valid, it should return unauthorized",
+			apiKey:        "unknown",
+			wantCode:      http.StatusUnauthorized,
+			wantChallenge: `error="invalid_token", error_description="The API key \"unknown\" is not valid."`,
+		},
+		{
+			name:        "when other scheme is satisfied, it should access the operation",
+			token:       "user-id",
+			wantCode:    http.StatusOK,
+			wantSubject: "user-id",
+		},
+		{
+			name:     "when no credentials are sent, it should return unauthorized",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(testSecuritySpec))
+	require.NoError(t, err)
+	security, err := NewSecurity(swagger, &SecurityOptions{
+		Schemes: map[string]SecurityScheme{
+			"bearerAuth": UserJWT(new(fake.FakeTokenProvider)),
+			"apiKeyAuth": APIKey(func(c echo.Context, key string) (*auth.Claims, error) {
+				switch key {
+				case "reader":
+					return &auth.Claims{Subject: "key-owner", Scopes: []string{"users:read"}}, nil
+				case "writer":
+					return &auth.Claims{Subject: "key-owner", Scopes: []string{"users:write"}}, nil
+				default:
+					return nil, &SchemeError{Code: "invalid_token", Detail: fmt.Sprintf("The API key %q is not valid.", key)}
+				}
+			}),
+		},
+	})
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/keys", nil)
+			if tt.apiKey != "" {
+				req.Header.Set("Authorization", "ApiKey "+tt.apiKey)
+			}
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetPath("/keys")
+
+			var subject string
+			handler := security.Middleware()(func(c echo.Context) error {
+				claims, _ := auth.ClaimsFromContext(c.Request().Context())
+				subject = claims.Subject
+				return c.NoContent(http.StatusOK)
+			})
+
+			assert := assert.New(t)
+			assert.NoError(handler(ctx))
+			assert.Equal(tt.wantCode, rec.Code)
+			assert.Equal(tt.wantSubject, subject)
+			if tt.wantChallenge != "" {
+				assert.Contains(rec.Header().Get(echo.HeaderWWWAuthenticate), tt.wantChallenge)
+			}
+		})
+	}
+}
+
+func TestNewSecurity(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		schemes map[string]SecurityScheme
+		wantErr string
+	}{
+		{
+			name: "when operation has no security, it should fail",
+			spec: `
+openapi: "3.0.0"
+info: {version: 1.0.0, title: Test}
+paths:
+  /keys:
+    get:
+      responses:
+        "200": {description: OK}
+`,
+			wantErr: "GET /keys has no security",
+		},
+		{
+			name: "when scheme is not supported, it should fail",
+			spec: `
+openapi: "3.0.0"
+info: {version: 1.0.0, title: Test}
+paths:
+  /keys:
+    get:
+      security:
+        - bearerAuth: []
+      responses:
+        "200": {description: OK}
+components:
+  securitySchemes:
+    bearerAuth: {type: http, scheme: bearer}
+`,
+			wantErr: "security scheme bearerAuth which is not supported",
+		},
+		{
+			name: "when scheme is not declared, it should fail",
+			spec: `
+openapi: "3.0.0"
+info: {version: 1.0.0, title: Test}
+paths:
+  /keys:
+    get:
+      security:
+        - bearerAuth: []
+      responses:
+        "200": {description: OK}
+`,
+			schemes: map[string]SecurityScheme{"bearerAuth": UserJWT(new(fake.FakeTokenProvider))},
+			wantErr: "undeclared security scheme bearerAuth",
+		},
+		{
+			name: "when mutual TLS scheme is not a client certificate, it should fail",
+			spec: `
+openapi: "3.0.0"
+info: {version: 1.0.0, title: Test}
+paths:
+  /keys:
+    get:
+      security:
+        - serviceCertificate: []
+      responses:
+        "200": {description: OK}
+components:
+  securitySchemes:
+    serviceCertificate: {type: apiKey, in: header, name: X-Client-Certificate, x-mutual-tls: true}
+`,
+			schemes: map[string]SecurityScheme{"serviceCertificate": UserJWT(new(fake.FakeTokenProvider))},
+			wantErr: "security scheme serviceCertificate whose x-mutual-tls does not match",
+		},
+		{
+			name: "when client certificate scheme is not mutual TLS, it should fail",
+			spec: `
+openapi: "3.0.0"
+info: {version: 1.0.0, title: Test}
+paths:
+  /keys:
+    get:
+      security:
+        - serviceCertificate: []
+      responses:
+        "200": {description: OK}
+components:
+  securitySchemes:
+    serviceCertificate: {type: apiKey, in: header, name: X-Client-Certificate}
+`,
+			schemes: map[string]SecurityScheme{"serviceCertificate": ClientCertificate(entity.ServiceClientType)},
+			wantErr: "security scheme serviceCertificate whose x-mutual-tls does not match",
+		},
+		{
+			name: "when mutual TLS scheme is a client certificate, it should succeed",
+			spec: `
+openapi: "3.0.0"
+info: {version: 1.0.0, title: Test}
+paths:
+  /keys:
+    get:
+      security:
+        - serviceCertificate: []
+      responses:
+        "200": {description: OK}
+components:
+  securitySchemes:
+    serviceCertificate: {type: apiKey, in: header, name: X-Client-Certificate, x-mutual-tls: true}
+`,
+			schemes: map[string]SecurityScheme{"serviceCertificate": ClientCertificate(entity.ServiceClientType)},
+		},
+		{
+			name: "when document has security, it should be used by the operations",
+			spec: `
+openapi: "3.0.0"
+info: {version: 1.0.0, title: Test}
+security:
+  - bearerAuth: []
+paths:
+  /keys:
+    get:
+      responses:
+        "200": {description: OK}
+components:
+  securitySchemes:
+    bearerAuth: {type: http, scheme: bearer}
+`,
+			schemes: map[string]SecurityScheme{"bearerAuth": UserJWT(new(fake.FakeTokenProvider))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			swagger, err := openapi3.NewLoader().LoadFromData([]byte(tt.spec))
+			require.NoError(t, err)
+
+			_, err = NewSecurity(swagger, &SecurityOptions{Schemes: tt.schemes})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSecurity_Middleware_UnexpectedError(t *testing.T) {
+	middleware := newTestSecurity(t, fake.NewFakeUserUsecase()).Middleware()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "ApiKey usk_000000000000_"+strings.Repeat("0", 64))
+	req = req.WithContext(context.WithValue(req.Context(), "api_key_error", true))
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetPath("/api/v1/users/me")
+
+	err := middleware(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestSecurity_Middleware_Metrics(t *testing.T) {
+	fm := fake.NewFakeMetrics()
+	security := newTestSecurity(t, fake.NewFakeUserUsecase())
+	security.metrics = fm
+	middleware := security.Middleware()
+
+	for _, token := range []string{"", "invalid", "invalid", "service-token", "user-id"} {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		ctx := e.NewContext(req, httptest.NewRecorder())
+		ctx.SetPath("/api/v1/users/me")
+
+		err := middleware(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})(ctx)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, map[string]int{
+		"missing_credentials": 1,
+		"invalid_token":       2,
+		"insufficient_scope":  1,
+	}, fm.TokenValidationFailures)
+}
+
+func TestClientCertificate_Authenticate(t *testing.T) {
+	tests := []struct {
+		name       string
+		tlsState   *tls.ConnectionState
+		wantClaims *auth.Claims
+		wantErr    error
+	}{
+		{
+			name: "when certificate is verified, it should return the service of the common name",
+			tlsState: &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "order-service"}}}},
+			},
+			wantClaims: &auth.Claims{Subject: "order-service", ClientType: entity.ServiceClientType},
+		},
+		{
+			name:    "when request is not TLS, it should return missing credentials",
+			wantErr: ErrMissingCredentials,
+		},
+		{
+			name: "when certificate is not verified, it should return missing credentials",
+			tlsState: &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "order-service"}}},
+			},
+			wantErr: ErrMissingCredentials,
+		},
+		{
+			name: "when certificate has no common name, it should return scheme error",
+			tlsState: &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{Organization: []string{"acme"}}}}},
+			},
+			wantErr: &SchemeError{Code: "invalid_certificate", Detail: "The client certificate has no common name."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.TLS = tt.tlsState
+			ctx := echo.New().NewContext(req, httptest.NewRecorder())
+
+			claims, err := ClientCertificate(entity.ServiceClientType).Authenticate(ctx)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantClaims, claims)
+		})
+	}
+}
+
+func TestSecurity_CheckRoutes(t *testing.T) {
+	security := newTestSecurity(t, fake.NewFakeUserUsecase())
+
+	e := echo.New()
+	generated.RegisterHandlers(e, nil)
+	e.Static("/blobs", t.TempDir())
+	assert.NoError(t, security.CheckRoutes(e.Routes()))
+
+	e.GET("/debug", func(c echo.Context) error { return nil })
+	assert.ErrorContains(t, security.CheckRoutes(e.Routes()), "GET /debug has no security")
+}
+
+// the mutual TLS scheme is declared with MutualTLSExtension so api.yml stays a valid OpenAPI 3.0 document
+func TestNewSecurity_ValidSpec(t *testing.T) {
+	swagger, err := generated.GetSwagger()
+	require.NoError(t, err)
+
+	assert.Equal(t, "3.0.0", swagger.OpenAPI)
+	assert.NoError(t, swagger.Validate(context.Background()))
+}
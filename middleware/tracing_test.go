@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"userservice/internal/tracing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider, err := tracing.Setup(context.Background(), &tracing.Options{Exporter: exporter})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		traceparent string
+		handlerErr  error
+		wantTraceID string
+		wantStatus  codes.Code
+	}{
+		{
+			name:        "when request has trace context, it should continue the trace",
+			traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantStatus:  codes.Unset,
+		},
+		{
+			name:       "when handler returns server error, it should set error status",
+			handlerErr: echo.ErrInternalServerError,
+			wantStatus: codes.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter.Reset()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+			req.Header.Set("traceparent", tt.traceparent)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetPath("/api/v1/users/:id")
+
+			handler := WithTracing()(func(c echo.Context) error {
+				if tt.handlerErr != nil {
+					return tt.handlerErr
+				}
+				return c.NoContent(http.StatusOK)
+			})
+
+			assert := assert.New(t)
+			assert.NoError(handler(ctx))
+			require.NoError(t, provider.ForceFlush(context.Background()))
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal("GET /api/v1/users/:id", spans[0].Name)
+			assert.Equal(tt.wantStatus, spans[0].Status.Code)
+			assert.Contains(spans[0].Attributes, attribute.String("http.route", "/api/v1/users/:id"))
+			if tt.wantTraceID != "" {
+				assert.Equal(tt.wantTraceID, spans[0].SpanContext.TraceID().String())
+			}
+		})
+	}
+}
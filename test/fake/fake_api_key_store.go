@@ -0,0 +1,89 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"github.com/google/uuid"
+)
+
+var _ driven.APIKeyStore = new(FakeAPIKeyStore)
+
+// FakeAPIKeyStore writes the last used time on every use.
+type FakeAPIKeyStore struct {
+	APIKeys map[string]*entity.APIKey
+}
+
+func NewFakeAPIKeyStore() *FakeAPIKeyStore {
+	return &FakeAPIKeyStore{
+		APIKeys: make(map[string]*entity.APIKey),
+	}
+}
+
+// CreateAPIKey implements driven.APIKeyStore.
+func (faks *FakeAPIKeyStore) CreateAPIKey(ctx context.Context, apiKey *entity.APIKey) error {
+	if val := ctx.Value("api_key_error"); val != nil {
+		return errors.New("error")
+	}
+
+	apiKey.ID = uuid.NewString()
+	faks.APIKeys[apiKey.ID] = apiKey
+	return nil
+}
+
+// GetAPIKeysByUserID implements driven.APIKeyStore.
+func (faks *FakeAPIKeyStore) GetAPIKeysByUserID(ctx context.Context, userID string) ([]*entity.APIKey, error) {
+	if val := ctx.Value("api_key_error"); val != nil {
+		return nil, errors.New("error")
+	}
+
+	apiKeys := []*entity.APIKey{}
+	for _, apiKey := range faks.APIKeys {
+		if apiKey.UserID == userID && apiKey.RevokedAt == nil {
+			apiKeys = append(apiKeys, apiKey)
+		}
+	}
+	sort.Slice(apiKeys, func(i, j int) bool {
+		return apiKeys[i].CreatedAt.After(apiKeys[j].CreatedAt)
+	})
+	return apiKeys, nil
+}
+
+// GetAPIKeyByPrefix implements driven.APIKeyStore.
+func (faks *FakeAPIKeyStore) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	if val := ctx.Value("api_key_error"); val != nil {
+		return nil, errors.New("error")
+	}
+
+	for _, apiKey := range faks.APIKeys {
+		if apiKey.Prefix == prefix {
+			return apiKey, nil
+		}
+	}
+	return nil, driven.ErrNotFound
+}
+
+// RevokeAPIKey implements driven.APIKeyStore.
+func (faks *FakeAPIKeyStore) RevokeAPIKey(ctx context.Context, userID, id string, revokedAt time.Time) error {
+	apiKey, ok := faks.APIKeys[id]
+	if !ok || apiKey.UserID != userID || apiKey.RevokedAt != nil {
+		return driven.ErrNotFound
+	}
+	apiKey.RevokedAt = &revokedAt
+	return nil
+}
+
+// TouchAPIKey implements driven.APIKeyStore.
+func (faks *FakeAPIKeyStore) TouchAPIKey(ctx context.Context, id string, usedAt time.Time) error {
+	apiKey, ok := faks.APIKeys[id]
+	if !ok {
+		return driven.ErrNotFound
+	}
+	apiKey.LastUsedAt = &usedAt
+	return nil
+}
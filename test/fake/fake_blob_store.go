@@ -0,0 +1,37 @@
+package fake
+
+import (
+	"context"
+	"errors"
+
+	"userservice/internal/user/port/driven"
+)
+
+var _ driven.BlobStore = new(FakeBlobStore)
+
+type FakeBlob struct {
+	ContentType string
+	Data        []byte
+}
+
+type FakeBlobStore struct {
+	Blobs map[string]FakeBlob
+}
+
+func NewFakeBlobStore() *FakeBlobStore {
+	return &FakeBlobStore{
+		Blobs: make(map[string]FakeBlob),
+	}
+}
+
+// Put implements driven.BlobStore.
+func (fbs *FakeBlobStore) Put(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	if val := ctx.Value("blob_error"); val != nil {
+		return "", errors.New("error")
+	}
+	fbs.Blobs[key] = FakeBlob{
+		ContentType: contentType,
+		Data:        data,
+	}
+	return "http://localhost/blobs/" + key, nil
+}
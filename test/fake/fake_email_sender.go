@@ -0,0 +1,33 @@
+package fake
+
+import (
+	"context"
+	"errors"
+
+	"userservice/internal/user/port/driven"
+)
+
+var _ driven.EmailSender = new(FakeEmailSender)
+
+type FakeEmail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type FakeEmailSender struct {
+	Emails []FakeEmail
+}
+
+// Send implements driven.EmailSender.
+func (fes *FakeEmailSender) Send(ctx context.Context, to, subject, body string) error {
+	if val := ctx.Value("email_error"); val != nil {
+		return errors.New("error")
+	}
+	fes.Emails = append(fes.Emails, FakeEmail{
+		To:      to,
+		Subject: subject,
+		Body:    body,
+	})
+	return nil
+}
@@ -0,0 +1,84 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+)
+
+var (
+	_ driven.EventOutbox    = new(FakeEventOutbox)
+	_ driven.EventPublisher = new(FakeEventPublisher)
+)
+
+// FakeEventOutbox only records the lock duration, the claimed events are hidden until they are marked.
+type FakeEventOutbox struct {
+	Events       []*entity.Event
+	Published    map[string]bool
+	Failed       map[string]string
+	LockDuration time.Duration
+	claimed      map[string]bool
+}
+
+func NewFakeEventOutbox(events ...*entity.Event) *FakeEventOutbox {
+	return &FakeEventOutbox{
+		Events:    events,
+		Published: make(map[string]bool),
+		Failed:    make(map[string]string),
+		claimed:   make(map[string]bool),
+	}
+}
+
+// ClaimUnpublished implements driven.EventOutbox.
+func (feo *FakeEventOutbox) ClaimUnpublished(ctx context.Context, limit int, lockDuration time.Duration) ([]*entity.Event, error) {
+	if val := ctx.Value("outbox_error"); val != nil {
+		return nil, errors.New("error")
+	}
+
+	feo.LockDuration = lockDuration
+	sort.SliceStable(feo.Events, func(i, j int) bool {
+		return feo.Events[i].OccurredAt.Before(feo.Events[j].OccurredAt)
+	})
+	events := []*entity.Event{}
+	for _, event := range feo.Events {
+		if len(events) == limit {
+			break
+		}
+		if !feo.Published[event.ID] && !feo.claimed[event.ID] {
+			feo.claimed[event.ID] = true
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// MarkPublished implements driven.EventOutbox.
+func (feo *FakeEventOutbox) MarkPublished(ctx context.Context, id string) error {
+	feo.Published[id] = true
+	delete(feo.claimed, id)
+	return nil
+}
+
+// MarkFailed implements driven.EventOutbox.
+func (feo *FakeEventOutbox) MarkFailed(ctx context.Context, id string, reason string) error {
+	feo.Failed[id] = reason
+	return nil
+}
+
+// FakeEventPublisher fails to publish the events of the aggregate id "broken".
+type FakeEventPublisher struct {
+	Published []*entity.Event
+}
+
+// Publish implements driven.EventPublisher.
+func (fep *FakeEventPublisher) Publish(ctx context.Context, event *entity.Event) error {
+	if event.AggregateID == "broken" {
+		return errors.New("broker is down")
+	}
+	fep.Published = append(fep.Published, event)
+	return nil
+}
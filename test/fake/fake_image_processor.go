@@ -0,0 +1,32 @@
+package fake
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+)
+
+var _ driven.ImageProcessor = new(FakeImageProcessor)
+
+// FakeImageProcessor fails for image containing "corrupted",
+// the thumbnail data is the size so it can be asserted.
+type FakeImageProcessor struct{}
+
+// Process implements driven.ImageProcessor.
+func (FakeImageProcessor) Process(image *entity.Image, thumbnailSizes []int) (*entity.Image, []entity.Image, error) {
+	if bytes.Contains(image.Data, []byte("corrupted")) {
+		return nil, nil, errors.New("error")
+	}
+
+	var thumbnails []entity.Image
+	for _, size := range thumbnailSizes {
+		thumbnails = append(thumbnails, entity.Image{
+			ContentType: image.ContentType,
+			Data:        []byte(fmt.Sprint(size)),
+		})
+	}
+	return image, thumbnails, nil
+}
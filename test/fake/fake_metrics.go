@@ -0,0 +1,58 @@
+package fake
+
+import (
+	"time"
+
+	"userservice/internal/user/port/driven"
+)
+
+var _ driven.Metrics = new(FakeMetrics)
+
+// FakeMetrics counts the recorded metrics by their label.
+type FakeMetrics struct {
+	SignUps                 int
+	LoginSuccesses          int
+	LoginFailures           map[string]int
+	TokenValidationFailures map[string]int
+	ProfileUpdates          map[string]int
+	PasswordHashings        map[string]int
+}
+
+func NewFakeMetrics() *FakeMetrics {
+	return &FakeMetrics{
+		LoginFailures:           make(map[string]int),
+		TokenValidationFailures: make(map[string]int),
+		ProfileUpdates:          make(map[string]int),
+		PasswordHashings:        make(map[string]int),
+	}
+}
+
+// UserSignedUp implements driven.Metrics.
+func (fm *FakeMetrics) UserSignedUp() {
+	fm.SignUps++
+}
+
+// LoginSucceeded implements driven.Metrics.
+func (fm *FakeMetrics) LoginSucceeded() {
+	fm.LoginSuccesses++
+}
+
+// LoginFailed implements driven.Metrics.
+func (fm *FakeMetrics) LoginFailed(reason string) {
+	fm.LoginFailures[reason]++
+}
+
+// TokenValidationFailed implements driven.Metrics.
+func (fm *FakeMetrics) TokenValidationFailed(reason string) {
+	fm.TokenValidationFailures[reason]++
+}
+
+// ProfileUpdated implements driven.Metrics.
+func (fm *FakeMetrics) ProfileUpdated(kind string) {
+	fm.ProfileUpdates[kind]++
+}
+
+// PasswordHashed implements driven.Metrics.
+func (fm *FakeMetrics) PasswordHashed(operation string, duration time.Duration) {
+	fm.PasswordHashings[operation]++
+}
@@ -3,6 +3,9 @@ package fake
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
+	"time"
 
 	"userservice/internal/user/entity"
 	"userservice/internal/user/param/request"
@@ -14,17 +17,28 @@ import (
 var (
 	_ driven.UserWriter = new(FakeUserDriven)
 	_ driven.UserGetter = new(FakeUserDriven)
+
+	_ driven.UserSearcher    = new(FakeUserDriven)
+	_ driven.PasswordHistory = new(FakeUserDriven)
 )
 
 type FakeUserDriven struct {
 	data        map[string]*entity.User
 	dataByPhone map[string]*entity.User
+	profiles    map[string]entity.Profile
+	redirects   map[string]entity.UsernameRedirect
+	privacy     map[string]entity.PrivacySettings
+	passwords   map[string][]string
 }
 
 func NewFakeUserDriven() *FakeUserDriven {
 	return &FakeUserDriven{
 		data:        make(map[string]*entity.User),
 		dataByPhone: make(map[string]*entity.User),
+		profiles:    make(map[string]entity.Profile),
+		redirects:   make(map[string]entity.UsernameRedirect),
+		privacy:     make(map[string]entity.PrivacySettings),
+		passwords:   make(map[string][]string),
 	}
 }
 
@@ -41,17 +55,51 @@ func (fud FakeUserDriven) GetByID(ctx context.Context, id string) (*entity.User,
 	if user, ok := fud.data[id]; ok {
 		return user, nil
 	}
-	return nil, errors.New("resource not found")
+	return nil, driven.ErrNotFound
+}
+
+// GetByIDs implements driven.UserGetter.
+func (fud *FakeUserDriven) GetByIDs(ctx context.Context, ids []string) ([]*entity.User, error) {
+	if val := ctx.Value("batch_error"); val != nil {
+		return nil, errors.New("error")
+	}
+	users := []*entity.User{}
+	for _, id := range ids {
+		if user, ok := fud.data[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// GetByPhoneNumbers implements driven.UserGetter.
+func (fud *FakeUserDriven) GetByPhoneNumbers(ctx context.Context, phoneNumbers []string) ([]*entity.User, error) {
+	if val := ctx.Value("batch_error"); val != nil {
+		return nil, errors.New("error")
+	}
+	users := []*entity.User{}
+	for _, phoneNumber := range phoneNumbers {
+		if user, ok := fud.dataByPhone[phoneNumber]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
 }
 
 // UpdateProfileByID implements driven.UserWriter.
 func (fud *FakeUserDriven) UpdateProfileByID(ctx context.Context, id string, params *request.UpdateProfile) (*entity.User, error) {
 	if user, ok := fud.data[id]; ok {
-		user.FullName = *params.FullName
-		user.PhoneNumber = *params.PhoneNumber
+		if params.FullName != nil {
+			user.FullName = *params.FullName
+		}
+		if params.PhoneNumber != nil {
+			delete(fud.dataByPhone, user.PhoneNumber)
+			user.PhoneNumber = *params.PhoneNumber
+			fud.dataByPhone[user.PhoneNumber] = user
+		}
 		return user, nil
 	}
-	return nil, errors.New("resource not found")
+	return nil, driven.ErrNotFound
 }
 
 // GetByPhoneNumber implements driven.UserGetter.
@@ -59,7 +107,7 @@ func (fud *FakeUserDriven) GetByPhoneNumber(ctx context.Context, phoneNumber str
 	if user, ok := fud.dataByPhone[phoneNumber]; ok {
 		return user, nil
 	}
-	return nil, errors.New("resource not found")
+	return nil, driven.ErrNotFound
 }
 
 // UpdateUserToken implements driven.UserWriter.
@@ -69,3 +117,186 @@ func (*FakeUserDriven) UpdateUserToken(ctx context.Context, userId string) error
 	}
 	return nil
 }
+
+// UpdatePasswordByID implements driven.UserWriter.
+func (fud *FakeUserDriven) UpdatePasswordByID(ctx context.Context, id string, password string, previousPassword string, historyLimit int) error {
+	user, ok := fud.data[id]
+	if !ok {
+		return driven.ErrNotFound
+	}
+	user.Password = password
+	if historyLimit > 0 {
+		passwords := append([]string{previousPassword}, fud.passwords[id]...)
+		if len(passwords) > historyLimit {
+			passwords = passwords[:historyLimit]
+		}
+		fud.passwords[id] = passwords
+	}
+	return nil
+}
+
+// GetLatestByUserID implements driven.PasswordHistory.
+func (fud *FakeUserDriven) GetLatestByUserID(ctx context.Context, userID string, limit int) ([]string, error) {
+	if val := ctx.Value("password_history_error"); val != nil {
+		return nil, errors.New("error")
+	}
+	passwords := fud.passwords[userID]
+	if len(passwords) > limit {
+		passwords = passwords[:limit]
+	}
+	return passwords, nil
+}
+
+// GetByEmail implements driven.UserGetter.
+func (fud *FakeUserDriven) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	var found *entity.User
+	for _, user := range fud.data {
+		if user.Email != "" && user.Email == email && (found == nil || user.EmailVerified) {
+			found = user
+		}
+	}
+	if found == nil {
+		return nil, driven.ErrNotFound
+	}
+	return found, nil
+}
+
+// UpdateEmailByID implements driven.UserWriter.
+func (fud *FakeUserDriven) UpdateEmailByID(ctx context.Context, id string, email string) error {
+	if user, ok := fud.data[id]; ok {
+		user.Email = email
+		user.EmailVerified = false
+		return nil
+	}
+	return driven.ErrNotFound
+}
+
+// VerifyEmailByID implements driven.UserWriter.
+func (fud *FakeUserDriven) VerifyEmailByID(ctx context.Context, id string, email string) error {
+	if user, ok := fud.data[id]; ok && user.Email == email {
+		user.EmailVerified = true
+		return nil
+	}
+	return driven.ErrNotFound
+}
+
+// GetProfileByUserID implements driven.UserGetter.
+func (fud *FakeUserDriven) GetProfileByUserID(ctx context.Context, userID string) (*entity.Profile, error) {
+	if val := ctx.Value("profile_error"); val != nil {
+		return nil, errors.New("error")
+	}
+	if profile, ok := fud.profiles[userID]; ok {
+		return &profile, nil
+	}
+	return nil, driven.ErrNotFound
+}
+
+// UpsertProfile implements driven.UserWriter.
+func (fud *FakeUserDriven) UpsertProfile(ctx context.Context, profile *entity.Profile) error {
+	profile.UpdatedAt = time.Now()
+	fud.profiles[profile.UserID] = *profile
+	return nil
+}
+
+// GetByUsername implements driven.UserGetter.
+func (fud *FakeUserDriven) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	if val := ctx.Value("username_error"); val != nil {
+		return nil, errors.New("error")
+	}
+	for _, user := range fud.data {
+		if user.Username != "" && strings.EqualFold(user.Username, username) {
+			return user, nil
+		}
+	}
+	return nil, driven.ErrNotFound
+}
+
+// GetUsernameRedirect implements driven.UserGetter.
+func (fud *FakeUserDriven) GetUsernameRedirect(ctx context.Context, username string) (*entity.UsernameRedirect, error) {
+	if redirect, ok := fud.redirects[strings.ToLower(username)]; ok && redirect.ExpiresAt.After(time.Now()) {
+		return &redirect, nil
+	}
+	return nil, driven.ErrNotFound
+}
+
+// UpdateUsername implements driven.UserWriter.
+func (fud *FakeUserDriven) UpdateUsername(ctx context.Context, user *entity.User, redirect *entity.UsernameRedirect) error {
+	storedUser, ok := fud.data[user.ID]
+	if !ok {
+		return driven.ErrNotFound
+	}
+	storedUser.Username = user.Username
+	storedUser.UsernameChangedAt = user.UsernameChangedAt
+
+	delete(fud.redirects, strings.ToLower(user.Username))
+	if redirect != nil {
+		fud.redirects[redirect.Username] = *redirect
+	}
+	return nil
+}
+
+// GetPrivacySettingsByUserID implements driven.UserGetter.
+func (fud *FakeUserDriven) GetPrivacySettingsByUserID(ctx context.Context, userID string) (*entity.PrivacySettings, error) {
+	if val := ctx.Value("privacy_error"); val != nil {
+		return nil, errors.New("error")
+	}
+	if settings, ok := fud.privacy[userID]; ok {
+		return &settings, nil
+	}
+	return nil, driven.ErrNotFound
+}
+
+// UpsertPrivacySettings implements driven.UserWriter.
+func (fud *FakeUserDriven) UpsertPrivacySettings(ctx context.Context, settings *entity.PrivacySettings) error {
+	if val := ctx.Value("privacy_error"); val != nil {
+		return errors.New("error")
+	}
+	settings.UpdatedAt = time.Now()
+	fud.privacy[settings.UserID] = *settings
+	return nil
+}
+
+// Search implements driven.UserSearcher.
+// Instead of the trigram similarity, a user with a full name word starting with any query word has score 0.5
+// and a user with the phone number prefix has score 1.
+func (fud *FakeUserDriven) Search(ctx context.Context, query *entity.UserSearchQuery) ([]*entity.UserSearchResult, error) {
+	if val := ctx.Value("search_error"); val != nil {
+		return nil, errors.New("error")
+	}
+
+	results := []*entity.UserSearchResult{}
+	for _, user := range fud.data {
+		score := 0.0
+		if query.PhoneNumberPrefix != "" && strings.HasPrefix(user.PhoneNumber, query.PhoneNumberPrefix) {
+			score = 1
+		} else {
+			for _, word := range strings.Fields(strings.ToLower(user.FullName)) {
+				for _, queryWord := range strings.Fields(strings.ToLower(query.Text)) {
+					if strings.HasPrefix(word, queryWord) {
+						score = 0.5
+					}
+				}
+			}
+		}
+		if score == 0 {
+			continue
+		}
+
+		after := query.After
+		if after != nil && (score > after.Score || (score == after.Score && user.ID <= after.ID)) {
+			continue
+		}
+		results = append(results, &entity.UserSearchResult{User: user, Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].User.ID < results[j].User.ID
+	})
+	if len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results, nil
+}
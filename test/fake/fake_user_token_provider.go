@@ -14,7 +14,7 @@ type FakeTokenProvider struct{}
 
 // Generate implements driven.TokenProvider.
 func (*FakeTokenProvider) Generate(user *entity.User) (*response.Token, error) {
-	if user.PhoneNumber == "======111" {
+	if user.PhoneNumber == "+628111111111" {
 		return nil, errors.New("invalid")
 	}
 	return &response.Token{
@@ -24,6 +24,29 @@ func (*FakeTokenProvider) Generate(user *entity.User) (*response.Token, error) {
 	}, nil
 }
 
+// ValidateJWT implements driven.TokenProvider.
+// "service-token", "admin-token" and "monitoring-token" are the tokens of the clients, any other token except "invalid" is the token of a user.
 func (*FakeTokenProvider) ValidateJWT(tokenString string) (map[string]interface{}, error) {
-	return nil, nil
+	switch tokenString {
+	case "invalid":
+		return nil, errors.New("invalid")
+	case "service-token":
+		return map[string]interface{}{
+			"sub":         "order-service",
+			"client_type": entity.ServiceClientType,
+		}, nil
+	case "admin-token":
+		return map[string]interface{}{
+			"sub":         "support-tool",
+			"client_type": entity.AdminClientType,
+		}, nil
+	case "monitoring-token":
+		return map[string]interface{}{
+			"sub":         "prometheus",
+			"client_type": entity.MonitoringClientType,
+		}, nil
+	}
+	return map[string]interface{}{
+		"sub": tokenString,
+	}, nil
 }
@@ -2,14 +2,15 @@ package fake
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	customerror "userservice/internal/custom_error"
 	"userservice/internal/user/entity"
 	"userservice/internal/user/param/request"
 	"userservice/internal/user/param/response"
+	"userservice/internal/user/port/driven"
 	"userservice/internal/user/port/driver"
 
 	"github.com/go-faker/faker/v4"
@@ -20,17 +21,36 @@ import (
 var (
 	_ driver.UserUsecase       = new(FakeUserUsecase)
 	_ driver.UserGetterUsecase = new(FakeUserUsecase)
+	_ driver.PasswordUsecase   = new(FakeUserUsecase)
+	_ driver.EmailUsecase      = new(FakeUserUsecase)
+	_ driver.AvatarUsecase     = new(FakeUserUsecase)
+	_ driver.UsernameUsecase   = new(FakeUserUsecase)
+
+	_ driver.InternalUserUsecase = new(FakeUserUsecase)
+	_ driver.UserSearchUsecase   = new(FakeUserUsecase)
+	_ driver.WebhookUsecase      = new(FakeUserUsecase)
+	_ driver.APIKeyUsecase       = new(FakeUserUsecase)
 )
 
 type FakeUserUsecase struct {
 	data     map[string]string
 	dataById map[string]*entity.User
+	profiles map[string]*entity.Profile
+	privacy  map[string]*entity.PrivacySettings
+	// Webhooks stores the webhook subscriptions and their delivery attempts
+	Webhooks *FakeWebhookStore
+	// APIKeys stores the API keys of the users
+	APIKeys *FakeAPIKeyStore
 }
 
 func NewFakeUserUsecase() *FakeUserUsecase {
 	return &FakeUserUsecase{
 		data:     make(map[string]string),
 		dataById: make(map[string]*entity.User),
+		profiles: make(map[string]*entity.Profile),
+		privacy:  make(map[string]*entity.PrivacySettings),
+		Webhooks: NewFakeWebhookStore(),
+		APIKeys:  NewFakeAPIKeyStore(),
 	}
 }
 
@@ -38,7 +58,7 @@ func NewFakeUserUsecase() *FakeUserUsecase {
 func (fu *FakeUserUsecase) CreateUser(ctx context.Context, params *request.CreateUser) (id string, err error) {
 	if len(params.PhoneNumber) == 0 {
 		customErr := customerror.NewValidationError()
-		customErr.AddError("phone number", "phone empty")
+		customErr.AddError("phone number", customerror.CodeEmpty, nil)
 		return "", customErr
 	}
 
@@ -69,7 +89,7 @@ func (fu FakeUserUsecase) GetIDByPhone(phone string) (id string, err error) {
 // GetUserByID implements driver.UserGetterUsecase.
 func (fu *FakeUserUsecase) GetUserByID(ctx context.Context, id string) (*entity.User, error) {
 	if id == "1232131" {
-		return nil, sql.ErrNoRows
+		return nil, driven.ErrNotFound
 	}
 	if data, ok := fu.dataById[id]; ok {
 		return data, nil
@@ -95,7 +115,7 @@ func (fu *FakeUserUsecase) UpdateProfileByID(ctx context.Context, id string, par
 // GenerateUserToken implements driver.UserUsecase.
 func (*FakeUserUsecase) GenerateUserToken(ctx context.Context, params *request.GenerateUserTokenRequest) (*response.Token, error) {
 	if params.PhoneNumber == "0000000" {
-		return nil, customerror.NewValidationErrorWithMessage("authentication", "error")
+		return nil, customerror.NewValidationErrorWithCode("authentication", customerror.CodeWrongPhoneNumberPassword, nil)
 	} else if params.PhoneNumber == "11111111" {
 		return nil, errors.New("error")
 	}
@@ -105,3 +125,366 @@ func (*FakeUserUsecase) GenerateUserToken(ctx context.Context, params *request.G
 		Type:      "Bearer",
 	}, nil
 }
+
+// ChangePasswordByID implements driver.PasswordUsecase.
+func (fu *FakeUserUsecase) ChangePasswordByID(ctx context.Context, id string, params *request.ChangePassword) error {
+	if id == "1232131" {
+		return errors.New("error")
+	}
+	if params.CurrentPassword == "0000000" {
+		return customerror.NewValidationErrorWithCode("currentPassword", customerror.CodeWrongPassword, nil)
+	}
+	if data, ok := fu.dataById[id]; ok {
+		data.Password = params.NewPassword
+		return nil
+	}
+	return errors.New("not Found")
+}
+
+// ChangeEmailByID implements driver.EmailUsecase.
+func (fu *FakeUserUsecase) ChangeEmailByID(ctx context.Context, id string, params *request.ChangeEmail) error {
+	if id == "1232131" {
+		return errors.New("error")
+	} else if id == "3333" {
+		return &pq.Error{
+			Code: "23505",
+		}
+	}
+	if params.Email == "" {
+		return customerror.NewValidationErrorWithCode("email", customerror.CodeInvalidEmail, nil)
+	}
+	if data, ok := fu.dataById[id]; ok {
+		data.Email = params.Email
+		data.EmailVerified = false
+		return nil
+	}
+	return errors.New("not Found")
+}
+
+// VerifyEmail implements driver.EmailUsecase.
+func (fu *FakeUserUsecase) VerifyEmail(ctx context.Context, token string) (*entity.User, error) {
+	if token == "1232131" {
+		return nil, errors.New("error")
+	}
+	// the fake token is the user id
+	if data, ok := fu.dataById[token]; ok && data.Email != "" {
+		data.EmailVerified = true
+		return data, nil
+	}
+	return nil, customerror.NewValidationErrorWithCode("token", customerror.CodeInvalidVerificationToken, nil)
+}
+
+// GetProfileByUserID implements driver.UserGetterUsecase.
+func (fu *FakeUserUsecase) GetProfileByUserID(ctx context.Context, userID string) (*entity.Profile, error) {
+	if userID == "1232131" {
+		return nil, errors.New("error")
+	}
+	if profile, ok := fu.profiles[userID]; ok {
+		return profile, nil
+	}
+	return &entity.Profile{
+		UserID: userID,
+	}, nil
+}
+
+// UpdateExtendedProfileByID implements driver.UserUsecase.
+func (fu *FakeUserUsecase) UpdateExtendedProfileByID(ctx context.Context, id string, params *request.UpdateExtendedProfile) (*entity.Profile, error) {
+	if id == "1232131" {
+		return nil, errors.New("error")
+	}
+	if params.Locale != nil && *params.Locale == "invalid" {
+		return nil, customerror.NewValidationErrorWithCode("locale", customerror.CodeInvalidLocale, nil)
+	}
+
+	profile, _ := fu.GetProfileByUserID(ctx, id)
+	if params.AvatarURL != nil {
+		profile.AvatarURL = *params.AvatarURL
+	}
+	if params.DateOfBirth != nil {
+		dateOfBirth, _ := time.Parse("2006-01-02", *params.DateOfBirth)
+		profile.DateOfBirth = &dateOfBirth
+	}
+	if params.Locale != nil {
+		profile.Locale = *params.Locale
+	}
+	if params.Timezone != nil {
+		profile.Timezone = *params.Timezone
+	}
+	if params.Address != nil {
+		profile.Address = &entity.Address{
+			Street:     params.Address.Street,
+			City:       params.Address.City,
+			Province:   params.Address.Province,
+			PostalCode: params.Address.PostalCode,
+			Country:    params.Address.Country,
+		}
+	}
+	profile.UpdatedAt = time.Now()
+	fu.profiles[id] = profile
+	return profile, nil
+}
+
+// AvatarMaxSize implements driver.AvatarUsecase.
+func (fu *FakeUserUsecase) AvatarMaxSize() int {
+	return 1024
+}
+
+// UploadAvatarByID implements driver.AvatarUsecase.
+func (fu *FakeUserUsecase) UploadAvatarByID(ctx context.Context, id string, data []byte) (*entity.Avatar, error) {
+	if id == "1232131" {
+		return nil, errors.New("error")
+	}
+	if len(data) == 0 {
+		return nil, customerror.NewValidationErrorWithCode("avatar", customerror.CodeEmpty, nil)
+	}
+
+	avatar := &entity.Avatar{
+		URL: "http://localhost/blobs/avatars/" + id + "/original.png",
+		Thumbnails: []entity.Thumbnail{
+			{Size: 64, URL: "http://localhost/blobs/avatars/" + id + "/64.png"},
+			{Size: 256, URL: "http://localhost/blobs/avatars/" + id + "/256.png"},
+		},
+	}
+	profile, _ := fu.GetProfileByUserID(ctx, id)
+	profile.AvatarURL = avatar.URL
+	fu.profiles[id] = profile
+	if user, ok := fu.dataById[id]; ok {
+		user.AvatarURL = avatar.URL
+	}
+	return avatar, nil
+}
+
+// CheckUsernameAvailability implements driver.UsernameUsecase.
+func (fu *FakeUserUsecase) CheckUsernameAvailability(ctx context.Context, username string) error {
+	if username == "error" {
+		return errors.New("error")
+	}
+	if err := entity.ValidateUsername(username); err != nil {
+		return err
+	}
+	if _, err := fu.GetUserByUsername(ctx, username); err == nil {
+		return customerror.NewValidationErrorWithCode("username", customerror.CodeTaken, nil)
+	}
+	return nil
+}
+
+// ChangeUsernameByID implements driver.UsernameUsecase.
+func (fu *FakeUserUsecase) ChangeUsernameByID(ctx context.Context, id string, params *request.ChangeUsername) (*entity.User, error) {
+	if id == "1232131" {
+		return nil, errors.New("error")
+	}
+	if err := fu.CheckUsernameAvailability(ctx, params.Username); err != nil {
+		return nil, err
+	}
+	if user, ok := fu.dataById[id]; ok {
+		user.Username = params.Username
+		return user, nil
+	}
+	return nil, driven.ErrNotFound
+}
+
+// GetUserByUsername implements driver.UsernameUsecase.
+func (fu *FakeUserUsecase) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	for _, user := range fu.dataById {
+		if user.Username != "" && strings.EqualFold(user.Username, username) {
+			return user, nil
+		}
+	}
+	return nil, driven.ErrNotFound
+}
+
+// GetPublicProfileByID implements driver.UserGetterUsecase.
+func (fu *FakeUserUsecase) GetPublicProfileByID(ctx context.Context, id string) (*entity.PublicProfile, error) {
+	user, err := fu.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	settings, _ := fu.GetPrivacySettingsByUserID(ctx, id)
+	return entity.NewPublicProfile(user, settings), nil
+}
+
+// GetPrivacySettingsByUserID implements driver.UserGetterUsecase.
+func (fu *FakeUserUsecase) GetPrivacySettingsByUserID(ctx context.Context, userID string) (*entity.PrivacySettings, error) {
+	if settings, ok := fu.privacy[userID]; ok {
+		return settings, nil
+	}
+	return entity.DefaultPrivacySettings(userID), nil
+}
+
+// UpdatePrivacySettingsByID implements driver.UserUsecase.
+func (fu *FakeUserUsecase) UpdatePrivacySettingsByID(ctx context.Context, id string, params *request.UpdatePrivacySettings) (*entity.PrivacySettings, error) {
+	if id == "1232131" {
+		return nil, errors.New("error")
+	}
+	settings := &entity.PrivacySettings{
+		UserID:          id,
+		ShowFullName:    params.ShowFullName,
+		ShowAvatar:      params.ShowAvatar,
+		ShowPhoneNumber: params.ShowPhoneNumber,
+		UpdatedAt:       time.Now(),
+	}
+	fu.privacy[id] = settings
+	return settings, nil
+}
+
+// BatchGetUsers implements driver.InternalUserUsecase.
+func (fu *FakeUserUsecase) BatchGetUsers(ctx context.Context, params *request.BatchGetUsers) (*response.BatchGetUsers, error) {
+	if len(params.IDs)+len(params.PhoneNumbers) == 0 {
+		return nil, customerror.NewValidationErrorWithCode("ids", customerror.CodeAtLeastOneIDOrPhoneNumber, nil)
+	}
+	if val := ctx.Value("batch_error"); val != nil {
+		return nil, errors.New("error")
+	}
+
+	result := &response.BatchGetUsers{
+		Users:               []*entity.User{},
+		MissingIDs:          []string{},
+		MissingPhoneNumbers: []string{},
+	}
+	for _, id := range params.IDs {
+		if user, ok := fu.dataById[id]; ok {
+			result.Users = append(result.Users, user)
+		} else {
+			result.MissingIDs = append(result.MissingIDs, id)
+		}
+	}
+	for _, phoneNumber := range params.PhoneNumbers {
+		if id, ok := fu.data[phoneNumber]; ok {
+			result.Users = append(result.Users, fu.dataById[id])
+		} else {
+			result.MissingPhoneNumbers = append(result.MissingPhoneNumbers, phoneNumber)
+		}
+	}
+	return result, nil
+}
+
+// SearchUsers implements driver.UserSearchUsecase.
+// The users whose full name contains the query are returned with score 1 and without pagination.
+func (fu *FakeUserUsecase) SearchUsers(ctx context.Context, params *request.SearchUsers) (*response.SearchUsers, error) {
+	if len(params.Query) < 2 {
+		return nil, customerror.NewValidationErrorWithCode("q", customerror.CodeLengthBetween, customerror.Params{"min": 2, "max": 64})
+	}
+	if val := ctx.Value("search_error"); val != nil {
+		return nil, errors.New("error")
+	}
+
+	result := &response.SearchUsers{
+		Results: []*entity.UserSearchResult{},
+	}
+	for _, user := range fu.dataById {
+		if strings.Contains(strings.ToLower(user.FullName), strings.ToLower(params.Query)) {
+			result.Results = append(result.Results, &entity.UserSearchResult{
+				User:                   user,
+				Score:                  1,
+				HighlightedFullName:    user.FullName,
+				HighlightedPhoneNumber: user.PhoneNumber,
+			})
+		}
+	}
+	return result, nil
+}
+
+// CreateWebhookSubscription implements driver.WebhookUsecase.
+func (fu *FakeUserUsecase) CreateWebhookSubscription(ctx context.Context, params *request.CreateWebhookSubscription) (*entity.WebhookSubscription, error) {
+	subscription, err := entity.NewWebhookSubscription(params.PartnerID, params.URL, params.EventTypes, params.Secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := fu.Webhooks.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// GetWebhookSubscriptions implements driver.WebhookUsecase.
+func (fu *FakeUserUsecase) GetWebhookSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	return fu.Webhooks.GetSubscriptions(ctx)
+}
+
+// UpdateWebhookSubscriptionByID implements driver.WebhookUsecase.
+func (fu *FakeUserUsecase) UpdateWebhookSubscriptionByID(ctx context.Context, id string, params *request.UpdateWebhookSubscription) (*entity.WebhookSubscription, error) {
+	subscription, err := fu.Webhooks.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := subscription.Update(params.URL, params.EventTypes, params.Enabled); err != nil {
+		return nil, err
+	}
+	return subscription, fu.Webhooks.UpdateSubscription(ctx, subscription)
+}
+
+// DeleteWebhookSubscriptionByID implements driver.WebhookUsecase.
+func (fu *FakeUserUsecase) DeleteWebhookSubscriptionByID(ctx context.Context, id string) error {
+	return fu.Webhooks.DeleteSubscription(ctx, id)
+}
+
+// GetWebhookDeliveryAttempts implements driver.WebhookUsecase.
+func (fu *FakeUserUsecase) GetWebhookDeliveryAttempts(ctx context.Context, subscriptionID string, limit int) ([]*entity.WebhookDeliveryAttempt, error) {
+	if limit < 0 || limit > 100 {
+		return nil, customerror.NewValidationErrorWithCode("limit", customerror.CodeRangeBetween, customerror.Params{"min": 1, "max": 100})
+	}
+	if limit == 0 {
+		limit = 100
+	}
+	if _, err := fu.Webhooks.GetSubscriptionByID(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return fu.Webhooks.GetDeliveryAttempts(ctx, subscriptionID, limit)
+}
+
+// LinkPartnerUser implements driver.WebhookUsecase.
+func (fu *FakeUserUsecase) LinkPartnerUser(ctx context.Context, partnerID, userID string) error {
+	if err := entity.ValidatePartnerID(partnerID); err != nil {
+		return err
+	}
+	if _, ok := fu.dataById[userID]; !ok {
+		return driven.ErrNotFound
+	}
+	return fu.Webhooks.LinkPartnerUser(ctx, partnerID, userID)
+}
+
+// UnlinkPartnerUser implements driver.WebhookUsecase.
+func (fu *FakeUserUsecase) UnlinkPartnerUser(ctx context.Context, partnerID, userID string) error {
+	return fu.Webhooks.UnlinkPartnerUser(ctx, partnerID, userID)
+}
+
+// CreateAPIKey implements driver.APIKeyUsecase.
+func (fu *FakeUserUsecase) CreateAPIKey(ctx context.Context, userID string, params *request.CreateAPIKey) (*entity.APIKey, error) {
+	apiKey, err := entity.NewAPIKey(userID, params.Name, params.Scopes, params.ExpiresAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := fu.APIKeys.CreateAPIKey(ctx, apiKey); err != nil {
+		return nil, err
+	}
+	return apiKey, nil
+}
+
+// GetAPIKeysByUserID implements driver.APIKeyUsecase.
+func (fu *FakeUserUsecase) GetAPIKeysByUserID(ctx context.Context, userID string) ([]*entity.APIKey, error) {
+	return fu.APIKeys.GetAPIKeysByUserID(ctx, userID)
+}
+
+// RevokeAPIKey implements driver.APIKeyUsecase.
+func (fu *FakeUserUsecase) RevokeAPIKey(ctx context.Context, userID, id string) error {
+	return fu.APIKeys.RevokeAPIKey(ctx, userID, id, time.Now())
+}
+
+// AuthenticateAPIKey implements driver.APIKeyUsecase.
+func (fu *FakeUserUsecase) AuthenticateAPIKey(ctx context.Context, key string) (*entity.APIKey, error) {
+	prefix, ok := entity.ParseAPIKeyPrefix(key)
+	if !ok {
+		return nil, entity.ErrInvalidAPIKey
+	}
+	apiKey, err := fu.APIKeys.GetAPIKeyByPrefix(ctx, prefix)
+	if errors.Is(err, driven.ErrNotFound) {
+		return nil, entity.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := apiKey.Verify(key, time.Now()); err != nil {
+		return nil, err
+	}
+	return apiKey, nil
+}
@@ -0,0 +1,182 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"userservice/internal/user/entity"
+	"userservice/internal/user/port/driven"
+
+	"github.com/google/uuid"
+)
+
+var _ driven.WebhookStore = new(FakeWebhookStore)
+
+// FakeWebhookStore ignores the lock duration, the claimed deliveries are hidden until their attempt is saved.
+type FakeWebhookStore struct {
+	Subscriptions map[string]*entity.WebhookSubscription
+	Deliveries    []*entity.WebhookDelivery
+	Attempts      []*entity.WebhookDeliveryAttempt
+	claimed       map[string]bool
+	links         map[string]bool
+}
+
+func NewFakeWebhookStore() *FakeWebhookStore {
+	return &FakeWebhookStore{
+		Subscriptions: make(map[string]*entity.WebhookSubscription),
+		claimed:       make(map[string]bool),
+		links:         make(map[string]bool),
+	}
+}
+
+// QueueDeliveries implements driven.WebhookStore.
+func (fws *FakeWebhookStore) QueueDeliveries(ctx context.Context, event *entity.Event) error {
+	if val := ctx.Value("webhook_error"); val != nil {
+		return errors.New("error")
+	}
+
+	for _, subscription := range fws.Subscriptions {
+		if !subscription.Enabled || !fws.links[partnerUserKey(subscription.PartnerID, event.AggregateID)] {
+			continue
+		}
+		for _, eventType := range subscription.EventTypes {
+			if eventType == event.Type {
+				fws.Deliveries = append(fws.Deliveries, &entity.WebhookDelivery{
+					ID:            uuid.NewString(),
+					Subscription:  subscription,
+					EventID:       event.ID,
+					EventType:     event.Type,
+					Payload:       event.Payload,
+					Status:        entity.WebhookDeliveryPending,
+					NextAttemptAt: event.OccurredAt,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// LinkPartnerUser implements driven.WebhookStore.
+func (fws *FakeWebhookStore) LinkPartnerUser(ctx context.Context, partnerID, userID string) error {
+	if val := ctx.Value("webhook_error"); val != nil {
+		return errors.New("error")
+	}
+
+	fws.links[partnerUserKey(partnerID, userID)] = true
+	return nil
+}
+
+// UnlinkPartnerUser implements driven.WebhookStore.
+func (fws *FakeWebhookStore) UnlinkPartnerUser(ctx context.Context, partnerID, userID string) error {
+	key := partnerUserKey(partnerID, userID)
+	if !fws.links[key] {
+		return driven.ErrNotFound
+	}
+	delete(fws.links, key)
+	return nil
+}
+
+// CreateSubscription implements driven.WebhookStore.
+func (fws *FakeWebhookStore) CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	if val := ctx.Value("webhook_error"); val != nil {
+		return errors.New("error")
+	}
+
+	subscription.ID = uuid.NewString()
+	subscription.CreatedAt = time.Now()
+	subscription.UpdatedAt = subscription.CreatedAt
+	fws.Subscriptions[subscription.ID] = subscription
+	return nil
+}
+
+// GetSubscriptions implements driven.WebhookStore.
+func (fws *FakeWebhookStore) GetSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	if val := ctx.Value("webhook_error"); val != nil {
+		return nil, errors.New("error")
+	}
+
+	subscriptions := []*entity.WebhookSubscription{}
+	for _, subscription := range fws.Subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+	})
+	return subscriptions, nil
+}
+
+// GetSubscriptionByID implements driven.WebhookStore.
+func (fws *FakeWebhookStore) GetSubscriptionByID(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	subscription, ok := fws.Subscriptions[id]
+	if !ok {
+		return nil, driven.ErrNotFound
+	}
+	return subscription, nil
+}
+
+// UpdateSubscription implements driven.WebhookStore.
+func (fws *FakeWebhookStore) UpdateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	if _, ok := fws.Subscriptions[subscription.ID]; !ok {
+		return driven.ErrNotFound
+	}
+	subscription.UpdatedAt = time.Now()
+	fws.Subscriptions[subscription.ID] = subscription
+	return nil
+}
+
+// DeleteSubscription implements driven.WebhookStore.
+func (fws *FakeWebhookStore) DeleteSubscription(ctx context.Context, id string) error {
+	if _, ok := fws.Subscriptions[id]; !ok {
+		return driven.ErrNotFound
+	}
+	delete(fws.Subscriptions, id)
+	return nil
+}
+
+// ClaimDueDeliveries implements driven.WebhookStore.
+func (fws *FakeWebhookStore) ClaimDueDeliveries(ctx context.Context, limit int, lockDuration time.Duration) ([]*entity.WebhookDelivery, error) {
+	if val := ctx.Value("webhook_error"); val != nil {
+		return nil, errors.New("error")
+	}
+
+	now := time.Now()
+	deliveries := []*entity.WebhookDelivery{}
+	for _, delivery := range fws.Deliveries {
+		if len(deliveries) == limit {
+			break
+		}
+		if delivery.Status == entity.WebhookDeliveryPending &&
+			delivery.Subscription.Enabled &&
+			!delivery.NextAttemptAt.After(now) &&
+			!fws.claimed[delivery.ID] {
+			fws.claimed[delivery.ID] = true
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+// SaveAttempt implements driven.WebhookStore.
+func (fws *FakeWebhookStore) SaveAttempt(ctx context.Context, delivery *entity.WebhookDelivery, attempt *entity.WebhookDeliveryAttempt) error {
+	attempt.ID = uuid.NewString()
+	fws.Attempts = append(fws.Attempts, attempt)
+	delete(fws.claimed, delivery.ID)
+	return nil
+}
+
+// GetDeliveryAttempts implements driven.WebhookStore.
+func (fws *FakeWebhookStore) GetDeliveryAttempts(ctx context.Context, subscriptionID string, limit int) ([]*entity.WebhookDeliveryAttempt, error) {
+	attempts := []*entity.WebhookDeliveryAttempt{}
+	for i := len(fws.Attempts) - 1; i >= 0 && len(attempts) < limit; i-- {
+		if fws.Attempts[i].SubscriptionID == subscriptionID {
+			attempts = append(attempts, fws.Attempts[i])
+		}
+	}
+	return attempts, nil
+}
+
+func partnerUserKey(partnerID, userID string) string {
+	return partnerID + "/" + userID
+}
@@ -0,0 +1,136 @@
+// Package userclient is the supported Go client of the user service HTTP API,
+// it wraps the client generated from api.yml with token acquisition, retries and typed errors.
+package userclient
+
+import (
+	"net/http"
+	"time"
+
+	"userservice/generated/apiclient"
+)
+
+const (
+	defaultMaxRetries   = 2
+	defaultRetryBackoff = 200 * time.Millisecond
+	defaultTimeout      = 10 * time.Second
+)
+
+type Client struct {
+	// api sends the token, public does not so the public operations work without credentials
+	api    *apiclient.ClientWithResponses
+	public *apiclient.ClientWithResponses
+}
+
+type options struct {
+	httpClient   apiclient.HttpRequestDoer
+	tokenSource  TokenSource
+	authScheme   string
+	credentials  *apiclient.CreateTokenRequest
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+type Option func(*options)
+
+// WithHTTPClient replaces the default http.Client which has a 10 seconds timeout.
+func WithHTTPClient(httpClient apiclient.HttpRequestDoer) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithToken uses the given token as is, it is never refreshed.
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.tokenSource = staticTokenSource(token)
+	}
+}
+
+// WithAPIKey sends the personal API key of a user instead of a token,
+// only the operations of the scopes of the key can be called.
+func WithAPIKey(key string) Option {
+	return func(o *options) {
+		o.tokenSource = staticTokenSource(key)
+		o.authScheme = "ApiKey"
+	}
+}
+
+// WithTokenSource uses the tokens of the given source, e.g. the service client tokens.
+func WithTokenSource(tokenSource TokenSource) Option {
+	return func(o *options) {
+		o.tokenSource = tokenSource
+	}
+}
+
+// WithPhoneNumberPassword generates the user token when needed and refreshes it before it expires.
+func WithPhoneNumberPassword(phoneNumber, password string) Option {
+	return func(o *options) {
+		o.credentials = &apiclient.CreateTokenRequest{PhoneNumber: &phoneNumber, Password: password}
+	}
+}
+
+// WithEmailPassword is like WithPhoneNumberPassword with the verified email.
+func WithEmailPassword(email, password string) Option {
+	return func(o *options) {
+		o.credentials = &apiclient.CreateTokenRequest{Email: &email, Password: password}
+	}
+}
+
+// WithRetry changes how many times an idempotent request is retried and the backoff before the first retry,
+// the backoff is doubled on every retry. Zero max retries disables the retry.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.maxRetries = maxRetries
+		o.retryBackoff = backoff
+	}
+}
+
+// New creates the client of the server at baseURL, e.g. "https://users.example.com".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	o := &options{
+		httpClient:   &http.Client{Timeout: defaultTimeout},
+		authScheme:   "Bearer",
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	doer := &retryDoer{
+		doer:       o.httpClient,
+		maxRetries: o.maxRetries,
+		backoff:    o.retryBackoff,
+	}
+	public, err := apiclient.NewClientWithResponses(baseURL, apiclient.WithHTTPClient(doer))
+	if err != nil {
+		return nil, err
+	}
+
+	tokenSource := o.tokenSource
+	if o.credentials != nil {
+		tokenSource = newPasswordTokenSource(public, *o.credentials)
+	}
+	if tokenSource == nil {
+		tokenSource = staticTokenSource("")
+	}
+	api, err := apiclient.NewClientWithResponses(baseURL, apiclient.WithHTTPClient(&authDoer{
+		doer:        doer,
+		tokenSource: tokenSource,
+		scheme:      o.authScheme,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		api:    api,
+		public: public,
+	}, nil
+}
+
+// Raw returns the generated client for the operations without a helper,
+// it sends the token and retries like the helpers do.
+func (c *Client) Raw() *apiclient.ClientWithResponses {
+	return c.api
+}
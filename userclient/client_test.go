@@ -0,0 +1,242 @@
+package userclient
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"userservice/generated"
+	"userservice/generated/apiclient"
+	"userservice/handler"
+	"userservice/infrastructure"
+	"userservice/internal/user/entity"
+	"userservice/internal/user/usecase"
+	custommiddleware "userservice/middleware"
+	"userservice/test/fake"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPhoneNumber = "+628123456789"
+	testPassword    = "Password1!"
+)
+
+// newTestServer serves the real handlers and usecases with the in-memory adapters.
+func newTestServer(t *testing.T) *httptest.Server {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tokenProvider := &infrastructure.UserTokenProvider{
+		PrivateKey:    privateKey,
+		PublicKey:     &privateKey.PublicKey,
+		ExpiresSecond: 3600,
+	}
+	userDriven := fake.NewFakeUserDriven()
+	server := handler.NewServerWithUsecases(tokenProvider, &handler.Usecases{
+		User:       usecase.NewUserUsecase(userDriven, new(infrastructure.BcyrpEncryption), userDriven, tokenProvider, fake.NewFakeMetrics()),
+		UserGetter: usecase.NewUserGetterUsecase(userDriven),
+		Username:   usecase.NewUsernameUsecase(userDriven, userDriven, 30, 30),
+		APIKey:     usecase.NewAPIKeyUsecase(fake.NewFakeAPIKeyStore()),
+	})
+
+	swagger, err := generated.GetSwagger()
+	require.NoError(t, err)
+	security, err := custommiddleware.NewSecurity(swagger, &custommiddleware.SecurityOptions{
+		Schemes: map[string]custommiddleware.SecurityScheme{
+			"bearerAuth":         custommiddleware.UserJWT(tokenProvider),
+			"serviceAuth":        custommiddleware.ClientJWT(tokenProvider, entity.ServiceClientType),
+			"serviceCertificate": custommiddleware.ClientCertificate(entity.ServiceClientType),
+			"adminAuth":          custommiddleware.ClientJWT(tokenProvider, entity.AdminClientType),
+			"monitoringAuth":     custommiddleware.ClientJWT(tokenProvider, entity.MonitoringClientType),
+			"apiKeyAuth":         custommiddleware.UserAPIKey(server.APIKeyUsecase),
+		},
+	})
+	require.NoError(t, err)
+	e := echo.New()
+	e.Use(
+		security.Middleware(),
+		// the handlers must answer what api.yml declares
+		custommiddleware.WithOpenAPIValidation(swagger, &custommiddleware.OpenAPIValidationOptions{
+			ResponseErrorHandler: func(c echo.Context, err error) {
+				t.Errorf("%s %s: %s", c.Request().Method, c.Path(), err)
+			},
+		}),
+	)
+	generated.RegisterHandlers(e, server)
+	require.NoError(t, security.CheckRoutes(e.Routes()))
+	httpServer := httptest.NewServer(e)
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+// countingDoer counts the requests sent to the paths with the given suffix.
+type countingDoer struct {
+	suffix string
+	count  atomic.Int32
+}
+
+func (cd *countingDoer) Do(req *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, cd.suffix) {
+		cd.count.Add(1)
+	}
+	return http.DefaultClient.Do(req)
+}
+
+func TestClient_Users(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t)
+
+	anonymous, err := New(server.URL)
+	require.NoError(t, err)
+	id, err := anonymous.CreateUser(ctx, apiclient.CreateUserRequest{
+		FullName:    "Jane Doe",
+		PhoneNumber: testPhoneNumber,
+		Password:    testPassword,
+	})
+	require.NoError(t, err)
+
+	doer := &countingDoer{suffix: "/token"}
+	client, err := New(server.URL, WithHTTPClient(doer), WithPhoneNumberPassword(testPhoneNumber, testPassword))
+	require.NoError(t, err)
+
+	user, err := client.GetCurrentUser(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", user.FullName)
+	assert.Equal(t, testPhoneNumber, user.PhoneNumber)
+
+	fullName := "John Doe"
+	updated, err := client.UpdateCurrentUser(ctx, apiclient.UpdateUserRequest{FullName: &fullName})
+	require.NoError(t, err)
+	assert.Equal(t, fullName, updated.FullName)
+
+	publicUser, err := client.GetUserByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, id, publicUser.Id)
+
+	// the token is generated once and reused
+	assert.Equal(t, int32(1), doer.count.Load())
+}
+
+func TestClient_APIKey(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t)
+
+	anonymous, err := New(server.URL)
+	require.NoError(t, err)
+	_, err = anonymous.CreateUser(ctx, apiclient.CreateUserRequest{
+		FullName:    "Jane Doe",
+		PhoneNumber: testPhoneNumber,
+		Password:    testPassword,
+	})
+	require.NoError(t, err)
+
+	client, err := New(server.URL, WithPhoneNumberPassword(testPhoneNumber, testPassword))
+	require.NoError(t, err)
+	created, err := client.Raw().CreateCurrentUserApiKeyWithResponse(ctx, apiclient.CreateApiKeyRequest{
+		Name:   "backup script",
+		Scopes: []apiclient.ApiKeyScope{apiclient.UsersRead},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created.JSON201)
+
+	scriptClient, err := New(server.URL, WithAPIKey(created.JSON201.Key))
+	require.NoError(t, err)
+	user, err := scriptClient.GetCurrentUser(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", user.FullName)
+
+	fullName := "John Doe"
+	_, err = scriptClient.UpdateCurrentUser(ctx, apiclient.UpdateUserRequest{FullName: &fullName})
+	assert.True(t, IsForbidden(err))
+
+	keys, err := client.Raw().GetCurrentUserApiKeysWithResponse(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys.JSON200.ApiKeys, 1)
+	assert.NotNil(t, keys.JSON200.ApiKeys[0].LastUsedAt)
+
+	revoked, err := client.Raw().RevokeCurrentUserApiKeyWithResponse(ctx, created.JSON201.Id)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, revoked.StatusCode())
+	_, err = scriptClient.GetCurrentUser(ctx)
+	assert.True(t, IsUnauthorized(err))
+}
+
+func TestClient_Errors(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t)
+
+	client, err := New(server.URL)
+	require.NoError(t, err)
+
+	t.Run("validation error", func(t *testing.T) {
+		_, err := client.CreateUser(ctx, apiclient.CreateUserRequest{
+			FullName:    "Jane Doe",
+			PhoneNumber: testPhoneNumber,
+			Password:    "password",
+		})
+		assert.True(t, IsValidationError(err))
+		assert.Contains(t, err.(*Error).Fields(), "password")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.GetUserByUsername(ctx, "nobody")
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("without token", func(t *testing.T) {
+		_, err := client.GetCurrentUser(ctx)
+		assert.True(t, IsUnauthorized(err))
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		client, err := New(server.URL, WithPhoneNumberPassword(testPhoneNumber, "Wrong1!password"))
+		require.NoError(t, err)
+		_, err = client.GetCurrentUser(ctx)
+		assert.Error(t, err)
+	})
+}
+
+// rotatingTokenSource returns an invalid token until it is invalidated.
+type rotatingTokenSource struct {
+	token       string
+	invalidated int
+}
+
+func (rts *rotatingTokenSource) Token(ctx context.Context) (string, error) {
+	return rts.token, nil
+}
+
+func (rts *rotatingTokenSource) Invalidate(token string) {
+	rts.invalidated++
+	rts.token = "refreshed"
+}
+
+func TestAuthDoer_RefreshesRejectedToken(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "Bearer refreshed" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tokenSource := &rotatingTokenSource{token: "expired"}
+	doer := &authDoer{doer: http.DefaultClient, tokenSource: tokenSource, scheme: "Bearer"}
+	req, _ := http.NewRequest(http.MethodPatch, server.URL, strings.NewReader(`{"fullName":"Jane"}`))
+	resp, err := doer.Do(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"Bearer expired", "Bearer refreshed"}, tokens)
+	assert.Equal(t, 1, tokenSource.invalidated)
+}
@@ -0,0 +1,101 @@
+package userclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Error is returned when the server does not answer with a success status.
+type Error struct {
+	StatusCode int
+	// Type is the type of ErrorResponse, e.g. "ValidationError"
+	Type     string
+	Messages []ErrorMessage
+}
+
+type ErrorMessage struct {
+	// Name is the invalid field for a ValidationError
+	Name   string
+	Reason string
+}
+
+func (e *Error) Error() string {
+	reasons := make([]string, 0, len(e.Messages))
+	for _, message := range e.Messages {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", message.Name, message.Reason))
+	}
+	if len(reasons) == 0 {
+		return fmt.Sprintf("userservice: %d %s", e.StatusCode, e.Type)
+	}
+	return fmt.Sprintf("userservice: %d %s: %s", e.StatusCode, e.Type, strings.Join(reasons, "; "))
+}
+
+// Fields returns the reasons of every invalid field of a ValidationError.
+func (e *Error) Fields() map[string][]string {
+	fields := make(map[string][]string)
+	for _, message := range e.Messages {
+		fields[message.Name] = append(fields[message.Name], message.Reason)
+	}
+	return fields
+}
+
+// IsValidationError reports whether the request was rejected because of the invalid fields.
+func IsValidationError(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Type == "ValidationError"
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func IsConflict(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// IsUnauthorized reports whether the token is missing, invalid or expired.
+func IsUnauthorized(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether the token is valid but not allowed for the operation.
+func IsForbidden(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden
+}
+
+// decodeError keeps the status text as the type when the body is not an ErrorResponse, e.g. from a proxy.
+func decodeError(resp *http.Response, body []byte) error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Type:       http.StatusText(resp.StatusCode),
+	}
+
+	var errorResponse struct {
+		Type     string `json:"type"`
+		Messages []struct {
+			Name   string `json:"name"`
+			Reason string `json:"reason"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(body, &errorResponse); err != nil {
+		return apiErr
+	}
+
+	if errorResponse.Type != "" {
+		apiErr.Type = errorResponse.Type
+	}
+	for _, message := range errorResponse.Messages {
+		apiErr.Messages = append(apiErr.Messages, ErrorMessage{
+			Name:   message.Name,
+			Reason: message.Reason,
+		})
+	}
+	return apiErr
+}
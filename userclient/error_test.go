@@ -0,0 +1,75 @@
+package userclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       *Error
+	}{
+		{
+			name:       "error response",
+			statusCode: http.StatusBadRequest,
+			body:       `{"type":"ValidationError","messages":[{"name":"password","reason":"too short"},{"name":"password","reason":"too weak"}]}`,
+			want: &Error{
+				StatusCode: http.StatusBadRequest,
+				Type:       "ValidationError",
+				Messages: []ErrorMessage{
+					{Name: "password", Reason: "too short"},
+					{Name: "password", Reason: "too weak"},
+				},
+			},
+		},
+		{
+			name:       "unauthorized",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"type":"Unauthorized","messages":[{"name":"authorization","reason":"The access token is missing."}]}`,
+			want: &Error{
+				StatusCode: http.StatusUnauthorized,
+				Type:       "Unauthorized",
+				Messages:   []ErrorMessage{{Name: "authorization", Reason: "The access token is missing."}},
+			},
+		},
+		{
+			name:       "not json",
+			statusCode: http.StatusBadGateway,
+			body:       `<html></html>`,
+			want: &Error{
+				StatusCode: http.StatusBadGateway,
+				Type:       "Bad Gateway",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := decodeError(&http.Response{StatusCode: tt.statusCode}, []byte(tt.body))
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
+
+func TestError_Fields(t *testing.T) {
+	err := &Error{
+		StatusCode: http.StatusBadRequest,
+		Type:       "ValidationError",
+		Messages: []ErrorMessage{
+			{Name: "password", Reason: "too short"},
+			{Name: "fullName", Reason: "required"},
+			{Name: "password", Reason: "too weak"},
+		},
+	}
+
+	assert.Equal(t, map[string][]string{
+		"password": {"too short", "too weak"},
+		"fullName": {"required"},
+	}, err.Fields())
+	assert.True(t, IsValidationError(err))
+	assert.Equal(t, "userservice: 400 ValidationError: password: too short; fullName: required; password: too weak", err.Error())
+}
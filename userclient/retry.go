@@ -0,0 +1,104 @@
+package userclient
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"userservice/generated/apiclient"
+)
+
+// the longest Retry-After which is waited, a longer one is returned to the caller as is
+const maxRetryAfter = 10 * time.Second
+
+// retryDoer retries the idempotent requests on the network errors and the temporary server errors.
+type retryDoer struct {
+	doer       apiclient.HttpRequestDoer
+	maxRetries int
+	backoff    time.Duration
+}
+
+// Do implements apiclient.HttpRequestDoer.
+func (rd *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	if !idempotent(req.Method) {
+		return rd.doer.Do(req)
+	}
+
+	backoff := rd.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := rd.doer.Do(req)
+		if attempt == rd.maxRetries || !retryable(req, resp, err) || !rewindBody(req) {
+			return resp, err
+		}
+
+		wait := backoff
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp); ok {
+				if retryAfter > maxRetryAfter {
+					return resp, nil
+				}
+				wait = retryAfter
+			}
+			drainBody(resp)
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// the caller gave up, it is not a failure of the server
+		return req.Context().Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter supports only the seconds, the server never sends a date.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// rewindBody prepares the body to be sent again, it returns false when the body cannot be read again.
+func rewindBody(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+	return true
+}
+
+// drainBody lets the connection be reused.
+func drainBody(resp *http.Response) {
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+}
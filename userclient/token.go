@@ -0,0 +1,129 @@
+package userclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"userservice/generated/apiclient"
+)
+
+// the token is refreshed a bit before it expires so it does not expire on the way to the server
+const tokenRefreshSkew = 30 * time.Second
+
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// invalidator is implemented by the token sources which can get a new token after the server rejected one.
+type invalidator interface {
+	Invalidate(token string)
+}
+
+type staticTokenSource string
+
+// Token implements TokenSource.
+func (sts staticTokenSource) Token(ctx context.Context) (string, error) {
+	return string(sts), nil
+}
+
+type passwordTokenSource struct {
+	client      *apiclient.ClientWithResponses
+	credentials apiclient.CreateTokenRequest
+	now         func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func newPasswordTokenSource(client *apiclient.ClientWithResponses, credentials apiclient.CreateTokenRequest) *passwordTokenSource {
+	return &passwordTokenSource{
+		client:      client,
+		credentials: credentials,
+		now:         time.Now,
+	}
+}
+
+// Token implements TokenSource.
+// The concurrent callers wait for the same token instead of generating one each.
+func (pts *passwordTokenSource) Token(ctx context.Context) (string, error) {
+	pts.mu.Lock()
+	defer pts.mu.Unlock()
+
+	if pts.token != "" && pts.now().Before(pts.expiresAt.Add(-tokenRefreshSkew)) {
+		return pts.token, nil
+	}
+
+	response, err := pts.client.GenerateUserTokenWithResponse(ctx, pts.credentials)
+	if err != nil {
+		return "", err
+	}
+	if response.JSON200 == nil {
+		return "", decodeError(response.HTTPResponse, response.Body)
+	}
+
+	pts.token = response.JSON200.AccessToken
+	pts.expiresAt = pts.now().Add(time.Duration(response.JSON200.ExpiresIn) * time.Second)
+	return pts.token, nil
+}
+
+// Invalidate implements invalidator.
+func (pts *passwordTokenSource) Invalidate(token string) {
+	pts.mu.Lock()
+	defer pts.mu.Unlock()
+
+	// another request may have refreshed it already
+	if pts.token == token {
+		pts.token = ""
+	}
+}
+
+// authDoer sets the token of every request, a rejected token is refreshed and the request is sent once more.
+type authDoer struct {
+	doer        apiclient.HttpRequestDoer
+	tokenSource TokenSource
+	// scheme is the scheme of the Authorization header, Bearer or ApiKey
+	scheme string
+}
+
+// Do implements apiclient.HttpRequestDoer.
+func (ad *authDoer) Do(req *http.Request) (*http.Response, error) {
+	token, err := ad.send(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ad.doer.Do(req)
+	if err != nil || !rejectedToken(resp) {
+		return resp, err
+	}
+
+	invalidator, ok := ad.tokenSource.(invalidator)
+	if !ok || !rewindBody(req) {
+		return resp, nil
+	}
+	drainBody(resp)
+	invalidator.Invalidate(token)
+	if _, err := ad.send(req); err != nil {
+		return nil, err
+	}
+	return ad.doer.Do(req)
+}
+
+func (ad *authDoer) send(req *http.Request) (string, error) {
+	token, err := ad.tokenSource.Token(req.Context())
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", ad.scheme+" "+token)
+	}
+	return token, nil
+}
+
+// rejectedToken reports whether the server did not accept the token, e.g. it expired earlier than expected.
+// A new token does not help with 403, the token is valid but cannot access the operation.
+func rejectedToken(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusUnauthorized
+}
@@ -0,0 +1,81 @@
+package userclient
+
+import (
+	"context"
+
+	"userservice/generated/apiclient"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// CreateUser does not need credentials.
+func (c *Client) CreateUser(ctx context.Context, params apiclient.CreateUserRequest) (openapi_types.UUID, error) {
+	response, err := c.public.CreateUserWithResponse(ctx, params)
+	if err != nil {
+		return openapi_types.UUID{}, err
+	}
+	if response.JSON201 == nil {
+		return openapi_types.UUID{}, decodeError(response.HTTPResponse, response.Body)
+	}
+	return response.JSON201.Id, nil
+}
+
+// GenerateUserToken does not need credentials, the client generates the token by itself
+// when it is created with WithPhoneNumberPassword or WithEmailPassword.
+func (c *Client) GenerateUserToken(ctx context.Context, params apiclient.CreateTokenRequest) (*apiclient.CreateTokenResponse, error) {
+	response, err := c.public.GenerateUserTokenWithResponse(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if response.JSON200 == nil {
+		return nil, decodeError(response.HTTPResponse, response.Body)
+	}
+	return response.JSON200, nil
+}
+
+func (c *Client) GetCurrentUser(ctx context.Context) (*apiclient.GetUserResponse, error) {
+	response, err := c.api.GetCurrentUserWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if response.JSON200 == nil {
+		return nil, decodeError(response.HTTPResponse, response.Body)
+	}
+	return response.JSON200, nil
+}
+
+// UpdateCurrentUser is not retried, PATCH is not idempotent for the server.
+func (c *Client) UpdateCurrentUser(ctx context.Context, params apiclient.UpdateUserRequest) (*apiclient.UpdateUserResponse, error) {
+	response, err := c.api.UpdateCurrentUserProfileWithResponse(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if response.JSON200 == nil {
+		return nil, decodeError(response.HTTPResponse, response.Body)
+	}
+	return response.JSON200, nil
+}
+
+// GetUserByID returns only the fields the user allows to be public.
+func (c *Client) GetUserByID(ctx context.Context, id openapi_types.UUID) (*apiclient.PublicUserResponse, error) {
+	response, err := c.public.GetUserByIDWithResponse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if response.JSON200 == nil {
+		return nil, decodeError(response.HTTPResponse, response.Body)
+	}
+	return response.JSON200, nil
+}
+
+// GetUserByUsername also finds the user by the old username for a grace period.
+func (c *Client) GetUserByUsername(ctx context.Context, username string) (*apiclient.UsernameResponse, error) {
+	response, err := c.public.GetUserByUsernameWithResponse(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if response.JSON200 == nil {
+		return nil, decodeError(response.HTTPResponse, response.Body)
+	}
+	return response.JSON200, nil
+}